@@ -0,0 +1,60 @@
+// Package blob stores opaque files such as car attachments. Storage is an
+// interface so deployments can keep files on local disk or in an
+// S3-compatible object store.
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// Storage keeps blobs under slash-separated keys. Get and Delete fail with
+// models.ErrNotFound for unknown keys.
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func notFound(key string) error {
+	return fmt.Errorf("blob %s: %w", key, models.ErrNotFound)
+}
+
+// NewStorageFromEnv builds the storage selected by BLOB_STORAGE: "local"
+// (the default) keeps files under BLOB_LOCAL_DIR, "s3" uses the bucket
+// described by the S3_* variables.
+func NewStorageFromEnv() (Storage, error) {
+	switch backend := os.Getenv("BLOB_STORAGE"); backend {
+	case "", "local":
+		dir := os.Getenv("BLOB_LOCAL_DIR")
+		if dir == "" {
+			dir = "data/blobs"
+		}
+		log.Printf("Storing blobs in %s", dir)
+		return NewLocalStorage(dir)
+	case "s3":
+		pathStyle := true
+		if v := os.Getenv("S3_PATH_STYLE"); v != "" {
+			var err error
+			if pathStyle, err = strconv.ParseBool(v); err != nil {
+				return nil, fmt.Errorf("invalid S3_PATH_STYLE %q", v)
+			}
+		}
+		return NewS3Storage(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       pathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORAGE %q", backend)
+	}
+}
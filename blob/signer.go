@@ -0,0 +1,53 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidSignature is returned for tampered or expired signed URLs.
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// URLSigner issues download links that need no other credentials. A link is
+// an HMAC over its path and expiry, so it cannot be reused for another file
+// or extended.
+type URLSigner struct {
+	secret []byte
+}
+
+func NewURLSigner(secret []byte) *URLSigner {
+	return &URLSigner{secret: secret}
+}
+
+// Sign returns path with expires and signature query parameters appended.
+func (s *URLSigner) Sign(path string, expires time.Time) string {
+	unix := strconv.FormatInt(expires.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", unix)
+	query.Set("signature", s.signature(path, unix))
+	return path + "?" + query.Encode()
+}
+
+// Verify checks the expires and signature parameters of a signed path.
+func (s *URLSigner) Verify(path string, query url.Values, now time.Time) error {
+	unix := query.Get("expires")
+	expires, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || now.Unix() > expires {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(query.Get("signature")), []byte(s.signature(path, unix))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *URLSigner) signature(path, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(path + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
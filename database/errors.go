@@ -0,0 +1,43 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes the stores translate into domain errors.
+const (
+	codeInvalidTextRepresentation = "22P02"
+	codeForeignKeyViolation       = "23503"
+	codeUniqueViolation           = "23505"
+)
+
+func IsUniqueViolation(err error) bool {
+	return hasCode(err, codeUniqueViolation)
+}
+
+func IsForeignKeyViolation(err error) bool {
+	return hasCode(err, codeForeignKeyViolation)
+}
+
+// IsInvalidInput reports a value Postgres could not parse, such as a
+// malformed UUID in a path parameter.
+func IsInvalidInput(err error) bool {
+	return hasCode(err, codeInvalidTextRepresentation)
+}
+
+func hasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// ViolatedTable names the table whose row caused a constraint violation, such
+// as the referencing table when a delete trips a RESTRICT foreign key.
+func ViolatedTable(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.TableName
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// Listen holds a connection from db listening on channel. It calls ready
+// once it is listening, then notify with the payload of each notification,
+// until ctx is cancelled or the connection fails. The connection is
+// discarded afterwards rather than returned to the pool still listening.
+func Listen(ctx context.Context, db *sql.DB, channel string, ready func(), notify func(payload string)) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var listenErr error
+	conn.Raw(func(driverConn any) error {
+		pgxConn := driverConn.(*stdlib.Conn).Conn()
+		if _, listenErr = pgxConn.Exec(ctx, "LISTEN "+channel); listenErr != nil {
+			return driver.ErrBadConn
+		}
+		ready()
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				listenErr = err
+				return driver.ErrBadConn
+			}
+			notify(notification.Payload)
+		}
+	})
+	return listenErr
+}
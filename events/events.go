@@ -0,0 +1,111 @@
+// Package events publishes the domain events relayed from the outbox to
+// other systems. Publisher is an interface so deployments can log events,
+// post them to an HTTP endpoint or fan them out to several destinations.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// Publisher delivers an event. An error makes the relay retry the event
+// later, so Publish may see the same event more than once.
+type Publisher interface {
+	Publish(ctx context.Context, event models.Event) error
+}
+
+// Publishers publishes each event to every publisher in turn. An event counts
+// as published only when all of them accepted it; a retry publishes it to
+// all of them again.
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, event models.Event) error {
+	var errs []error
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogPublisher writes events to the log. It is the default when no other
+// destination is configured.
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(ctx context.Context, event models.Event) error {
+	log.Printf("event %d: %s %s %s", event.ID, event.Type, event.AggregateType, event.AggregateID)
+	return nil
+}
+
+// HTTPPublisher posts each event as JSON to a URL. Any 2xx response accepts
+// the event. The X-Event-ID header lets the receiver drop redeliveries.
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// DefaultHTTPTimeout bounds a single delivery when no client is given.
+const DefaultHTTPTimeout = 10 * time.Second
+
+func NewHTTPPublisher(url string, client *http.Client) *HTTPPublisher {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	return &HTTPPublisher{url: url, client: client}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, event models.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatInt(event.ID, 10))
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("publish event %d: %s responded %s", event.ID, p.url, resp.Status)
+	}
+	return nil
+}
+
+// NewPublisherFromEnv posts events to EVENTS_PUBLISH_URL when it is set and
+// logs them otherwise.
+func NewPublisherFromEnv() (Publisher, error) {
+	target := os.Getenv("EVENTS_PUBLISH_URL")
+	if target == "" {
+		log.Printf("EVENTS_PUBLISH_URL is not set; events are only logged")
+		return LogPublisher{}, nil
+	}
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid EVENTS_PUBLISH_URL %q", target)
+	}
+	log.Printf("Publishing events to %s", u.Redacted())
+	return NewHTTPPublisher(target, nil), nil
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+func testEvent() models.Event {
+	return models.Event{
+		ID:            42,
+		Type:          models.EventCarCreated,
+		AggregateType: models.AggregateCar,
+		AggregateID:   uuid.MustParse("7f1a3c2e-0000-4000-8000-000000000001"),
+		Payload:       json.RawMessage(`{"name":"Test"}`),
+		OccurredAt:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestHTTPPublisherPostsEvent(t *testing.T) {
+	var got models.Event
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	event := testEvent()
+	if err := NewHTTPPublisher(server.URL, nil).Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if got.ID != event.ID || got.Type != event.Type || got.AggregateID != event.AggregateID {
+		t.Errorf("received %+v, want %+v", got, event)
+	}
+	if string(got.Payload) != string(event.Payload) {
+		t.Errorf("payload = %s, want %s", got.Payload, event.Payload)
+	}
+	if header.Get("X-Event-ID") != "42" || header.Get("X-Event-Type") != models.EventCarCreated {
+		t.Errorf("headers = %v", header)
+	}
+}
+
+func TestHTTPPublisherRejectsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if err := NewHTTPPublisher(server.URL, nil).Publish(context.Background(), testEvent()); err == nil {
+		t.Fatal("expected an error for a 503 response")
+	}
+}
+
+type failingPublisher struct{ calls int }
+
+func (p *failingPublisher) Publish(ctx context.Context, event models.Event) error {
+	p.calls++
+	return errors.New("unavailable")
+}
+
+func TestPublishersTriesEveryPublisher(t *testing.T) {
+	first, second := &failingPublisher{}, &failingPublisher{}
+	err := Publishers{first, LogPublisher{}, second}.Publish(context.Background(), testEvent())
+	if err == nil {
+		t.Fatal("expected the failures to be reported")
+	}
+	if first.calls != 1 || second.calls != 1 {
+		t.Errorf("calls = %d, %d, want 1, 1", first.calls, second.calls)
+	}
+}
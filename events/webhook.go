@@ -0,0 +1,119 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// Headers of a webhook request. The signature is an HMAC-SHA256, keyed with
+// the subscription's secret, of the timestamp, a dot and the body, so a
+// receiver can reject both forged and replayed requests.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// signaturePrefix names the algorithm so it can be changed later.
+const signaturePrefix = "sha256="
+
+var (
+	ErrMissingSignature = errors.New("webhook signature or timestamp missing")
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	ErrStaleSignature   = errors.New("webhook timestamp is outside the tolerance")
+)
+
+// SignWebhook returns the signature header value for body sent at timestamp.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks the signature of a received webhook request and that
+// it was signed within tolerance of now.
+func VerifyWebhook(secret string, header http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	signature, timestamp := header.Get(WebhookSignatureHeader), header.Get(WebhookTimestampHeader)
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrMissingSignature
+	}
+	signedAt := time.Unix(unix, 0)
+	if now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance {
+		return ErrStaleSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(SignWebhook(secret, signedAt, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// WebhookSender posts signed webhook deliveries.
+type WebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookSender(client *http.Client) *WebhookSender {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	return &WebhookSender{client: client, now: time.Now}
+}
+
+// Send posts a delivery once and reports the outcome. Failures are part of
+// the attempt rather than an error, so they can be logged with it.
+func (s *WebhookSender) Send(ctx context.Context, delivery models.WebhookDelivery) (attempt models.WebhookAttempt) {
+	start := s.now()
+	attempt.AttemptedAt = start
+	defer func() {
+		attempt.DurationMs = time.Since(start).Milliseconds()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "management-webhooks/1")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(start.Unix(), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(delivery.Secret, start, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	attempt.StatusCode = &status
+	if status < 200 || status > 299 {
+		// Keep the start of the response: it usually says what went wrong.
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		attempt.Error = strings.TrimSpace(fmt.Sprintf("%s %s", resp.Status, snippet))
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return attempt
+}
@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+const testSecret = "whsec_0123456789abcdef"
+
+func testDelivery(url string) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:        uuid.MustParse("7f1a3c2e-0000-4000-8000-000000000002"),
+		EventType: models.EventCarSold,
+		Payload:   []byte(`{"id":7,"type":"car.sold"}`),
+		URL:       url,
+		Secret:    testSecret,
+	}
+}
+
+// TestWebhookSenderSignsRequests runs a receiver that verifies signatures
+// the way a partner would.
+func TestWebhookSenderSignsRequests(t *testing.T) {
+	var verifyErr error
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		header = r.Header
+		if verifyErr = VerifyWebhook(testSecret, r.Header, body, time.Now(), 5*time.Minute); verifyErr != nil {
+			http.Error(w, verifyErr.Error(), http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := testDelivery(server.URL)
+	attempt := NewWebhookSender(nil).Send(context.Background(), delivery)
+	if verifyErr != nil {
+		t.Fatalf("receiver rejected the signature: %v", verifyErr)
+	}
+	if !attempt.Succeeded() {
+		t.Fatalf("attempt = %+v, want success", attempt)
+	}
+	if header.Get(WebhookEventHeader) != models.EventCarSold || header.Get(WebhookDeliveryHeader) != delivery.ID.String() {
+		t.Errorf("headers = %v", header)
+	}
+}
+
+func TestWebhookSenderReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "try later", http.StatusBadGateway)
+	}))
+	attempt := NewWebhookSender(nil).Send(context.Background(), testDelivery(server.URL))
+	server.Close()
+
+	if attempt.Succeeded() || attempt.StatusCode == nil || *attempt.StatusCode != http.StatusBadGateway {
+		t.Errorf("attempt = %+v, want a failed 502", attempt)
+	}
+	if attempt.Error == "" {
+		t.Error("the failure was not described")
+	}
+
+	// The server is gone, so there is no response at all.
+	attempt = NewWebhookSender(nil).Send(context.Background(), testDelivery(server.URL))
+	if attempt.Succeeded() || attempt.StatusCode != nil || attempt.Error == "" {
+		t.Errorf("attempt = %+v, want a failure without a status", attempt)
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signedAt := time.Unix(1714550400, 0)
+	header := http.Header{}
+	header.Set(WebhookTimestampHeader, "1714550400")
+	header.Set(WebhookSignatureHeader, SignWebhook(testSecret, signedAt, body))
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		now    time.Time
+		want   error
+	}{
+		{"valid", testSecret, body, signedAt.Add(time.Minute), nil},
+		{"wrong secret", "whsec_another-secret-value", body, signedAt, ErrInvalidSignature},
+		{"tampered body", testSecret, []byte(`{"id":2}`), signedAt, ErrInvalidSignature},
+		{"replayed", testSecret, body, signedAt.Add(time.Hour), ErrStaleSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhook(tt.secret, header, tt.body, tt.now, 5*time.Minute)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("VerifyWebhook error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := VerifyWebhook(testSecret, http.Header{}, body, signedAt, time.Minute); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("unsigned request error = %v, want ErrMissingSignature", err)
+	}
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"errors"
+	"log"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// Error codes reported in an error's extensions.
+const (
+	codeBadUserInput  = "BAD_USER_INPUT"
+	codeNotFound      = "NOT_FOUND"
+	codeConflict      = "CONFLICT"
+	codeInternal      = "INTERNAL_SERVER_ERROR"
+	codeQueryTooLarge = "QUERY_TOO_LARGE"
+)
+
+// codedError is an error with a code for clients to branch on, which
+// graphql-go reports as {"extensions": {"code": ...}}.
+type codedError struct {
+	error
+	code string
+}
+
+func (e codedError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// toError maps the model sentinel errors to error codes in the manner of
+// handler.WriteError. Anything unrecognised is logged and reported without
+// leaking details.
+func toError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		return codedError{err, codeBadUserInput}
+	case errors.Is(err, models.ErrNotFound):
+		return codedError{err, codeNotFound}
+	case errors.Is(err, models.ErrConflict):
+		return codedError{err, codeConflict}
+	default:
+		log.Printf("internal error: %v", err)
+		return codedError{errors.New("Internal server error"), codeInternal}
+	}
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// fakeCars is a car service listing the same cars for every brand.
+type fakeCars struct {
+	service.CarService
+	cars []models.Car
+}
+
+func (f fakeCars) GetCarsByBrand(ctx context.Context, filter models.CarFilter, currency string) ([]models.Car, error) {
+	return f.cars, nil
+}
+
+// fakeEngines is an engine service that records its batch lookups.
+type fakeEngines struct {
+	service.EngineService
+	engines map[string]models.Engine
+	batches [][]string
+}
+
+func (f *fakeEngines) GetEnginesByIDs(ctx context.Context, ids []string) ([]models.Engine, error) {
+	f.batches = append(f.batches, slices.Clone(ids))
+	var found []models.Engine
+	for _, id := range ids {
+		if engine, ok := f.engines[id]; ok {
+			found = append(found, engine)
+		}
+	}
+	return found, nil
+}
+
+func serve(t *testing.T, h *Handler, query string) (int, map[string]any) {
+	t.Helper()
+	body, _ := json.Marshal(Request{Query: query})
+	rec := httptest.NewRecorder()
+	h.Serve(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+
+	var resp map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return rec.Code, resp
+}
+
+func TestCarEnginesLoadInOneBatch(t *testing.T) {
+	ice := models.Engine{EngineID: uuid.New(), EngineSpec: models.EngineSpec{Powertrain: models.PowertrainICE}}
+	bev := models.Engine{EngineID: uuid.New(), EngineSpec: models.EngineSpec{Powertrain: models.PowertrainBEV}}
+	cars := fakeCars{}
+	for _, engine := range []models.Engine{ice, bev, ice, bev, ice} {
+		cars.cars = append(cars.cars, models.Car{ID: uuid.New(), Engine: models.Engine{EngineID: engine.EngineID}})
+	}
+	engines := &fakeEngines{engines: map[string]models.Engine{
+		ice.EngineID.String(): ice,
+		bev.EngineID.String(): bev,
+	}}
+	h, err := NewHandler(cars, engines)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	code, resp := serve(t, h, `{ cars(brand: "any", limit: 4) { total items { engine { id powertrain } } } }`)
+	if code != http.StatusOK || resp["errors"] != nil {
+		t.Fatalf("got %d %v", code, resp)
+	}
+
+	if len(engines.batches) != 1 || len(engines.batches[0]) != 2 {
+		t.Errorf("got batches %v, want one batch of 2 engines", engines.batches)
+	}
+	page := resp["data"].(map[string]any)["cars"].(map[string]any)
+	if page["total"] != float64(5) {
+		t.Errorf("got total %v, want 5", page["total"])
+	}
+	items := page["items"].([]any)
+	if len(items) != 4 {
+		t.Fatalf("got %d items, want 4", len(items))
+	}
+	for i, item := range items {
+		engine := item.(map[string]any)["engine"].(map[string]any)
+		if want := cars.cars[i].Engine.EngineID.String(); engine["id"] != want {
+			t.Errorf("item %d: got engine %v, want %s", i, engine["id"], want)
+		}
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	fullCar := `id name year brand model trim location condition odometerKm price currency totalPrice
+		engine { id powertrain displacement numberOfCylinders carRange powerKw torqueNm batteryCapacityKwh }`
+	for name, tc := range map[string]struct {
+		query string
+		ok    bool
+	}{
+		"small page":    {`{ cars(brand: "a") { items { ` + fullCar + ` } } }`, true},
+		"introspection": {`{ __schema { types { fields { type { ofType { ofType { ofType { ofType { name } } } } } } } } }`, true},
+		"too deep":      {`{ a { b { c { d { e { f { g { h { i } } } } } } } } }`, false},
+		"too complex": {`{
+			a: cars(brand: "a", limit: 100) { items { ...car } }
+			b: cars(brand: "b", limit: 100) { items { ...car } }
+			c: cars(brand: "c", limit: 100) { items { ...car } }
+			d: cars(brand: "d", limit: 100) { items { ...car } }
+			e: cars(brand: "e", limit: 100) { items { ...car } }
+		}
+		fragment car on Car { ` + fullCar + ` }`, false},
+		"nested spreads": {`{ cars(brand: "a") { items { ...f0 } } }` + nestedSpreads(40), false},
+	} {
+		doc, err := parser.Parse(parser.ParseParams{Source: tc.query})
+		if err != nil {
+			t.Fatalf("%s: parse: %v", name, err)
+		}
+		if err := checkLimits(doc, nil); (err == nil) != tc.ok {
+			t.Errorf("%s: got %v, want ok %v", name, err, tc.ok)
+		}
+	}
+}
+
+// nestedSpreads defines fragments f0 to fn in which each one spreads the next
+// twice, so walking every spread would take 2^n steps.
+func nestedSpreads(n int) string {
+	var b strings.Builder
+	for i := range n {
+		fmt.Fprintf(&b, "\nfragment f%d on Car { ...f%d ...f%d }", i, i+1, i+1)
+	}
+	fmt.Fprintf(&b, "\nfragment f%d on Car { id }", n)
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/service"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// maxRequestSize bounds the body of a GraphQL request.
+const maxRequestSize = 1 << 20
+
+type Handler struct {
+	schema  graphql.Schema
+	engines service.EngineService
+}
+
+func NewHandler(cars service.CarService, engines service.EngineService) (*Handler, error) {
+	schema, err := NewSchema(cars, engines)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, engines: engines}, nil
+}
+
+// Request is a GraphQL request in the usual JSON form.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Serve runs a GraphQL query or mutation. Requests that cannot be run at
+// all, because they do not parse, fail validation or exceed the query
+// limits, are answered with 400; otherwise the response is 200 with any
+// field errors alongside the data, as GraphQL clients expect.
+func (h *Handler) Serve(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		writeErrors(w, gqlerrors.FormatErrors(errors.New("Invalid request body")))
+		return
+	}
+	if req.Query == "" {
+		writeErrors(w, gqlerrors.FormatErrors(errors.New("query is required")))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		writeErrors(w, gqlerrors.FormatErrors(err))
+		return
+	}
+	if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		writeErrors(w, validation.Errors)
+		return
+	}
+	if err := checkLimits(doc, req.Variables); err != nil {
+		writeErrors(w, []gqlerrors.FormattedError{gqlerrors.FormatError(gqlerrors.NewLocatedError(err, nil))})
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(r.Context(), newLoaders(h.engines)),
+	})
+	handler.WriteJSON(w, http.StatusOK, result)
+}
+
+func writeErrors(w http.ResponseWriter, errs []gqlerrors.FormattedError) {
+	handler.WriteJSON(w, http.StatusBadRequest, graphql.Result{Errors: errs})
+}
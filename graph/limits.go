@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Query limits. The depth of a query is how deeply its fields nest. Its
+// complexity counts one for every field it selects, with the selections
+// under a paged listing counted once per item the page may hold, so a page
+// of 100 cars with every field and their engines costs about 5,000.
+const (
+	maxDepth      = 8
+	maxComplexity = 10000
+)
+
+// checkLimits rejects a query that nests too deeply or would select too
+// much. The document must already have been validated, which rules out
+// fragment cycles. Introspection is not counted, since its size is bounded
+// by the schema rather than the data.
+func checkLimits(doc *ast.Document, variables map[string]any) error {
+	a := analysis{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		spreads:   make(map[spread]cost),
+		variables: variables,
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		operation, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, complexity := a.selectionSet(operation.SelectionSet, 1)
+		if depth > maxDepth {
+			return codedError{fmt.Errorf("query depth %d exceeds the limit of %d", depth, maxDepth), codeQueryTooLarge}
+		}
+		if complexity > maxComplexity {
+			return codedError{fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, maxComplexity), codeQueryTooLarge}
+		}
+	}
+	return nil
+}
+
+type analysis struct {
+	fragments map[string]*ast.FragmentDefinition
+	// spreads remembers the cost of each fragment at each level, since a
+	// fragment spread repeatedly through nested fragments would otherwise be
+	// walked an exponential number of times.
+	spreads   map[spread]cost
+	variables map[string]any
+}
+
+type spread struct {
+	name  string
+	level int
+}
+
+type cost struct {
+	depth, complexity int
+}
+
+// selectionSet returns the depth and complexity of a selection set whose
+// fields are at the given level.
+func (a analysis) selectionSet(set *ast.SelectionSet, level int) (depth, complexity int) {
+	if set == nil {
+		return level - 1, 0
+	}
+	depth = level - 1
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			d, c = a.selectionSet(selection.SelectionSet, level+1)
+			c = 1 + c*a.multiplier(selection)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(selection.SelectionSet, level)
+		case *ast.FragmentSpread:
+			d, c = a.fragmentSpread(selection.Name.Value, level)
+		}
+		depth = max(depth, d)
+		// Stop counting once over the limit so that repeated spreads cannot
+		// overflow the total
+		complexity = min(complexity+c, maxComplexity+1)
+	}
+	return depth, complexity
+}
+
+func (a analysis) fragmentSpread(name string, level int) (depth, complexity int) {
+	key := spread{name: name, level: level}
+	if known, ok := a.spreads[key]; ok {
+		return known.depth, known.complexity
+	}
+	fragment, ok := a.fragments[name]
+	if !ok {
+		return level - 1, 0
+	}
+	depth, complexity = a.selectionSet(fragment.SelectionSet, level)
+	a.spreads[key] = cost{depth: depth, complexity: complexity}
+	return depth, complexity
+}
+
+// multiplier is how many times a field's selections are counted: the page
+// size for a paged listing and once for anything else.
+func (a analysis) multiplier(field *ast.Field) int {
+	if !pagedFields[field.Name.Value] {
+		return 1
+	}
+	limit := models.DefaultPageLimit
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				limit = n
+			}
+		case *ast.Variable:
+			switch n := a.variables[value.Name.Value].(type) {
+			case float64:
+				limit = int(n)
+			case int:
+				limit = n
+			}
+		}
+	}
+	// Larger pages are refused by the resolver anyway
+	return min(max(limit, 1), models.MaxPageLimit)
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+)
+
+// loader batches lookups by key in the manner of DataLoader. Load only
+// queues a key and returns a thunk; the executor resolves thunks once the
+// whole level of the query has been walked, so the first thunk called
+// fetches every key queued by then in one go. Results are kept for the rest
+// of the request.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+	// name describes a missing value in its not found error.
+	name string
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	results map[string]result[V]
+}
+
+type result[V any] struct {
+	value V
+	err   error
+}
+
+func newLoader[V any](name string, fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		name:    name,
+		queued:  make(map[string]bool),
+		results: make(map[string]result[V]),
+	}
+}
+
+// Load queues key and returns a thunk for its value, in the form graphql-go
+// resolves after the current level.
+func (l *loader[V]) Load(ctx context.Context, key string) func() (any, error) {
+	l.mu.Lock()
+	if _, done := l.results[key]; !done && !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, done := l.results[key]; !done {
+			l.dispatch(ctx)
+		}
+		r := l.results[key]
+		return r.value, r.err
+	}
+}
+
+// dispatch fetches every queued key. l.mu must be held.
+func (l *loader[V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	clear(l.queued)
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		switch value, ok := values[key]; {
+		case err != nil:
+			l.results[key] = result[V]{err: err}
+		case !ok:
+			l.results[key] = result[V]{err: fmt.Errorf("%s %s: %w", l.name, key, models.ErrNotFound)}
+		default:
+			l.results[key] = result[V]{value: value}
+		}
+	}
+}
+
+// loaders are the loaders of one request. They cache what they load, so a
+// new set is made for every request.
+type loaders struct {
+	engines *loader[models.Engine]
+}
+
+func newLoaders(engines service.EngineService) *loaders {
+	return &loaders{
+		engines: newLoader("engine", func(ctx context.Context, ids []string) (map[string]models.Engine, error) {
+			found, err := engines.GetEnginesByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]models.Engine, len(found))
+			for _, engine := range found {
+				byID[engine.EngineID.String()] = engine
+			}
+			return byID, nil
+		}),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
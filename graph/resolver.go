@@ -0,0 +1,261 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+type resolver struct {
+	cars    service.CarService
+	engines service.EngineService
+}
+
+func (r *resolver) car(p graphql.ResolveParams) (any, error) {
+	car, err := r.cars.GetCarByID(p.Context, p.Args["id"].(string))
+	if err != nil {
+		return nil, toError(err)
+	}
+	return car, nil
+}
+
+// listCars pages through the cars matching the filter. The engines are left
+// to the loader, so the listing does not join them in.
+func (r *resolver) listCars(p graphql.ResolveParams) (any, error) {
+	limit, offset := p.Args["limit"].(int), p.Args["offset"].(int)
+	if err := models.ValidatePagination(limit, offset); err != nil {
+		return nil, toError(models.ValidationError(err))
+	}
+
+	filter := models.CarFilter{
+		Brand:           p.Args["brand"].(string),
+		Options:         stringList(p.Args["options"]),
+		Condition:       stringArg(p.Args, "condition"),
+		MinOdometerKm:   int64Arg(p.Args, "minOdometerKm"),
+		MaxOdometerKm:   int64Arg(p.Args, "maxOdometerKm"),
+		MinGrade:        intArg(p.Args, "minGrade"),
+		MaxOwners:       intArg(p.Args, "maxOwners"),
+		AccidentHistory: stringArg(p.Args, "accidentHistory"),
+		Certified:       boolArg(p.Args, "certified"),
+	}
+	cars, err := r.cars.GetCarsByBrand(p.Context, filter, stringArg(p.Args, "currency"))
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	page := models.Page[models.Car]{Items: []models.Car{}, Total: int64(len(cars)), Limit: limit, Offset: offset}
+	if offset < len(cars) {
+		page.Items = cars[offset:min(offset+limit, len(cars))]
+	}
+	return page, nil
+}
+
+func (r *resolver) engine(p graphql.ResolveParams) (any, error) {
+	engine, err := r.engines.GetEngineByID(p.Context, p.Args["id"].(string))
+	if err != nil {
+		return nil, toError(err)
+	}
+	return engine, nil
+}
+
+func (r *resolver) listEngines(p graphql.ResolveParams) (any, error) {
+	filter := models.EngineFilter{
+		Powertrain:      stringArg(p.Args, "powertrain"),
+		MinDisplacement: int64Arg(p.Args, "minDisplacement"),
+		MaxDisplacement: int64Arg(p.Args, "maxDisplacement"),
+		Cylinders:       int64Arg(p.Args, "cylinders"),
+		MinRange:        int64Arg(p.Args, "minRange"),
+		MaxRange:        int64Arg(p.Args, "maxRange"),
+		Sort:            stringArg(p.Args, "sort"),
+		Limit:           p.Args["limit"].(int),
+		Offset:          p.Args["offset"].(int),
+	}
+	if descending := boolArg(p.Args, "descending"); descending != nil {
+		filter.Descending = *descending
+	}
+	page, err := r.engines.ListEngines(p.Context, filter)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return page, nil
+}
+
+func (r *resolver) createCar(p graphql.ResolveParams) (any, error) {
+	input, err := carRequestFromInput(p.Args["input"].(map[string]any))
+	if err != nil {
+		return nil, toError(models.ValidationError(err))
+	}
+	car, err := r.cars.CreateCar(p.Context, &input)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return car, nil
+}
+
+func (r *resolver) updateCar(p graphql.ResolveParams) (any, error) {
+	input, err := carRequestFromInput(p.Args["input"].(map[string]any))
+	if err != nil {
+		return nil, toError(models.ValidationError(err))
+	}
+	car, err := r.cars.UpdateCar(p.Context, p.Args["id"].(string), &input)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return car, nil
+}
+
+func (r *resolver) deleteCar(p graphql.ResolveParams) (any, error) {
+	if err := r.cars.DeleteCar(p.Context, p.Args["id"].(string)); err != nil {
+		return nil, toError(err)
+	}
+	return true, nil
+}
+
+func (r *resolver) createEngine(p graphql.ResolveParams) (any, error) {
+	input := models.EngineRequest{EngineSpec: engineSpecFromInput(p.Args["input"].(map[string]any))}
+	engine, err := r.engines.CreateEngine(p.Context, &input)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return engine, nil
+}
+
+func (r *resolver) updateEngine(p graphql.ResolveParams) (any, error) {
+	input := models.EngineRequest{EngineSpec: engineSpecFromInput(p.Args["input"].(map[string]any))}
+	engine, err := r.engines.UpdateEngine(p.Context, p.Args["id"].(string), &input)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return engine, nil
+}
+
+func (r *resolver) deleteEngine(p graphql.ResolveParams) (any, error) {
+	if err := r.engines.DeleteEngine(p.Context, p.Args["id"].(string)); err != nil {
+		return nil, toError(err)
+	}
+	return true, nil
+}
+
+// carRequestFromInput parses the fields the REST API would have decoded
+// from JSON. Everything else is left to the service's validation.
+func carRequestFromInput(input map[string]any) (models.CarRequest, error) {
+	req := models.CarRequest{
+		Name:               input["name"].(string),
+		Year:               input["year"].(string),
+		Brand:              input["brand"].(string),
+		Model:              stringArg(input, "model"),
+		Trim:               stringArg(input, "trim"),
+		Condition:          stringArg(input, "condition"),
+		ConditionGrade:     intArg(input, "conditionGrade"),
+		AccidentHistory:    stringArg(input, "accidentHistory"),
+		RegistrationNumber: stringArg(input, "registrationNumber"),
+		RegistrationRegion: stringArg(input, "registrationRegion"),
+		FuelType:           input["fuelType"].(string),
+		Currency:           input["currency"].(string),
+	}
+	if odometer := int64Arg(input, "odometerKm"); odometer != nil {
+		req.OdometerKm = *odometer
+	}
+	if owners := intArg(input, "previousOwners"); owners != nil {
+		req.PreviousOwners = *owners
+	}
+	if engine, ok := input["engine"].(map[string]any); ok {
+		req.Engine.EngineSpec = engineSpecFromInput(engine)
+	}
+
+	if v := stringArg(input, "locationId"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return models.CarRequest{}, errors.New("locationId must be a UUID")
+		}
+		req.LocationID = &id
+	}
+	if v := stringArg(input, "engineId"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return models.CarRequest{}, errors.New("engineId must be a UUID")
+		}
+		req.Engine.EngineID = id
+	}
+	if v := stringArg(input, "registrationDate"); v != "" {
+		date, err := models.ParseDate(v)
+		if err != nil {
+			return models.CarRequest{}, err
+		}
+		req.RegistrationDate = &date
+	}
+	price, err := models.ParseMoney(input["price"].(string))
+	if err != nil {
+		return models.CarRequest{}, err
+	}
+	req.Price = price
+	return req, nil
+}
+
+func engineSpecFromInput(input map[string]any) models.EngineSpec {
+	spec := models.EngineSpec{
+		Powertrain:     stringArg(input, "powertrain"),
+		EmissionsClass: stringArg(input, "emissionsClass"),
+	}
+	for name, dest := range map[string]*int64{
+		"displacement":      &spec.Displacement,
+		"numberOfCylinders": &spec.NumberOfCylinders,
+		"carRange":          &spec.CarRange,
+		"powerKw":           &spec.PowerKW,
+		"torqueNm":          &spec.TorqueNm,
+		"co2GPerKm":         &spec.CO2GPerKm,
+	} {
+		if v := int64Arg(input, name); v != nil {
+			*dest = *v
+		}
+	}
+	if v, ok := input["batteryCapacityKwh"].(float64); ok {
+		spec.BatteryCapacityKWh = v
+	}
+	return spec
+}
+
+// Arguments and input fields that were not given are absent from their
+// maps; these read them as empty values or nil pointers.
+
+func stringArg(args map[string]any, name string) string {
+	v, _ := args[name].(string)
+	return v
+}
+
+func intArg(args map[string]any, name string) *int {
+	v, ok := args[name].(int)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func int64Arg(args map[string]any, name string) *int64 {
+	v, ok := args[name].(int)
+	if !ok {
+		return nil
+	}
+	n := int64(v)
+	return &n
+}
+
+func boolArg(args map[string]any, name string) *bool {
+	v, ok := args[name].(bool)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		values = append(values, item.(string))
+	}
+	return values
+}
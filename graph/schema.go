@@ -0,0 +1,359 @@
+// Package graph serves cars and engines over GraphQL, on top of the same
+// services as the REST handlers.
+package graph
+
+import (
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	nonNullID       = graphql.NewNonNull(graphql.ID)
+	nonNullString   = graphql.NewNonNull(graphql.String)
+	nonNullInt      = graphql.NewNonNull(graphql.Int)
+	nonNullFloat    = graphql.NewNonNull(graphql.Float)
+	nonNullBoolean  = graphql.NewNonNull(graphql.Boolean)
+	nonNullDateTime = graphql.NewNonNull(graphql.DateTime)
+)
+
+var engineSpecFields = map[string]func(models.EngineSpec) any{
+	"powertrain":         func(s models.EngineSpec) any { return s.Powertrain },
+	"displacement":       func(s models.EngineSpec) any { return s.Displacement },
+	"numberOfCylinders":  func(s models.EngineSpec) any { return s.NumberOfCylinders },
+	"carRange":           func(s models.EngineSpec) any { return s.CarRange },
+	"powerKw":            func(s models.EngineSpec) any { return s.PowerKW },
+	"torqueNm":           func(s models.EngineSpec) any { return s.TorqueNm },
+	"batteryCapacityKwh": func(s models.EngineSpec) any { return s.BatteryCapacityKWh },
+	"emissionsClass":     func(s models.EngineSpec) any { return s.EmissionsClass },
+	"co2GPerKm":          func(s models.EngineSpec) any { return s.CO2GPerKm },
+}
+
+var engineType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Engine",
+	Fields: func() graphql.Fields {
+		fields := graphql.Fields{
+			"id":        {Type: nonNullID, Resolve: fromEngine(func(e models.Engine) any { return e.EngineID.String() })},
+			"createdAt": {Type: graphql.DateTime, Resolve: fromEngine(func(e models.Engine) any { return optionalTime(e.CreatedAt) })},
+			"updatedAt": {Type: graphql.DateTime, Resolve: fromEngine(func(e models.Engine) any { return optionalTime(e.UpdatedAt) })},
+		}
+		for name, get := range engineSpecFields {
+			fields[name] = &graphql.Field{
+				Type:    specFieldType(name),
+				Resolve: fromEngine(func(e models.Engine) any { return get(e.EngineSpec) }),
+			}
+		}
+		return fields
+	}(),
+})
+
+// specFieldType is the type of an engine spec field.
+func specFieldType(name string) graphql.Output {
+	switch name {
+	case "powertrain", "emissionsClass":
+		return nonNullString
+	case "batteryCapacityKwh":
+		return nonNullFloat
+	default:
+		return nonNullInt
+	}
+}
+
+var carOptionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "CarOption",
+	Fields: graphql.Fields{
+		"id":       {Type: nonNullID, Resolve: fromCarOption(func(o models.CarOption) any { return o.OptionID.String() })},
+		"code":     {Type: nonNullString, Resolve: fromCarOption(func(o models.CarOption) any { return o.Code })},
+		"name":     {Type: nonNullString, Resolve: fromCarOption(func(o models.CarOption) any { return o.Name })},
+		"category": {Type: nonNullString, Resolve: fromCarOption(func(o models.CarOption) any { return o.Category })},
+		"price":    {Type: nonNullString, Resolve: fromCarOption(func(o models.CarOption) any { return o.Price.String() })},
+	},
+})
+
+var convertedPriceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ConvertedPrice",
+	Fields: graphql.Fields{
+		"amount":        {Type: nonNullString, Resolve: fromConvertedPrice(func(c models.ConvertedPrice) any { return c.Amount.String() })},
+		"currency":      {Type: nonNullString, Resolve: fromConvertedPrice(func(c models.ConvertedPrice) any { return c.Currency })},
+		"rate":          {Type: nonNullString, Resolve: fromConvertedPrice(func(c models.ConvertedPrice) any { return c.Rate.String() })},
+		"effectiveDate": {Type: nonNullString, Resolve: fromConvertedPrice(func(c models.ConvertedPrice) any { return c.EffectiveDate.String() })},
+	},
+})
+
+// carType is a car. Amounts of money are strings in the decimal form of the
+// REST API so that they are not rounded through floating point.
+var carType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Car",
+	Fields: graphql.Fields{
+		"id":                 {Type: nonNullID, Resolve: fromCar(func(c models.Car) any { return c.ID.String() })},
+		"name":               {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.Name })},
+		"year":               {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.Year })},
+		"brand":              {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.Brand })},
+		"brandId":            {Type: nonNullID, Resolve: fromCar(func(c models.Car) any { return c.BrandID.String() })},
+		"model":              {Type: graphql.String, Resolve: fromCar(func(c models.Car) any { return c.Model })},
+		"modelId":            {Type: graphql.ID, Resolve: fromCar(func(c models.Car) any { return optionalID(c.ModelID) })},
+		"trim":               {Type: graphql.String, Resolve: fromCar(func(c models.Car) any { return c.Trim })},
+		"trimId":             {Type: graphql.ID, Resolve: fromCar(func(c models.Car) any { return optionalID(c.TrimID) })},
+		"locationId":         {Type: graphql.ID, Resolve: fromCar(func(c models.Car) any { return optionalID(c.LocationID) })},
+		"location":           {Type: graphql.String, Resolve: fromCar(func(c models.Car) any { return c.Location })},
+		"inTransit":          {Type: nonNullBoolean, Resolve: fromCar(func(c models.Car) any { return c.InTransit })},
+		"condition":          {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.Condition })},
+		"odometerKm":         {Type: nonNullInt, Resolve: fromCar(func(c models.Car) any { return c.OdometerKm })},
+		"conditionGrade":     {Type: graphql.Int, Resolve: fromCar(func(c models.Car) any { return c.ConditionGrade })},
+		"previousOwners":     {Type: nonNullInt, Resolve: fromCar(func(c models.Car) any { return c.PreviousOwners })},
+		"accidentHistory":    {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.AccidentHistory })},
+		"registrationNumber": {Type: graphql.String, Resolve: fromCar(func(c models.Car) any { return c.RegistrationNumber })},
+		"registrationDate":   {Type: graphql.String, Resolve: fromCar(func(c models.Car) any { return optionalDate(c.RegistrationDate) })},
+		"registrationRegion": {Type: graphql.String, Resolve: fromCar(func(c models.Car) any { return c.RegistrationRegion })},
+		"certifiedPreOwned":  {Type: nonNullBoolean, Resolve: fromCar(func(c models.Car) any { return c.CertifiedPreOwned })},
+		"fuelType":           {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.FuelType })},
+		"price":              {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.Price.String() })},
+		"currency":           {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.Currency })},
+		"totalPrice":         {Type: nonNullString, Resolve: fromCar(func(c models.Car) any { return c.TotalPrice.String() })},
+		"options":            {Type: graphql.NewList(graphql.NewNonNull(carOptionType)), Resolve: fromCar(func(c models.Car) any { return c.Options })},
+		"convertedPrice":     {Type: convertedPriceType, Resolve: fromCar(func(c models.Car) any { return optionalConvertedPrice(c.ConvertedPrice) })},
+		"createdAt":          {Type: nonNullDateTime, Resolve: fromCar(func(c models.Car) any { return c.CreatedAt })},
+		"updatedAt":          {Type: nonNullDateTime, Resolve: fromCar(func(c models.Car) any { return c.UpdatedAt })},
+		// The engine is loaded for all the cars in a response at once
+		"engine": {
+			Type: graphql.NewNonNull(engineType),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				car := p.Source.(models.Car)
+				load := loadersFromContext(p.Context).engines.Load(p.Context, car.Engine.EngineID.String())
+				return func() (any, error) {
+					engine, err := load()
+					if err != nil {
+						return nil, toError(err)
+					}
+					return engine, nil
+				}, nil
+			},
+		},
+	},
+})
+
+// pageType is a page of a listing, in the form of models.Page.
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items":  {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"total":  {Type: nonNullInt},
+			"limit":  {Type: nonNullInt},
+			"offset": {Type: nonNullInt},
+		},
+	})
+}
+
+var (
+	carPageType    = pageType("CarPage", carType)
+	enginePageType = pageType("EnginePage", engineType)
+)
+
+var engineInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "EngineInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"powertrain":         {Type: graphql.String},
+		"displacement":       {Type: graphql.Int},
+		"numberOfCylinders":  {Type: graphql.Int},
+		"carRange":           {Type: graphql.Int},
+		"powerKw":            {Type: graphql.Int},
+		"torqueNm":           {Type: graphql.Int},
+		"batteryCapacityKwh": {Type: graphql.Float},
+		"emissionsClass":     {Type: graphql.String},
+		"co2GPerKm":          {Type: graphql.Int},
+	},
+})
+
+// carInputType mirrors models.CarRequest. A car either names an existing
+// engine by engineId or describes a new one in engine.
+var carInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CarInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":               {Type: nonNullString},
+		"year":               {Type: nonNullString},
+		"brand":              {Type: nonNullString},
+		"model":              {Type: graphql.String},
+		"trim":               {Type: graphql.String},
+		"locationId":         {Type: graphql.ID},
+		"condition":          {Type: graphql.String},
+		"odometerKm":         {Type: graphql.Int},
+		"conditionGrade":     {Type: graphql.Int},
+		"previousOwners":     {Type: graphql.Int},
+		"accidentHistory":    {Type: graphql.String},
+		"registrationNumber": {Type: graphql.String},
+		"registrationDate":   {Type: graphql.String},
+		"registrationRegion": {Type: graphql.String},
+		"fuelType":           {Type: nonNullString},
+		"engineId":           {Type: graphql.ID},
+		"engine":             {Type: engineInputType},
+		"price":              {Type: nonNullString},
+		"currency":           {Type: nonNullString},
+	},
+})
+
+// pagedFields are the listings that take a limit and offset.
+var pagedFields = map[string]bool{"cars": true, "engines": true}
+
+// NewSchema returns the schema for the car and engine services.
+func NewSchema(cars service.CarService, engines service.EngineService) (graphql.Schema, error) {
+	r := &resolver{cars: cars, engines: engines}
+
+	pageArgs := graphql.FieldConfigArgument{
+		"limit":  {Type: graphql.Int, DefaultValue: models.DefaultPageLimit},
+		"offset": {Type: graphql.Int, DefaultValue: 0},
+	}
+	withPageArgs := func(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		for name, arg := range pageArgs {
+			args[name] = arg
+		}
+		return args
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"car": {
+				Type:    carType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: nonNullID}},
+				Resolve: r.car,
+			},
+			"cars": {
+				Type: graphql.NewNonNull(carPageType),
+				Args: withPageArgs(graphql.FieldConfigArgument{
+					"brand":           {Type: nonNullString},
+					"options":         {Type: graphql.NewList(nonNullString)},
+					"condition":       {Type: graphql.String},
+					"minOdometerKm":   {Type: graphql.Int},
+					"maxOdometerKm":   {Type: graphql.Int},
+					"minGrade":        {Type: graphql.Int},
+					"maxOwners":       {Type: graphql.Int},
+					"accidentHistory": {Type: graphql.String},
+					"certified":       {Type: graphql.Boolean},
+					"currency":        {Type: graphql.String},
+				}),
+				Resolve: r.listCars,
+			},
+			"engine": {
+				Type:    engineType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: nonNullID}},
+				Resolve: r.engine,
+			},
+			"engines": {
+				Type: graphql.NewNonNull(enginePageType),
+				Args: withPageArgs(graphql.FieldConfigArgument{
+					"powertrain":      {Type: graphql.String},
+					"minDisplacement": {Type: graphql.Int},
+					"maxDisplacement": {Type: graphql.Int},
+					"cylinders":       {Type: graphql.Int},
+					"minRange":        {Type: graphql.Int},
+					"maxRange":        {Type: graphql.Int},
+					"sort":            {Type: graphql.String},
+					"descending":      {Type: graphql.Boolean},
+				}),
+				Resolve: r.listEngines,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createCar": {
+				Type:    graphql.NewNonNull(carType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(carInputType)}},
+				Resolve: r.createCar,
+			},
+			"updateCar": {
+				Type: graphql.NewNonNull(carType),
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: nonNullID},
+					"input": {Type: graphql.NewNonNull(carInputType)},
+				},
+				Resolve: r.updateCar,
+			},
+			"deleteCar": {
+				Type:    nonNullBoolean,
+				Args:    graphql.FieldConfigArgument{"id": {Type: nonNullID}},
+				Resolve: r.deleteCar,
+			},
+			"createEngine": {
+				Type:    graphql.NewNonNull(engineType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(engineInputType)}},
+				Resolve: r.createEngine,
+			},
+			"updateEngine": {
+				Type: graphql.NewNonNull(engineType),
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: nonNullID},
+					"input": {Type: graphql.NewNonNull(engineInputType)},
+				},
+				Resolve: r.updateEngine,
+			},
+			"deleteEngine": {
+				Type:    nonNullBoolean,
+				Args:    graphql.FieldConfigArgument{"id": {Type: nonNullID}},
+				Resolve: r.deleteEngine,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func fromCar(get func(models.Car) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(models.Car)), nil
+	}
+}
+
+func fromEngine(get func(models.Engine) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(models.Engine)), nil
+	}
+}
+
+func fromCarOption(get func(models.CarOption) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(models.CarOption)), nil
+	}
+}
+
+func fromConvertedPrice(get func(models.ConvertedPrice) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(models.ConvertedPrice)), nil
+	}
+}
+
+func optionalID(id *uuid.UUID) any {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+func optionalDate(date *models.Date) any {
+	if date == nil {
+		return nil
+	}
+	return date.String()
+}
+
+// optionalTime is null for the zero time, which engines loaded without
+// their timestamps have.
+func optionalTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func optionalConvertedPrice(price *models.ConvertedPrice) any {
+	if price == nil {
+		return nil
+	}
+	return *price
+}
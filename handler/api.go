@@ -1 +1,53 @@
-package handle
\ No newline at end of file
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// WriteJSON writes v as a JSON response with the given status code.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v", err)
+	}
+}
+
+// WriteError maps the model sentinel errors to HTTP status codes. Anything
+// unrecognised is logged and reported as a 500 without leaking details.
+func WriteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		log.Printf("internal error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// ParsePagination reads the limit and offset query parameters, defaulting to
+// the first page. Range checks are left to the models.
+func ParsePagination(r *http.Request) (limit, offset int, err error) {
+	limit = models.DefaultPageLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return 0, 0, errors.New("limit must be an integer")
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil {
+			return 0, 0, errors.New("offset must be an integer")
+		}
+	}
+	return limit, offset, nil
+}
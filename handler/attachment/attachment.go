@@ -0,0 +1,114 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/LikhithMar14/management/blob"
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	attachmentService "github.com/LikhithMar14/management/service/attachment"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxUploadSize leaves room for the multipart framing and form fields around
+// the file itself.
+const maxUploadSize = models.MaxAttachmentSize + 1<<20
+
+type AttachmentHandler struct {
+	service service.AttachmentService
+}
+
+func NewAttachmentHandler(service service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service: service}
+}
+
+// UploadAttachment accepts a multipart form with a "file" part and an
+// optional "kind" field.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "File must be at most 10 MiB", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "A file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.UploadAttachment(r.Context(), chi.URLParam(r, "id"), r.FormValue("kind"),
+		header.Filename, file, middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, attachment)
+}
+
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	attachments, err := h.service.ListAttachments(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, attachments)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DeleteAttachment(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "attachmentID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DownloadContent and DownloadThumbnail serve signed URLs and are mounted
+// outside the authenticated routes: the signature is the credential.
+func (h *AttachmentHandler) DownloadContent(w http.ResponseWriter, r *http.Request) {
+	h.download(w, r, attachmentService.VariantContent)
+}
+
+func (h *AttachmentHandler) DownloadThumbnail(w http.ResponseWriter, r *http.Request) {
+	h.download(w, r, attachmentService.VariantThumbnail)
+}
+
+func (h *AttachmentHandler) download(w http.ResponseWriter, r *http.Request, variant string) {
+	attachment, content, err := h.service.OpenAttachment(r.Context(), chi.URLParam(r, "id"), variant, r.URL.Path, r.URL.Query())
+	if err != nil {
+		if errors.Is(err, blob.ErrInvalidSignature) {
+			http.Error(w, "Link is invalid or has expired", http.StatusForbidden)
+			return
+		}
+		handler.WriteError(w, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if variant == attachmentService.VariantContent {
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("failed to send attachment %s: %v", attachment.ID, err)
+	}
+}
@@ -0,0 +1,179 @@
+package brand
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type BrandHandler struct {
+	service service.BrandService
+}
+
+func NewBrandHandler(service service.BrandService) *BrandHandler {
+	return &BrandHandler{service: service}
+}
+
+func (h *BrandHandler) ListBrands(w http.ResponseWriter, r *http.Request) {
+	brands, err := h.service.ListBrands(r.Context())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, brands)
+}
+
+func (h *BrandHandler) GetBrandByID(w http.ResponseWriter, r *http.Request) {
+	brand, err := h.service.GetBrandByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, brand)
+}
+
+func (h *BrandHandler) CreateBrand(w http.ResponseWriter, r *http.Request) {
+	var req models.BrandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	brand, err := h.service.CreateBrand(r.Context(), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, brand)
+}
+
+func (h *BrandHandler) UpdateBrand(w http.ResponseWriter, r *http.Request) {
+	var req models.BrandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	brand, err := h.service.UpdateBrand(r.Context(), chi.URLParam(r, "id"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, brand)
+}
+
+func (h *BrandHandler) DeleteBrand(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteBrand(r.Context(), chi.URLParam(r, "id")); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BrandHandler) ListModels(w http.ResponseWriter, r *http.Request) {
+	vehicleModels, err := h.service.ListModels(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, vehicleModels)
+}
+
+func (h *BrandHandler) GetModelByID(w http.ResponseWriter, r *http.Request) {
+	model, err := h.service.GetModelByID(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, model)
+}
+
+func (h *BrandHandler) CreateModel(w http.ResponseWriter, r *http.Request) {
+	var req models.VehicleModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	model, err := h.service.CreateModel(r.Context(), chi.URLParam(r, "id"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, model)
+}
+
+func (h *BrandHandler) UpdateModel(w http.ResponseWriter, r *http.Request) {
+	var req models.VehicleModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	model, err := h.service.UpdateModel(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, model)
+}
+
+func (h *BrandHandler) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteModel(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID")); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BrandHandler) ListTrims(w http.ResponseWriter, r *http.Request) {
+	trims, err := h.service.ListTrims(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, trims)
+}
+
+func (h *BrandHandler) CreateTrim(w http.ResponseWriter, r *http.Request) {
+	var req models.TrimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	trim, err := h.service.CreateTrim(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, trim)
+}
+
+func (h *BrandHandler) UpdateTrim(w http.ResponseWriter, r *http.Request) {
+	var req models.TrimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	trim, err := h.service.UpdateTrim(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID"), chi.URLParam(r, "trimID"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, trim)
+}
+
+func (h *BrandHandler) DeleteTrim(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DeleteTrim(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "modelID"), chi.URLParam(r, "trimID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,10 +2,15 @@ package car
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/middleware"
 	"github.com/LikhithMar14/management/models"
 	"github.com/LikhithMar14/management/service"
 	"github.com/go-chi/chi/v5"
@@ -43,13 +48,21 @@ func (h *CarHandler) GetCarByID(w http.ResponseWriter, r *http.Request) {
 func (h *CarHandler) GetCarsByBrand(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	brand := r.URL.Query().Get("brand")
-	isEngine := r.URL.Query().Get("isEngine") == "true"
+	filter, err := parseCarFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	currency := r.URL.Query().Get("currency")
 
-	log.Println("I am Get cars by brand:", brand)
+	log.Println("I am Get cars by brand:", filter.Brand)
 
-	cars, err := h.service.GetCarsByBrand(ctx, brand, isEngine)
+	cars, err := h.service.GetCarsByBrand(ctx, filter, currency)
 	if err != nil {
+		if errors.Is(err, models.ErrValidation) || errors.Is(err, models.ErrNotFound) {
+			handler.WriteError(w, err)
+			return
+		}
 		http.Error(w, "Failed to fetch cars", http.StatusInternalServerError)
 		return
 	}
@@ -61,6 +74,54 @@ func (h *CarHandler) GetCarsByBrand(w http.ResponseWriter, r *http.Request) {
 }
 
 
+// parseCarFilter reads the listing query parameters. Repeat option to
+// require several, e.g. ?option=sunroof&option=adas-pack.
+func parseCarFilter(r *http.Request) (models.CarFilter, error) {
+	q := r.URL.Query()
+	filter := models.CarFilter{
+		Brand:           q.Get("brand"),
+		IsEngine:        q.Get("isEngine") == "true",
+		Options:         q["option"],
+		Condition:       q.Get("condition"),
+		AccidentHistory: q.Get("accident_history"),
+	}
+
+	for name, dest := range map[string]**int64{
+		"min_odometer": &filter.MinOdometerKm,
+		"max_odometer": &filter.MaxOdometerKm,
+	} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return models.CarFilter{}, fmt.Errorf("%s must be an integer", name)
+			}
+			*dest = &n
+		}
+	}
+
+	for name, dest := range map[string]**int{
+		"min_grade":  &filter.MinGrade,
+		"max_owners": &filter.MaxOwners,
+	} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return models.CarFilter{}, fmt.Errorf("%s must be an integer", name)
+			}
+			*dest = &n
+		}
+	}
+
+	if v := q.Get("certified"); v != "" {
+		certified, err := strconv.ParseBool(v)
+		if err != nil {
+			return models.CarFilter{}, errors.New("certified must be true or false")
+		}
+		filter.Certified = &certified
+	}
+	return filter, nil
+}
+
 func (h *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -73,7 +134,7 @@ func (h *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
 
 	car , err := h.service.CreateCar(ctx, &newCar)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 
@@ -106,7 +167,7 @@ func (h *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request){
 
 	car, err := h.service.UpdateCar(ctx, vars, &updateCar)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 	fmt.Println("error: ",err)
@@ -127,11 +188,95 @@ func (h *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
 
 	err := h.service.DeleteCar(ctx, vars)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *CarHandler) GetCarsByEngineID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	engineID := chi.URLParam(r, "id")
+
+	cars, err := h.service.GetCarsByEngineID(ctx, engineID)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+
+	handler.WriteJSON(w, http.StatusOK, cars)
+}
+
+// GetCarsByLocation lists the cars kept at a location.
+func (h *CarHandler) GetCarsByLocation(w http.ResponseWriter, r *http.Request) {
+	cars, err := h.service.GetCarsByLocation(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, cars)
+}
+
+// CompareCars compares two to five cars given as ?ids=a,b,c, optionally
+// pricing them in ?currency=.
+func (h *CarHandler) CompareCars(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if strings.TrimSpace(id) != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	comparison, err := h.service.CompareCars(r.Context(), ids, r.URL.Query().Get("currency"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, comparison)
+}
+
+func (h *CarHandler) GetInspection(w http.ResponseWriter, r *http.Request) {
+	inspection, err := h.service.GetInspection(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, inspection)
+}
 
+// SetInspection records the certified pre-owned checklist of a used car.
+func (h *CarHandler) SetInspection(w http.ResponseWriter, r *http.Request) {
+	var req models.InspectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	inspection, err := h.service.SetInspection(r.Context(), chi.URLParam(r, "id"), &req, middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, inspection)
+}
+
+func (h *CarHandler) DeleteInspection(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteInspection(r.Context(), chi.URLParam(r, "id")); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListInspectionChecklist lists the items a certified pre-owned inspection
+// must cover.
+func (h *CarHandler) ListInspectionChecklist(w http.ResponseWriter, r *http.Request) {
+	handler.WriteJSON(w, http.StatusOK, models.InspectionChecklist)
+}
+
+// ListFuelTypes lists the fuel types cars accept, with the aliases that are
+// normalized to each.
+func (h *CarHandler) ListFuelTypes(w http.ResponseWriter, r *http.Request) {
+	handler.WriteJSON(w, http.StatusOK, models.FuelTypes)
+}
@@ -0,0 +1,181 @@
+package editing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Connection limits. The server pings every pingInterval and drops a client
+// that has not answered within pongWait.
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingInterval   = pongWait * 9 / 10
+	maxMessageSize = 4096
+)
+
+// upgrader only accepts connections from pages served by this host, the
+// gorilla default, so other sites cannot open one with a user's token.
+var upgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024}
+
+type EditingHandler struct {
+	service service.EditingService
+}
+
+func NewEditingHandler(service service.EditingService) *EditingHandler {
+	return &EditingHandler{service: service}
+}
+
+// Connect upgrades an authenticated request to a WebSocket on which the
+// client subscribes to cars, sees who else has them open, takes soft locks
+// and receives cars as they are saved. Commands are JSON objects with a
+// type (subscribe, unsubscribe, lock, unlock) and a car_id.
+func (h *EditingHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	username := middleware.UsernameFromContext(r.Context())
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied
+		return
+	}
+	defer conn.Close()
+
+	session := h.service.Connect(username)
+	// The request context ends with the connection, so closing its cars
+	// must not depend on it
+	defer h.service.Disconnect(context.Background(), session.ID)
+
+	replies := make(chan models.EditingMessage)
+	stop := make(chan struct{})
+	defer close(stop)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.read(r.Context(), conn, session, replies, stop)
+	}()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case message, ok := <-session.Messages:
+			if !ok {
+				// The session fell behind or the server is stopping; either
+				// way the client should reconnect
+				closeMessage := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "reconnect")
+				conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeWait))
+				return
+			}
+			if err := write(conn, message); err != nil {
+				return
+			}
+		case reply := <-replies:
+			if err := write(conn, reply); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// read runs the client's commands until the connection fails or stop is
+// closed, passing any reply to the writer.
+func (h *EditingHandler) read(ctx context.Context, conn *websocket.Conn, session models.EditingSession, replies chan<- models.EditingMessage, stop <-chan struct{}) {
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("editing: read from %s: %v", session.Username, err)
+			}
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var reply *models.EditingMessage
+		var command models.EditingCommand
+		if err := json.Unmarshal(data, &command); err != nil {
+			reply = &models.EditingMessage{Type: models.EditingError, Error: "Invalid message"}
+		} else {
+			reply = h.handle(ctx, session, command)
+		}
+		if reply == nil {
+			continue
+		}
+		select {
+		case replies <- *reply:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// handle runs a command and returns the reply to it, if any. Successful
+// commands have no reply: their effect arrives as a presence message.
+func (h *EditingHandler) handle(ctx context.Context, session models.EditingSession, command models.EditingCommand) *models.EditingMessage {
+	if command.CarID == uuid.Nil {
+		return &models.EditingMessage{Type: models.EditingError, Error: "car_id is required"}
+	}
+	carID := command.CarID
+
+	var err error
+	switch command.Type {
+	case models.EditingSubscribe:
+		err = h.service.Subscribe(ctx, session.ID, carID)
+	case models.EditingUnsubscribe:
+		err = h.service.Unsubscribe(ctx, session.ID, carID)
+	case models.EditingLock:
+		var lock models.CarEditLock
+		var acquired bool
+		lock, acquired, err = h.service.Lock(ctx, session.ID, carID)
+		if err == nil && !acquired {
+			return &models.EditingMessage{Type: models.EditingLockDenied, CarID: &carID, Lock: &lock}
+		}
+	case models.EditingUnlock:
+		err = h.service.Unlock(ctx, session.ID, carID)
+	default:
+		return &models.EditingMessage{Type: models.EditingError, CarID: &carID, Error: "Unknown message type"}
+	}
+	if err != nil {
+		return &models.EditingMessage{Type: models.EditingError, CarID: &carID, Error: errorMessage(err)}
+	}
+	return nil
+}
+
+// errorMessage is the message for an error reported to the client, in the
+// manner of handler.WriteError.
+func errorMessage(err error) string {
+	switch {
+	case errors.Is(err, models.ErrValidation), errors.Is(err, models.ErrNotFound), errors.Is(err, models.ErrConflict):
+		return err.Error()
+	default:
+		log.Printf("internal error: %v", err)
+		return "Internal server error"
+	}
+}
+
+func write(conn *websocket.Conn, message models.EditingMessage) error {
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteJSON(message)
+}
@@ -2,8 +2,12 @@ package engine
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/LikhithMar14/management/handler"
 	"github.com/LikhithMar14/management/models"
 	"github.com/LikhithMar14/management/service"
 	"github.com/go-chi/chi/v5"
@@ -24,7 +28,7 @@ func (h *EngineHandler) GetEngineByID(w http.ResponseWriter, r *http.Request) {
 
 	engine, err := h.service.GetEngineByID(ctx, vars)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 
@@ -48,7 +52,7 @@ func (h *EngineHandler) CreateEngine(w http.ResponseWriter, r *http.Request) {
 
 	engine, err := h.service.CreateEngine(ctx, &newEngine)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 
@@ -74,7 +78,7 @@ func (h *EngineHandler) UpdateEngine(w http.ResponseWriter, r *http.Request) {
 
 	engine, err := h.service.UpdateEngine(ctx, vars, &updateEngine)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 
@@ -93,7 +97,7 @@ func (h *EngineHandler) DeleteEngine(w http.ResponseWriter, r *http.Request) {
 
 	err := h.service.DeleteEngine(ctx, vars)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		handler.WriteError(w, err)
 		return
 	}
 
@@ -105,3 +109,71 @@ func (h *EngineHandler) DeleteEngine(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *EngineHandler) ListEngines(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEngineFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	engines, err := h.service.ListEngines(r.Context(), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, engines)
+}
+
+func (h *EngineHandler) GetEngineUsage(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEngineFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	usage, err := h.service.GetEngineUsage(r.Context(), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, usage)
+}
+
+// parseEngineFilter reads the listing query parameters. A leading "-" on
+// sort orders descending, e.g. sort=-power_kw.
+func parseEngineFilter(r *http.Request) (models.EngineFilter, error) {
+	q := r.URL.Query()
+	filter := models.EngineFilter{
+		Powertrain: strings.ToLower(q.Get("powertrain")),
+	}
+
+	sort := q.Get("sort")
+	if strings.HasPrefix(sort, "-") {
+		filter.Descending = true
+		sort = sort[1:]
+	}
+	filter.Sort = sort
+
+	bounds := map[string]**int64{
+		"min_displacement": &filter.MinDisplacement,
+		"max_displacement": &filter.MaxDisplacement,
+		"cylinders":        &filter.Cylinders,
+		"min_range":        &filter.MinRange,
+		"max_range":        &filter.MaxRange,
+	}
+	for name, dest := range bounds {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return models.EngineFilter{}, fmt.Errorf("%s must be an integer", name)
+			}
+			*dest = &n
+		}
+	}
+
+	var err error
+	if filter.Limit, filter.Offset, err = handler.ParsePagination(r); err != nil {
+		return models.EngineFilter{}, err
+	}
+	return filter, nil
+}
@@ -0,0 +1,128 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+)
+
+// heartbeatInterval keeps idle connections from being closed by proxies.
+const heartbeatInterval = 15 * time.Second
+
+// reconnectDelay is how long clients wait before reconnecting.
+const reconnectDelay = 3 * time.Second
+
+// EventReset tells a client its last event is no longer available, so it
+// should reload what it shows.
+const EventReset = "reset"
+
+type EventStreamHandler struct {
+	service service.EventStreamService
+}
+
+func NewEventStreamHandler(service service.EventStreamService) *EventStreamHandler {
+	return &EventStreamHandler{service: service}
+}
+
+// Stream sends car and engine events as Server-Sent Events, optionally only
+// those about cars of ?brand= or at ?location_id=. A reconnecting client
+// passes the Last-Event-ID header, or ?last_event_id=, to receive what it
+// missed.
+func (h *EventStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.StreamFilter{Brand: q.Get("brand")}
+	if v := q.Get("location_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			http.Error(w, "location_id must be a UUID", http.StatusBadRequest)
+			return
+		}
+		filter.LocationID = &id
+	}
+
+	var lastEventID *int64
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = q.Get("last_event_id")
+	}
+	if v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Last-Event-ID must be an integer", http.StatusBadRequest)
+			return
+		}
+		lastEventID = &id
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	subscription := h.service.Subscribe(filter, lastEventID)
+	defer subscription.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay.Milliseconds())
+	if subscription.Reset {
+		// With nothing buffered yet there is no event to resume from, so the
+		// empty id clears the client's Last-Event-ID instead of pointing it at
+		// an event that never existed
+		id := ""
+		if subscription.LatestEventID != 0 {
+			id = strconv.FormatInt(subscription.LatestEventID, 10)
+		}
+		fmt.Fprintf(w, "id: %s\nevent: %s\ndata: {}\n\n", id, EventReset)
+	}
+	for _, event := range subscription.Replay {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes an event with its outbox ID, so the client can resume
+// after it, and its type as the event name.
+func writeEvent(w http.ResponseWriter, event models.StreamEvent) error {
+	data, err := json.Marshal(event.Event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
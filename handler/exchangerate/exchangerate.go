@@ -0,0 +1,57 @@
+package exchangerate
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+)
+
+// maxImportSize caps the size of an uploaded rates file.
+const maxImportSize = 1 << 20
+
+type ExchangeRateHandler struct {
+	service service.ExchangeRateService
+}
+
+func NewExchangeRateHandler(service service.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{service: service}
+}
+
+func (h *ExchangeRateHandler) ListExchangeRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.service.ListExchangeRates(r.Context())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, rates)
+}
+
+func (h *ExchangeRateHandler) SetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var rates []models.ExchangeRate
+	if err := json.NewDecoder(r.Body).Decode(&rates); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	saved, err := h.service.SetExchangeRates(r.Context(), rates)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, saved)
+}
+
+// ImportExchangeRates accepts a CSV body of base,quote,rate,effective_date rows.
+func (h *ExchangeRateHandler) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	saved, err := h.service.ImportExchangeRates(r.Context(), body)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, saved)
+}
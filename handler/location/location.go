@@ -0,0 +1,164 @@
+package location
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type LocationHandler struct {
+	service service.LocationService
+}
+
+func NewLocationHandler(service service.LocationService) *LocationHandler {
+	return &LocationHandler{service: service}
+}
+
+func (h *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.service.ListLocations(r.Context())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, locations)
+}
+
+func (h *LocationHandler) GetLocationByID(w http.ResponseWriter, r *http.Request) {
+	location, err := h.service.GetLocationByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, location)
+}
+
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var req models.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.service.CreateLocation(r.Context(), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, location)
+}
+
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	var req models.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.service.UpdateLocation(r.Context(), chi.URLParam(r, "id"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, location)
+}
+
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteLocation(r.Context(), chi.URLParam(r, "id")); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTransfers returns the transfer history, filtered by ?car_id=,
+// ?location_id= and ?status=.
+func (h *LocationHandler) ListTransfers(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := handler.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := models.TransferFilter{
+		CarID:      q.Get("car_id"),
+		LocationID: q.Get("location_id"),
+		Status:     strings.ToLower(q.Get("status")),
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	transfers, err := h.service.ListTransfers(r.Context(), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, transfers)
+}
+
+// ListCarTransfers returns the movements of one car.
+func (h *LocationHandler) ListCarTransfers(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := handler.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.TransferFilter{CarID: chi.URLParam(r, "id"), Limit: limit, Offset: offset}
+	transfers, err := h.service.ListTransfers(r.Context(), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, transfers)
+}
+
+func (h *LocationHandler) GetTransferByID(w http.ResponseWriter, r *http.Request) {
+	transfer, err := h.service.GetTransferByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, transfer)
+}
+
+// CreateTransfer dispatches a car to another location.
+func (h *LocationHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var req models.TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.service.CreateTransfer(r.Context(), &req, middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, transfer)
+}
+
+// ReceiveTransfer confirms the car arrived at its destination.
+func (h *LocationHandler) ReceiveTransfer(w http.ResponseWriter, r *http.Request) {
+	transfer, err := h.service.ReceiveTransfer(r.Context(), chi.URLParam(r, "id"), middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, transfer)
+}
+
+func (h *LocationHandler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	transfer, err := h.service.CancelTransfer(r.Context(), chi.URLParam(r, "id"), middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, transfer)
+}
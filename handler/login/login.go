@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/LikhithMar14/management/middleware"
 	"github.com/LikhithMar14/management/models"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -50,10 +51,13 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 func generateToken(creds models.Credentials) (string, error) {
 	expiration := time.Now().Add(24*time.Hour)
 
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expiration),
-		IssuedAt: jwt.NewNumericDate(time.Now()),
-		Subject: creds.Username,
+	claims := middleware.CustomClaims{
+		Admin: middleware.AdminCredentials(creds.Username, creds.Password),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiration),
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Subject: creds.Username,
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
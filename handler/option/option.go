@@ -0,0 +1,100 @@
+package option
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type OptionHandler struct {
+	service service.OptionService
+}
+
+func NewOptionHandler(service service.OptionService) *OptionHandler {
+	return &OptionHandler{service: service}
+}
+
+func (h *OptionHandler) ListOptions(w http.ResponseWriter, r *http.Request) {
+	options, err := h.service.ListOptions(r.Context(), r.URL.Query().Get("category"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, options)
+}
+
+func (h *OptionHandler) GetOptionByID(w http.ResponseWriter, r *http.Request) {
+	option, err := h.service.GetOptionByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, option)
+}
+
+func (h *OptionHandler) CreateOption(w http.ResponseWriter, r *http.Request) {
+	var req models.OptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	option, err := h.service.CreateOption(r.Context(), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, option)
+}
+
+func (h *OptionHandler) UpdateOption(w http.ResponseWriter, r *http.Request) {
+	var req models.OptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	option, err := h.service.UpdateOption(r.Context(), chi.URLParam(r, "id"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, option)
+}
+
+func (h *OptionHandler) DeleteOption(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteOption(r.Context(), chi.URLParam(r, "id")); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OptionHandler) GetCarOptions(w http.ResponseWriter, r *http.Request) {
+	options, err := h.service.GetCarOptions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, options)
+}
+
+// SetCarOptions replaces the options fitted to a car with the listed ones.
+func (h *OptionHandler) SetCarOptions(w http.ResponseWriter, r *http.Request) {
+	var req models.CarOptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	options, err := h.service.SetCarOptions(r.Context(), chi.URLParam(r, "id"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, options)
+}
@@ -0,0 +1,77 @@
+package order
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/invoice"
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type OrderHandler struct {
+	service service.OrderService
+}
+
+func NewOrderHandler(service service.OrderService) *OrderHandler {
+	return &OrderHandler{service: service}
+}
+
+func (h *OrderHandler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	order, err := h.service.GetOrderByID(ctx, id)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+
+	handler.WriteJSON(w, http.StatusOK, order)
+}
+
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var newOrder models.OrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&newOrder); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	salesperson := middleware.UsernameFromContext(ctx)
+	order, err := h.service.CreateOrder(ctx, &newOrder, salesperson)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+
+	handler.WriteJSON(w, http.StatusCreated, order)
+}
+
+func (h *OrderHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	order, err := h.service.GetOrderByID(ctx, id)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := invoice.Render(&buf, order); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", invoice.Number(order)+".pdf"))
+	w.WriteHeader(http.StatusOK)
+	buf.WriteTo(w)
+}
@@ -0,0 +1,60 @@
+package price
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type PriceHandler struct {
+	service service.PriceService
+}
+
+func NewPriceHandler(service service.PriceService) *PriceHandler {
+	return &PriceHandler{service: service}
+}
+
+func (h *PriceHandler) GetPriceTimeline(w http.ResponseWriter, r *http.Request) {
+	carID := chi.URLParam(r, "id")
+
+	timeline, err := h.service.GetPriceTimeline(r.Context(), carID)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, timeline)
+}
+
+func (h *PriceHandler) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	carID := chi.URLParam(r, "id")
+
+	var schedule models.PriceScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.SchedulePriceChange(ctx, carID, &schedule, middleware.UsernameFromContext(ctx))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *PriceHandler) CancelPriceSchedule(w http.ResponseWriter, r *http.Request) {
+	carID := chi.URLParam(r, "id")
+	scheduleID := chi.URLParam(r, "scheduleID")
+
+	if err := h.service.CancelPriceSchedule(r.Context(), carID, scheduleID); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+)
+
+type ReportHandler struct {
+	service service.ReportService
+}
+
+func NewReportHandler(service service.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GetInventoryReport serves the inventory report for ?from= and ?to=
+// (YYYY-MM-DD) with sales per ?interval=day|week|month, as JSON or, with
+// ?format=csv, as a CSV download.
+func (h *ReportHandler) GetInventoryReport(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.ReportFilter{Interval: strings.ToLower(q.Get("interval"))}
+	for name, dest := range map[string]*models.Date{"from": &filter.From, "to": &filter.To} {
+		if v := q.Get(name); v != "" {
+			date, err := models.ParseDate(v)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("%s: %v", name, err), http.StatusBadRequest)
+				return
+			}
+			*dest = date
+		}
+	}
+
+	format := strings.ToLower(q.Get("format"))
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetInventoryReport(r.Context(), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Last-Modified", report.DataAsOf.UTC().Format(http.TimeFormat))
+	if format != "csv" {
+		handler.WriteJSON(w, http.StatusOK, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="inventory-report-%s.csv"`, report.To))
+	if err := writeInventoryCSV(w, report); err != nil {
+		log.Printf("failed to write inventory report: %v", err)
+	}
+}
+
+// RefreshReports rebuilds the report views now instead of waiting for the
+// next scheduled refresh.
+func (h *ReportHandler) RefreshReports(w http.ResponseWriter, r *http.Request) {
+	refreshes, err := h.service.RefreshViews(r.Context())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, refreshes)
+}
+
+// writeInventoryCSV flattens the report into one table. Each row names its
+// section; columns that do not apply to a section are left empty.
+func writeInventoryCSV(w http.ResponseWriter, report models.InventoryReport) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"section", "key", "currency", "count", "total_value", "average_value", "average_days"}); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name   string
+		groups []models.InventoryGroup
+	}{
+		{"total", report.Totals},
+		{"brand", report.ByBrand},
+		{"fuel_type", report.ByFuelType},
+		{"year", report.ByYear},
+		{"location", report.ByLocation},
+	}
+	for _, section := range sections {
+		for _, g := range section.groups {
+			record := []string{
+				section.name, g.Key, g.Currency, strconv.FormatInt(g.Count, 10),
+				g.TotalValue.String(), g.AverageValue.String(), "",
+			}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, bucket := range report.Aging {
+		record := []string{
+			"aging", bucket.Label, "", strconv.FormatInt(bucket.Count, 10),
+			"", "", strconv.FormatFloat(bucket.AverageDays, 'f', 1, 64),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	for _, window := range report.SalesVelocity {
+		record := []string{
+			"sales_" + report.Interval, window.Start.String(), "", strconv.FormatInt(window.Sales, 10),
+			"", "", strconv.FormatFloat(window.AverageDays, 'f', 1, 64),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
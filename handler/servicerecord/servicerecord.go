@@ -0,0 +1,133 @@
+package servicerecord
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type ServiceRecordHandler struct {
+	service service.ServiceRecordService
+}
+
+func NewServiceRecordHandler(service service.ServiceRecordService) *ServiceRecordHandler {
+	return &ServiceRecordHandler{service: service}
+}
+
+func (h *ServiceRecordHandler) ListServiceRecords(w http.ResponseWriter, r *http.Request) {
+	records, err := h.service.ListServiceRecords(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, records)
+}
+
+func (h *ServiceRecordHandler) GetServiceRecord(w http.ResponseWriter, r *http.Request) {
+	record, err := h.service.GetServiceRecord(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "serviceID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, record)
+}
+
+func (h *ServiceRecordHandler) CreateServiceRecord(w http.ResponseWriter, r *http.Request) {
+	var req models.ServiceRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	record, err := h.service.CreateServiceRecord(r.Context(), chi.URLParam(r, "id"), &req, middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, record)
+}
+
+func (h *ServiceRecordHandler) UpdateServiceRecord(w http.ResponseWriter, r *http.Request) {
+	var req models.ServiceRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	record, err := h.service.UpdateServiceRecord(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "serviceID"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, record)
+}
+
+func (h *ServiceRecordHandler) DeleteServiceRecord(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DeleteServiceRecord(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "serviceID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ServiceRecordHandler) ListServiceIntervals(w http.ResponseWriter, r *http.Request) {
+	intervals, err := h.service.ListServiceIntervals(r.Context())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, intervals)
+}
+
+// SetServiceInterval sets the service interval of the powertrain in the path.
+func (h *ServiceRecordHandler) SetServiceInterval(w http.ResponseWriter, r *http.Request) {
+	var req models.ServiceIntervalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	interval, err := h.service.SetServiceInterval(r.Context(), chi.URLParam(r, "powertrain"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, interval)
+}
+
+// ListServicesDue lists cars due for service by ?before=YYYY-MM-DD (default
+// today), optionally of one ?powertrain=.
+func (h *ServiceRecordHandler) ListServicesDue(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := handler.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := models.ServiceDueFilter{
+		Powertrain: strings.ToLower(q.Get("powertrain")),
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if v := q.Get("before"); v != "" {
+		if filter.Before, err = models.ParseDate(v); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	due, err := h.service.ListServicesDue(r.Context(), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, due)
+}
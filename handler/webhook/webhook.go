@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/LikhithMar14/management/handler"
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.service.ListWebhooks(r.Context())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, webhooks)
+}
+
+func (h *WebhookHandler) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	webhook, err := h.service.GetWebhookByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(r.Context(), &req, middleware.UsernameFromContext(r.Context()))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusCreated, webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(r.Context(), chi.URLParam(r, "id"), &req)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteWebhook(r.Context(), chi.URLParam(r, "id")); err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestWebhook fires a webhook.test event at the subscription and returns
+// the delivery with the outcome.
+func (h *WebhookHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.service.TestWebhook(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, delivery)
+}
+
+// ListDeliveries returns the delivery log of a subscription, filtered by
+// ?status=.
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := handler.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.WebhookDeliveryFilter{
+		Status: strings.ToLower(r.URL.Query().Get("status")),
+		Limit:  limit,
+		Offset: offset,
+	}
+	deliveries, err := h.service.ListDeliveries(r.Context(), chi.URLParam(r, "id"), filter)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, deliveries)
+}
+
+// GetDelivery returns a delivery with every attempt made to send it.
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.service.GetDelivery(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusOK, delivery)
+}
+
+// RetryDelivery requeues a dead-lettered delivery.
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.service.RetryDelivery(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteJSON(w, http.StatusAccepted, delivery)
+}
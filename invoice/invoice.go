@@ -0,0 +1,88 @@
+package invoice
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+const (
+	left   = 50.0
+	right  = 545.0
+	amount = right
+)
+
+// Render writes a single-page PDF invoice for order.
+func Render(w io.Writer, order models.Order) error {
+	d := &document{}
+
+	d.text(left, 60, 22, true, "INVOICE")
+	d.textRight(right, 52, 10, false, fmt.Sprintf("Invoice No: %s", Number(order)))
+	d.textRight(right, 66, 10, false, "Date: "+order.CreatedAt.Format("02 Jan 2006"))
+	d.textRight(right, 80, 10, false, "Order: "+order.ID.String())
+	d.line(left, 95, right, 95)
+
+	y := 120.0
+	d.text(left, y, 11, true, "Bill To")
+	y += 16
+	for _, l := range []string{order.Buyer.Name, order.Buyer.Address, order.Buyer.Email, order.Buyer.Phone} {
+		if l == "" {
+			continue
+		}
+		d.text(left, y, 10, false, l)
+		y += 14
+	}
+
+	y += 20
+	d.text(left, y, 11, true, "Vehicle")
+	d.textRight(amount, y, 11, true, "Amount ("+order.Currency+")")
+	y += 6
+	d.line(left, y, right, y)
+	y += 16
+
+	description := order.CarID.String()
+	if order.Car != nil {
+		description = fmt.Sprintf("%s %s (%s, %s)", order.Car.Brand, order.Car.Name, order.Car.Year, order.Car.FuelType)
+	}
+	d.text(left, y, 10, false, description)
+	d.textRight(amount, y, 10, false, order.SalePrice.String())
+	y += 16
+
+	for _, discount := range order.Discounts {
+		d.text(left+15, y, 10, false, "Discount: "+discount.Description)
+		d.textRight(amount, y, 10, false, "-"+discount.Amount.String())
+		y += 16
+	}
+
+	y += 4
+	d.line(left+250, y, right, y)
+	y += 16
+	d.text(left+250, y, 10, false, "Subtotal")
+	d.textRight(amount, y, 10, false, (order.SalePrice - order.DiscountTotal).String())
+	y += 16
+
+	for _, tax := range order.Taxes {
+		d.text(left+250, y, 10, false, fmt.Sprintf("%s (%s%%)", tax.Name, tax.Rate))
+		d.textRight(amount, y, 10, false, tax.Amount.String())
+		y += 16
+	}
+
+	y += 4
+	d.line(left+250, y, right, y)
+	y += 18
+	d.text(left+250, y, 12, true, "Total")
+	d.textRight(amount, y, 12, true, order.Currency+" "+order.Total.String())
+
+	y += 40
+	d.text(left, y, 10, false, "Payment method: "+order.PaymentMethod)
+	y += 14
+	d.text(left, y, 10, false, "Salesperson: "+order.Salesperson)
+
+	return d.writeTo(w)
+}
+
+// Number formats the order's sequential invoice number.
+func Number(order models.Order) string {
+	return fmt.Sprintf("INV-%06d", order.InvoiceNumber)
+}
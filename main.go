@@ -1,20 +1,72 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/LikhithMar14/management/blob"
 	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/events"
+	attachmentHandler "github.com/LikhithMar14/management/handler/attachment"
+	brandHandler "github.com/LikhithMar14/management/handler/brand"
 	carHandler "github.com/LikhithMar14/management/handler/car"
+	editingHandler "github.com/LikhithMar14/management/handler/editing"
 	engineHandler "github.com/LikhithMar14/management/handler/engine"
+	eventStreamHandler "github.com/LikhithMar14/management/handler/eventstream"
+	exchangeRateHandler "github.com/LikhithMar14/management/handler/exchangerate"
+	locationHandler "github.com/LikhithMar14/management/handler/location"
 	"github.com/LikhithMar14/management/handler/login"
+	optionHandler "github.com/LikhithMar14/management/handler/option"
+	orderHandler "github.com/LikhithMar14/management/handler/order"
+	priceHandler "github.com/LikhithMar14/management/handler/price"
+	reportHandler "github.com/LikhithMar14/management/handler/report"
+	serviceRecordHandler "github.com/LikhithMar14/management/handler/servicerecord"
+	webhookHandler "github.com/LikhithMar14/management/handler/webhook"
+	"github.com/LikhithMar14/management/graph"
 	"github.com/LikhithMar14/management/middleware"
 	"github.com/LikhithMar14/management/migrations"
+	"github.com/LikhithMar14/management/openapi"
+	"github.com/LikhithMar14/management/rpc"
+	attachmentService "github.com/LikhithMar14/management/service/attachment"
+	brandService "github.com/LikhithMar14/management/service/brand"
 	carService "github.com/LikhithMar14/management/service/car"
+	editingService "github.com/LikhithMar14/management/service/editing"
 	engineService "github.com/LikhithMar14/management/service/engine"
+	eventStreamService "github.com/LikhithMar14/management/service/eventstream"
+	exchangeRateService "github.com/LikhithMar14/management/service/exchangerate"
+	locationService "github.com/LikhithMar14/management/service/location"
+	optionService "github.com/LikhithMar14/management/service/option"
+	orderService "github.com/LikhithMar14/management/service/order"
+	outboxService "github.com/LikhithMar14/management/service/outbox"
+	priceService "github.com/LikhithMar14/management/service/price"
+	reportService "github.com/LikhithMar14/management/service/report"
+	serviceRecordService "github.com/LikhithMar14/management/service/servicerecord"
+	webhookService "github.com/LikhithMar14/management/service/webhook"
+	attachmentStore "github.com/LikhithMar14/management/store/attachment"
+	brandStore "github.com/LikhithMar14/management/store/brand"
 	carStore "github.com/LikhithMar14/management/store/car"
+	editingStore "github.com/LikhithMar14/management/store/editing"
 	engineStore "github.com/LikhithMar14/management/store/engine"
+	eventStreamStore "github.com/LikhithMar14/management/store/eventstream"
+	exchangeRateStore "github.com/LikhithMar14/management/store/exchangerate"
+	locationStore "github.com/LikhithMar14/management/store/location"
+	optionStore "github.com/LikhithMar14/management/store/option"
+	orderStore "github.com/LikhithMar14/management/store/order"
+	outboxStore "github.com/LikhithMar14/management/store/outbox"
+	priceStore "github.com/LikhithMar14/management/store/price"
+	reportStore "github.com/LikhithMar14/management/store/report"
+	serviceRecordStore "github.com/LikhithMar14/management/store/servicerecord"
+	webhookStore "github.com/LikhithMar14/management/store/webhook"
 	"github.com/go-chi/chi/v5"
 	"github.com/pressly/goose/v3"
 
@@ -28,6 +80,10 @@ func main() {
 		log.Fatal("Error loading .env file")
 	}
 
+	// Cancelled on SIGINT or SIGTERM to stop the servers and background work
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	database.InitDB()
 	defer database.CloseDB()
 
@@ -38,14 +94,151 @@ func main() {
 	}
 	log.Println("Migrations applied successfully.")
 
+	exchangeRateStore := exchangeRateStore.NewExchangeRateStore(db)
+	exchangeRateService := exchangeRateService.NewExchangeRateService(exchangeRateStore)
+	exchangeRateHandler := exchangeRateHandler.NewExchangeRateHandler(exchangeRateService)
+
+	brandStore := brandStore.NewBrandStore(db)
+	brandService := brandService.NewBrandService(brandStore)
+	brandHandler := brandHandler.NewBrandHandler(brandService)
+
 	carStore := carStore.NewCarStore(db)
-	carService := carService.NewCarService(carStore)
+	carService := carService.NewCarService(carStore, exchangeRateService)
 	carHandler := carHandler.NewCarHandler(carService)
 
+	locationStore := locationStore.NewLocationStore(db)
+	locationService := locationService.NewLocationService(locationStore)
+	locationHandler := locationHandler.NewLocationHandler(locationService)
+
+	optionStore := optionStore.NewOptionStore(db)
+	optionService := optionService.NewOptionService(optionStore, exchangeRateService)
+	optionHandler := optionHandler.NewOptionHandler(optionService)
+
+	blobStorage, err := blob.NewStorageFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up blob storage: %v", err)
+	}
+
+	urlSecret := []byte(os.Getenv("ATTACHMENT_URL_SECRET"))
+	if len(urlSecret) == 0 {
+		urlSecret = make([]byte, 32)
+		if _, err := rand.Read(urlSecret); err != nil {
+			log.Fatalf("Failed to generate attachment URL secret: %v", err)
+		}
+		log.Println("ATTACHMENT_URL_SECRET is not set, download links will not survive a restart")
+	}
+
+	urlTTL := attachmentService.DefaultURLTTL
+	if v := os.Getenv("ATTACHMENT_URL_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			urlTTL = d
+		} else {
+			log.Printf("Invalid ATTACHMENT_URL_TTL %q, using %s", v, urlTTL)
+		}
+	}
+
+	attachmentStore := attachmentStore.NewAttachmentStore(db)
+	attachmentService := attachmentService.NewAttachmentService(attachmentStore, blobStorage, blob.NewURLSigner(urlSecret), urlTTL)
+	attachmentHandler := attachmentHandler.NewAttachmentHandler(attachmentService)
+
+	serviceRecordStore := serviceRecordStore.NewServiceRecordStore(db)
+	serviceRecordService := serviceRecordService.NewServiceRecordService(serviceRecordStore)
+	serviceRecordHandler := serviceRecordHandler.NewServiceRecordHandler(serviceRecordService)
+
+	refreshInterval := reportService.DefaultRefreshInterval
+	if v := os.Getenv("REPORT_REFRESH_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			refreshInterval = d
+		} else {
+			log.Printf("Invalid REPORT_REFRESH_INTERVAL %q, using %s", v, refreshInterval)
+		}
+	}
+
+	reportStore := reportStore.NewReportStore(db)
+	reportService := reportService.NewReportService(reportStore)
+	reportHandler := reportHandler.NewReportHandler(reportService)
+
 	engineStore := engineStore.NewEngineStore(db)
 	engineService := engineService.NewEngineService(engineStore)
 	engineHandler := engineHandler.NewEngineHandler(engineService)
 
+	orderStore := orderStore.NewOrderStore(db)
+	orderService := orderService.NewOrderService(orderStore, carStore)
+	orderHandler := orderHandler.NewOrderHandler(orderService)
+
+	schedulerInterval := priceService.DefaultSchedulerInterval
+	if v := os.Getenv("PRICE_SCHEDULER_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			schedulerInterval = d
+		} else {
+			log.Printf("Invalid PRICE_SCHEDULER_INTERVAL %q, using %s", v, schedulerInterval)
+		}
+	}
+
+	priceStore := priceStore.NewPriceStore(db)
+	priceService := priceService.NewPriceService(priceStore, carStore)
+	priceHandler := priceHandler.NewPriceHandler(priceService)
+
+	priceService.StartScheduler(ctx, schedulerInterval)
+
+	publisher, err := events.NewPublisherFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up event publishing: %v", err)
+	}
+
+	dispatchInterval := webhookService.DefaultDispatchInterval
+	if v := os.Getenv("WEBHOOK_DISPATCH_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			dispatchInterval = d
+		} else {
+			log.Printf("Invalid WEBHOOK_DISPATCH_INTERVAL %q, using %s", v, dispatchInterval)
+		}
+	}
+
+	webhookStore := webhookStore.NewWebhookStore(db)
+	webhookService := webhookService.NewWebhookService(webhookStore, events.NewWebhookSender(nil))
+	webhookHandler := webhookHandler.NewWebhookHandler(webhookService)
+
+	webhookService.StartDispatcher(ctx, dispatchInterval)
+
+	eventStreamStore := eventStreamStore.NewEventStreamStore(db)
+	eventStreamService := eventStreamService.NewEventStreamService(eventStreamStore)
+	eventStreamHandler := eventStreamHandler.NewEventStreamHandler(eventStreamService)
+
+	eventStreamService.StartListener(ctx)
+
+	editingStore := editingStore.NewEditingStore(db)
+	editingService := editingService.NewEditingService(editingStore)
+	editingHandler := editingHandler.NewEditingHandler(editingService)
+
+	editingService.StartListener(ctx)
+	editingService.StartHeartbeat(ctx)
+	editingService.StartEventFeed(ctx, eventStreamService)
+
+	graphHandler, err := graph.NewHandler(carService, engineService)
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
+
+	openapiHandler, err := openapi.NewHandler()
+	if err != nil {
+		log.Fatalf("Failed to build OpenAPI document: %v", err)
+	}
+
+	relayInterval := outboxService.DefaultRelayInterval
+	if v := os.Getenv("OUTBOX_RELAY_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			relayInterval = d
+		} else {
+			log.Printf("Invalid OUTBOX_RELAY_INTERVAL %q, using %s", v, relayInterval)
+		}
+	}
+
+	outboxStore := outboxStore.NewOutboxStore(db)
+	outboxService := outboxService.NewOutboxService(outboxStore, publisher)
+	outboxService.StartRelay(ctx, relayInterval)
+	reportService.StartRefresher(ctx, refreshInterval)
+
 	router := chi.NewRouter()
 	login.InitGoogleOauthConfig()
 	login.InitGitHubOauthConfig()
@@ -63,29 +256,183 @@ func main() {
 		json.NewEncoder(w).Encode(response)
 	})
 
+	router.Get("/openapi.json", openapiHandler.Spec)
+	router.Get("/docs", openapiHandler.Docs)
+
+	// Signed download links carry their own credential
+	router.Get("/attachments/{id}/content", attachmentHandler.DownloadContent)
+	router.Get("/attachments/{id}/thumbnail", attachmentHandler.DownloadThumbnail)
+
+
+	// Browsers cannot set headers on an EventSource or WebSocket, so these
+	// also take the token from the query string
+	router.With(middleware.TokenFromQuery, middleware.AuthMiddleware).Get("/events/stream", eventStreamHandler.Stream)
+	router.With(middleware.TokenFromQuery, middleware.AuthMiddleware).Get("/ws/editing", editingHandler.Connect)
 
 	router.Route("/", func(r chi.Router) {
 		r.Use(middleware.AuthMiddleware)
 		
 		
+		r.Get("/cars/compare", carHandler.CompareCars)
 		r.Get("/cars/{id}", carHandler.GetCarByID)
 		r.Get("/cars", carHandler.GetCarsByBrand)
 		r.Post("/cars", carHandler.CreateCar)
 		r.Put("/cars/{id}", carHandler.UpdateCar)
 		r.Delete("/cars/{id}", carHandler.DeleteCar)
+		r.Get("/cars/{id}/options", optionHandler.GetCarOptions)
+		r.Put("/cars/{id}/options", optionHandler.SetCarOptions)
+		r.Get("/cars/{id}/attachments", attachmentHandler.ListAttachments)
+		r.Post("/cars/{id}/attachments", attachmentHandler.UploadAttachment)
+		r.Delete("/cars/{id}/attachments/{attachmentID}", attachmentHandler.DeleteAttachment)
+		r.Get("/cars/{id}/inspection", carHandler.GetInspection)
+		r.Put("/cars/{id}/inspection", carHandler.SetInspection)
+		r.Delete("/cars/{id}/inspection", carHandler.DeleteInspection)
+		r.Get("/inspection-checklist", carHandler.ListInspectionChecklist)
+		r.Get("/cars/{id}/transfers", locationHandler.ListCarTransfers)
+		r.Get("/cars/{id}/services", serviceRecordHandler.ListServiceRecords)
+		r.Post("/cars/{id}/services", serviceRecordHandler.CreateServiceRecord)
+		r.Get("/cars/{id}/services/{serviceID}", serviceRecordHandler.GetServiceRecord)
+		r.Put("/cars/{id}/services/{serviceID}", serviceRecordHandler.UpdateServiceRecord)
+		r.Delete("/cars/{id}/services/{serviceID}", serviceRecordHandler.DeleteServiceRecord)
+		r.Get("/services/due", serviceRecordHandler.ListServicesDue)
+		r.Get("/service-intervals", serviceRecordHandler.ListServiceIntervals)
+		r.With(middleware.RequireAdmin).Put("/service-intervals/{powertrain}", serviceRecordHandler.SetServiceInterval)
+		r.Get("/fuel-types", carHandler.ListFuelTypes)
+
+		r.Get("/locations", locationHandler.ListLocations)
+		r.Get("/locations/{id}", locationHandler.GetLocationByID)
+		r.Get("/locations/{id}/cars", carHandler.GetCarsByLocation)
+		r.With(middleware.RequireAdmin).Post("/locations", locationHandler.CreateLocation)
+		r.With(middleware.RequireAdmin).Put("/locations/{id}", locationHandler.UpdateLocation)
+		r.With(middleware.RequireAdmin).Delete("/locations/{id}", locationHandler.DeleteLocation)
+
+		r.Get("/transfers", locationHandler.ListTransfers)
+		r.Get("/transfers/{id}", locationHandler.GetTransferByID)
+		r.Post("/transfers", locationHandler.CreateTransfer)
+		r.Post("/transfers/{id}/receive", locationHandler.ReceiveTransfer)
+		r.Post("/transfers/{id}/cancel", locationHandler.CancelTransfer)
+
+		r.Get("/options", optionHandler.ListOptions)
+		r.Get("/options/{id}", optionHandler.GetOptionByID)
+		r.With(middleware.RequireAdmin).Post("/options", optionHandler.CreateOption)
+		r.With(middleware.RequireAdmin).Put("/options/{id}", optionHandler.UpdateOption)
+		r.With(middleware.RequireAdmin).Delete("/options/{id}", optionHandler.DeleteOption)
+		r.Get("/cars/{id}/prices", priceHandler.GetPriceTimeline)
+		r.Post("/cars/{id}/prices/schedules", priceHandler.SchedulePriceChange)
+		r.Delete("/cars/{id}/prices/schedules/{scheduleID}", priceHandler.CancelPriceSchedule)
 
 		
+		r.Get("/brands", brandHandler.ListBrands)
+		r.Get("/brands/{id}", brandHandler.GetBrandByID)
+		r.With(middleware.RequireAdmin).Post("/brands", brandHandler.CreateBrand)
+		r.With(middleware.RequireAdmin).Put("/brands/{id}", brandHandler.UpdateBrand)
+		r.With(middleware.RequireAdmin).Delete("/brands/{id}", brandHandler.DeleteBrand)
+		r.Get("/brands/{id}/models", brandHandler.ListModels)
+		r.Get("/brands/{id}/models/{modelID}", brandHandler.GetModelByID)
+		r.With(middleware.RequireAdmin).Post("/brands/{id}/models", brandHandler.CreateModel)
+		r.With(middleware.RequireAdmin).Put("/brands/{id}/models/{modelID}", brandHandler.UpdateModel)
+		r.With(middleware.RequireAdmin).Delete("/brands/{id}/models/{modelID}", brandHandler.DeleteModel)
+		r.Get("/brands/{id}/models/{modelID}/trims", brandHandler.ListTrims)
+		r.With(middleware.RequireAdmin).Post("/brands/{id}/models/{modelID}/trims", brandHandler.CreateTrim)
+		r.With(middleware.RequireAdmin).Put("/brands/{id}/models/{modelID}/trims/{trimID}", brandHandler.UpdateTrim)
+		r.With(middleware.RequireAdmin).Delete("/brands/{id}/models/{modelID}/trims/{trimID}", brandHandler.DeleteTrim)
+
+		r.Get("/engine", engineHandler.ListEngines)
+		r.Get("/engine/usage", engineHandler.GetEngineUsage)
 		r.Get("/engine/{id}", engineHandler.GetEngineByID)
+		r.Get("/engine/{id}/cars", carHandler.GetCarsByEngineID)
 		r.Post("/engine", engineHandler.CreateEngine)
 		r.Put("/engine/{id}", engineHandler.UpdateEngine)
 		r.Delete("/engine/{id}", engineHandler.DeleteEngine)
+
+		r.Post("/orders", orderHandler.CreateOrder)
+		r.Get("/orders/{id}", orderHandler.GetOrderByID)
+		r.Get("/orders/{id}/invoice.pdf", orderHandler.GetInvoice)
+
+		r.Get("/reports/inventory", reportHandler.GetInventoryReport)
+		r.With(middleware.RequireAdmin).Post("/reports/refresh", reportHandler.RefreshReports)
+
+		r.With(middleware.RequireAdmin).Get("/webhooks", webhookHandler.ListWebhooks)
+		r.With(middleware.RequireAdmin).Post("/webhooks", webhookHandler.CreateWebhook)
+		r.With(middleware.RequireAdmin).Get("/webhooks/{id}", webhookHandler.GetWebhookByID)
+		r.With(middleware.RequireAdmin).Put("/webhooks/{id}", webhookHandler.UpdateWebhook)
+		r.With(middleware.RequireAdmin).Delete("/webhooks/{id}", webhookHandler.DeleteWebhook)
+		r.With(middleware.RequireAdmin).Post("/webhooks/{id}/test", webhookHandler.TestWebhook)
+		r.With(middleware.RequireAdmin).Get("/webhooks/{id}/deliveries", webhookHandler.ListDeliveries)
+		r.With(middleware.RequireAdmin).Get("/webhooks/{id}/deliveries/{deliveryID}", webhookHandler.GetDelivery)
+		r.With(middleware.RequireAdmin).Post("/webhooks/{id}/deliveries/{deliveryID}/retry", webhookHandler.RetryDelivery)
+
+		r.Get("/exchange-rates", exchangeRateHandler.ListExchangeRates)
+		r.With(middleware.RequireAdmin).Put("/exchange-rates", exchangeRateHandler.SetExchangeRates)
+		r.With(middleware.RequireAdmin).Post("/exchange-rates/import", exchangeRateHandler.ImportExchangeRates)
+
+		r.Post("/graphql", graphHandler.Serve)
 	})
 
-	log.Println("Server starting on :8080")
-	err = http.ListenAndServe(":8080", router)
+	httpAddr := os.Getenv("HTTP_ADDR")
+	if httpAddr == "" {
+		httpAddr = ":8080"
+	}
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+
+	httpServer := &http.Server{Addr: httpAddr, Handler: router}
+	// Streams stay open until the client leaves, so end them when shutting
+	// down rather than wait for them
+	httpServer.RegisterOnShutdown(eventStreamService.Shutdown)
+	httpServer.RegisterOnShutdown(editingService.Shutdown)
+
+	grpcServer := rpc.NewServer(carService, engineService)
+	grpcListener, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		log.Fatalf("Failed to listen on %s: %v", grpcAddr, err)
+	}
+
+	serverErrs := make(chan error, 2)
+	go func() {
+		log.Printf("HTTP server starting on %s", httpAddr)
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serverErrs <- fmt.Errorf("HTTP server: %w", err)
+		}
+	}()
+	go func() {
+		log.Printf("gRPC server starting on %s", grpcAddr)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			serverErrs <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Println("Shutting down")
+	case err := <-serverErrs:
+		log.Printf("%v, shutting down", err)
+	}
+	stop()
+
+	// Both servers finish the requests in flight, up to shutdownTimeout,
+	// before the database is closed
+	const shutdownTimeout = 15 * time.Second
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown: %v", err)
+		httpServer.Close()
+	}
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
 	}
+	log.Println("Servers stopped")
 }
 
 
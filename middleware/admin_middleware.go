@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+const adminKey contextKey = "admin"
+
+// RequireAdmin only lets through users whose token carries the admin claim.
+// It must run after AuthMiddleware.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdmin(r.Context()) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// IsAdmin reports whether AuthMiddleware found the admin claim in the
+// request's token.
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminKey).(bool)
+	return admin
+}
+
+// WithAdmin marks the authenticated principal as an administrator.
+func WithAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminKey, true)
+}
+
+// AdminCredentials reports whether username is listed in the comma separated
+// ADMIN_USERS environment variable and password matches ADMIN_PASSWORD. Login
+// only grants the admin claim when it does; with no ADMIN_PASSWORD set nobody
+// is an administrator.
+func AdminCredentials(username, password string) bool {
+	adminPassword := os.Getenv("ADMIN_PASSWORD")
+	if username == "" || adminPassword == "" {
+		return false
+	}
+	admins := strings.Split(os.Getenv("ADMIN_USERS"), ",")
+	for i := range admins {
+		admins[i] = strings.TrimSpace(admins[i])
+	}
+	if !slices.Contains(admins, username) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(password), []byte(adminPassword)) == 1
+}
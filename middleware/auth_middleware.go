@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -10,6 +11,8 @@ import (
 
 type CustomClaims struct {
 	UserName string `json:"username"`
+	// Admin is only set by login after checking AdminCredentials
+	Admin bool `json:"admin,omitempty"`
 	jwt.RegisteredClaims
 }
 type contextKey string
@@ -25,22 +28,74 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return  jwtKey,nil
-		})
+		claims, err := ParseClaims(strings.TrimPrefix(authHeader, "Bearer "))
 		if err != nil {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		claims, ok := token.Claims.(*CustomClaims)
-		if !ok || !token.Valid {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
+		ctx := WithUsername(r.Context(), claims.Principal())
+		if claims.Admin {
+			ctx = WithAdmin(ctx)
 		}
-
-		ctx := context.WithValue(r.Context(), usernameKey, claims.UserName)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
-}
\ No newline at end of file
+}
+
+// ParseToken validates a JWT and returns the principal it was issued to.
+// It is shared by every transport that accepts the tokens.
+func ParseToken(tokenString string) (string, error) {
+	claims, err := ParseClaims(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims.Principal(), nil
+}
+
+// ParseClaims validates a JWT and returns its claims.
+func ParseClaims(tokenString string) (*CustomClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*CustomClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// Principal returns the user the token was issued to.
+func (c *CustomClaims) Principal() string {
+	if c.UserName != "" {
+		return c.UserName
+	}
+	return c.Subject
+}
+
+// WithUsername stores the authenticated principal for UsernameFromContext.
+func WithUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, usernameKey, username)
+}
+
+// TokenFromQuery lets clients that cannot set headers, such as a browser
+// EventSource or WebSocket, pass their token as ?access_token= to AuthMiddleware. URLs
+// end up in logs, so it is only used on the routes that need it.
+func TokenFromQuery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("access_token"); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UsernameFromContext returns the authenticated principal stored by
+// AuthMiddleware, or an empty string for unauthenticated requests.
+func UsernameFromContext(ctx context.Context) string {
+	username, _ := ctx.Value(usernameKey).(string)
+	return username
+}
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Create orders table recording the sale of a car
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_number BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE RESTRICT,
+    buyer_name VARCHAR(255) NOT NULL,
+    buyer_email VARCHAR(255) NOT NULL DEFAULT '',
+    buyer_phone VARCHAR(50) NOT NULL DEFAULT '',
+    buyer_address TEXT NOT NULL DEFAULT '',
+    sale_price NUMERIC(14, 2) NOT NULL,
+    discounts JSONB NOT NULL DEFAULT '[]',
+    taxes JSONB NOT NULL DEFAULT '[]',
+    discount_total NUMERIC(14, 2) NOT NULL,
+    tax_total NUMERIC(14, 2) NOT NULL,
+    total NUMERIC(14, 2) NOT NULL,
+    payment_method VARCHAR(50) NOT NULL,
+    salesperson VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A car can only be sold once
+CREATE UNIQUE INDEX IF NOT EXISTS orders_car_id_key ON orders (car_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Widen price so amounts above 99,999,999.99 fit, and record the currency it is listed in
+ALTER TABLE cars ALTER COLUMN price TYPE NUMERIC(18, 2);
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+ALTER TABLE orders ALTER COLUMN sale_price TYPE NUMERIC(18, 2);
+ALTER TABLE orders ALTER COLUMN discount_total TYPE NUMERIC(18, 2);
+ALTER TABLE orders ALTER COLUMN tax_total TYPE NUMERIC(18, 2);
+ALTER TABLE orders ALTER COLUMN total TYPE NUMERIC(18, 2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+ALTER TABLE orders ALTER COLUMN total TYPE NUMERIC(14, 2);
+ALTER TABLE orders ALTER COLUMN tax_total TYPE NUMERIC(14, 2);
+ALTER TABLE orders ALTER COLUMN discount_total TYPE NUMERIC(14, 2);
+ALTER TABLE orders ALTER COLUMN sale_price TYPE NUMERIC(14, 2);
+
+ALTER TABLE cars DROP COLUMN IF EXISTS currency;
+ALTER TABLE cars ALTER COLUMN price TYPE DECIMAL(10, 2);
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Create exchange_rates table; a rate applies from its effective date until superseded
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(20, 8) NOT NULL CHECK (rate > 0),
+    effective_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (base_currency, quote_currency, effective_date)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS exchange_rates;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- Create price_schedules table for future price changes, optionally reverted at ends_at
+CREATE TABLE IF NOT EXISTS price_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    price NUMERIC(18, 2) NOT NULL CHECK (price > 0),
+    currency CHAR(3) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ CHECK (ends_at IS NULL OR ends_at > starts_at),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    original_price NUMERIC(18, 2),
+    original_currency CHAR(3),
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_schedules_due_idx ON price_schedules (status, starts_at);
+
+-- Create price_history table recording every price a car has had
+CREATE TABLE IF NOT EXISTS price_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    price NUMERIC(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    previous_price NUMERIC(18, 2),
+    previous_currency CHAR(3),
+    reason VARCHAR(20) NOT NULL,
+    schedule_id UUID REFERENCES price_schedules(id) ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_history_car_id_idx ON price_history (car_id, changed_at);
+
+-- Seed the history with the current price of every existing car
+INSERT INTO price_history (car_id, price, currency, reason)
+SELECT id, price, currency, 'initial' FROM cars;
+
+-- +goose Down
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS price_schedules;
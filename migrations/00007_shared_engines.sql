@@ -0,0 +1,49 @@
+-- +goose Up
+-- Generate ids in the database so inserts do not have to supply them
+ALTER TABLE engines ALTER COLUMN id SET DEFAULT gen_random_uuid();
+ALTER TABLE cars ALTER COLUMN id SET DEFAULT gen_random_uuid();
+
+-- Merge engines with identical specs into the oldest one and repoint their cars
+WITH ranked AS (
+    SELECT id,
+           FIRST_VALUE(id) OVER (
+               PARTITION BY displacement, number_of_cylinders, car_range
+               ORDER BY created_at, id
+           ) AS keep_id
+    FROM engines
+)
+UPDATE cars c
+SET engine_id = r.keep_id
+FROM ranked r
+WHERE c.engine_id = r.id AND r.id <> r.keep_id;
+
+-- Only the merged duplicates go; the oldest engine of each spec is kept even
+-- when no car uses it
+WITH ranked AS (
+    SELECT id,
+           FIRST_VALUE(id) OVER (
+               PARTITION BY displacement, number_of_cylinders, car_range
+               ORDER BY created_at, id
+           ) AS keep_id
+    FROM engines
+)
+DELETE FROM engines e
+USING ranked r
+WHERE e.id = r.id AND r.id <> r.keep_id
+AND NOT EXISTS (SELECT 1 FROM cars c WHERE c.engine_id = e.id);
+
+ALTER TABLE engines ADD CONSTRAINT engines_spec_key UNIQUE (displacement, number_of_cylinders, car_range);
+
+-- An engine in use by a car can no longer be deleted out from under it
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS fk_engine_id;
+ALTER TABLE cars ADD CONSTRAINT fk_engine_id FOREIGN KEY (engine_id) REFERENCES engines(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS cars_engine_id_idx ON cars (engine_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS cars_engine_id_idx;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS fk_engine_id;
+ALTER TABLE cars ADD CONSTRAINT fk_engine_id FOREIGN KEY (engine_id) REFERENCES engines(id) ON DELETE CASCADE;
+ALTER TABLE engines DROP CONSTRAINT IF EXISTS engines_spec_key;
+ALTER TABLE cars ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE engines ALTER COLUMN id DROP DEFAULT;
@@ -0,0 +1,125 @@
+-- +goose Up
+-- Describe engines by powertrain, derived below from the cars that use them
+ALTER TABLE engines ADD COLUMN IF NOT EXISTS powertrain VARCHAR(10) NOT NULL DEFAULT 'ice'
+    CHECK (powertrain IN ('ice', 'hybrid', 'bev'));
+ALTER TABLE engines ADD COLUMN IF NOT EXISTS power_kw INT NOT NULL DEFAULT 0;
+ALTER TABLE engines ADD COLUMN IF NOT EXISTS torque_nm INT NOT NULL DEFAULT 0;
+ALTER TABLE engines ADD COLUMN IF NOT EXISTS battery_kwh NUMERIC(6, 1) NOT NULL DEFAULT 0;
+ALTER TABLE engines ADD COLUMN IF NOT EXISTS emissions_class VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE engines ADD COLUMN IF NOT EXISTS co2_g_per_km INT NOT NULL DEFAULT 0;
+
+ALTER TABLE engines DROP CONSTRAINT IF EXISTS engines_spec_key;
+
+-- Fuel types are still free text here, so match the spellings 00010 accepts
+CREATE TEMPORARY TABLE car_powertrains ON COMMIT DROP AS
+SELECT id AS car_id, engine_id, created_at,
+       CASE lower(btrim(fuel_type))
+           WHEN 'electric' THEN 'bev'
+           WHEN 'ev' THEN 'bev'
+           WHEN 'bev' THEN 'bev'
+           WHEN 'hybrid' THEN 'hybrid'
+           WHEN 'hev' THEN 'hybrid'
+           WHEN 'phev' THEN 'hybrid'
+           ELSE 'ice'
+       END AS powertrain
+FROM cars;
+
+-- An engine takes the powertrain of its oldest car
+UPDATE engines e
+SET powertrain = p.powertrain
+FROM (
+    SELECT DISTINCT ON (engine_id) engine_id, powertrain
+    FROM car_powertrains
+    ORDER BY engine_id, created_at, car_id
+) p
+WHERE e.id = p.engine_id;
+
+-- Cars of another powertrain on the same engine move to a copy of it
+CREATE TEMPORARY TABLE engine_splits ON COMMIT DROP AS
+SELECT engine_id, powertrain, gen_random_uuid() AS new_id
+FROM (
+    SELECT DISTINCT cp.engine_id, cp.powertrain
+    FROM car_powertrains cp
+    JOIN engines e ON e.id = cp.engine_id
+    WHERE cp.powertrain <> e.powertrain
+) split;
+
+INSERT INTO engines (id, powertrain, displacement, number_of_cylinders, car_range, created_at, updated_at)
+SELECT s.new_id, s.powertrain, e.displacement, e.number_of_cylinders, e.car_range, e.created_at, e.updated_at
+FROM engine_splits s
+JOIN engines e ON e.id = s.engine_id;
+
+UPDATE cars c
+SET engine_id = s.new_id
+FROM car_powertrains cp
+JOIN engine_splits s ON s.engine_id = cp.engine_id AND s.powertrain = cp.powertrain
+WHERE c.id = cp.car_id;
+
+-- Battery electric engines have no combustion figures. The capacity of
+-- existing battery engines is unknown, so they get the smallest one the
+-- column holds until the catalog is corrected.
+UPDATE engines SET displacement = 0, number_of_cylinders = 0 WHERE powertrain = 'bev';
+UPDATE engines SET battery_kwh = 0.1 WHERE powertrain IN ('bev', 'hybrid');
+
+-- Zeroing the combustion figures can leave battery electric engines with the
+-- same spec; merge them into the oldest one, as 00007 did
+WITH ranked AS (
+    SELECT id,
+           FIRST_VALUE(id) OVER (
+               PARTITION BY car_range
+               ORDER BY created_at, id
+           ) AS keep_id
+    FROM engines
+    WHERE powertrain = 'bev'
+)
+UPDATE cars c
+SET engine_id = r.keep_id
+FROM ranked r
+WHERE c.engine_id = r.id AND r.id <> r.keep_id;
+
+DELETE FROM engines e
+WHERE e.powertrain = 'bev'
+AND NOT EXISTS (SELECT 1 FROM cars c WHERE c.engine_id = e.id);
+
+-- The whole spec identifies an engine in the catalog
+ALTER TABLE engines ADD CONSTRAINT engines_spec_key UNIQUE (
+    powertrain, displacement, number_of_cylinders, car_range, power_kw,
+    torque_nm, battery_kwh, emissions_class, co2_g_per_km
+);
+
+-- +goose Down
+ALTER TABLE engines DROP CONSTRAINT IF EXISTS engines_spec_key;
+ALTER TABLE engines DROP COLUMN IF EXISTS co2_g_per_km;
+ALTER TABLE engines DROP COLUMN IF EXISTS emissions_class;
+ALTER TABLE engines DROP COLUMN IF EXISTS battery_kwh;
+ALTER TABLE engines DROP COLUMN IF EXISTS torque_nm;
+ALTER TABLE engines DROP COLUMN IF EXISTS power_kw;
+ALTER TABLE engines DROP COLUMN IF EXISTS powertrain;
+
+-- Engines split by powertrain share the old spec again
+WITH ranked AS (
+    SELECT id,
+           FIRST_VALUE(id) OVER (
+               PARTITION BY displacement, number_of_cylinders, car_range
+               ORDER BY created_at, id
+           ) AS keep_id
+    FROM engines
+)
+UPDATE cars c
+SET engine_id = r.keep_id
+FROM ranked r
+WHERE c.engine_id = r.id AND r.id <> r.keep_id;
+
+WITH ranked AS (
+    SELECT id,
+           FIRST_VALUE(id) OVER (
+               PARTITION BY displacement, number_of_cylinders, car_range
+               ORDER BY created_at, id
+           ) AS keep_id
+    FROM engines
+)
+DELETE FROM engines e
+USING ranked r
+WHERE e.id = r.id AND r.id <> r.keep_id;
+
+ALTER TABLE engines ADD CONSTRAINT engines_spec_key UNIQUE (displacement, number_of_cylinders, car_range);
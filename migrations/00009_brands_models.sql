@@ -0,0 +1,111 @@
+-- +goose Up
+-- Brand and model names match ignoring case, spacing and punctuation, so
+-- "BMW", "bmw" and "B.M.W" all name the same brand
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reference_key(value TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+    AS $$ SELECT lower(regexp_replace(value, '[^[:alnum:]]+', '', 'g')) $$;
+-- +goose StatementEnd
+
+CREATE TABLE IF NOT EXISTS brands (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS brands_name_key ON brands (reference_key(name));
+
+-- Alternative names such as "VW" for "Volkswagen"
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    brand_id UUID NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    alias VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS brand_aliases_alias_key ON brand_aliases (reference_key(alias));
+CREATE INDEX IF NOT EXISTS brand_aliases_brand_id_idx ON brand_aliases (brand_id);
+
+-- Vehicle model lines, named uniquely within their brand
+CREATE TABLE IF NOT EXISTS models (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    brand_id UUID NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT models_id_brand_id_key UNIQUE (id, brand_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS models_name_key ON models (brand_id, reference_key(name));
+
+CREATE TABLE IF NOT EXISTS model_aliases (
+    model_id UUID NOT NULL,
+    brand_id UUID NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    FOREIGN KEY (model_id, brand_id) REFERENCES models(id, brand_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS model_aliases_alias_key ON model_aliases (brand_id, reference_key(alias));
+CREATE INDEX IF NOT EXISTS model_aliases_model_id_idx ON model_aliases (model_id);
+
+-- Backfill one brand per distinct spelling, named after its most common
+-- spelling in the existing cars
+INSERT INTO brands (name)
+SELECT DISTINCT ON (reference_key(brand)) brand
+FROM (SELECT brand, COUNT(*) AS uses FROM cars GROUP BY brand) spellings
+ORDER BY reference_key(brand), uses DESC, brand;
+
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS brand_id UUID REFERENCES brands(id) ON DELETE RESTRICT;
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS model_id UUID;
+
+UPDATE cars c
+SET brand_id = b.id
+FROM brands b
+WHERE reference_key(b.name) = reference_key(c.brand);
+
+-- Car names that lead with their brand ("Honda Civic") give the model line
+INSERT INTO models (brand_id, name)
+SELECT DISTINCT ON (c.brand_id, reference_key(m.name)) c.brand_id, m.name
+FROM cars c
+CROSS JOIN LATERAL (SELECT btrim(substr(c.name, length(c.brand) + 1)) AS name) m
+WHERE lower(c.name) LIKE lower(c.brand) || ' %'
+AND reference_key(m.name) <> ''
+ORDER BY c.brand_id, reference_key(m.name), m.name;
+
+UPDATE cars c
+SET model_id = m.id
+FROM models m
+WHERE m.brand_id = c.brand_id
+AND lower(c.name) LIKE lower(c.brand) || ' %'
+AND reference_key(m.name) = reference_key(substr(c.name, length(c.brand) + 1));
+
+ALTER TABLE cars ALTER COLUMN brand_id SET NOT NULL;
+
+-- A car's model must belong to the car's brand
+ALTER TABLE cars ADD CONSTRAINT cars_model_fk FOREIGN KEY (model_id, brand_id)
+    REFERENCES models(id, brand_id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS cars_brand_id_idx ON cars (brand_id);
+CREATE INDEX IF NOT EXISTS cars_model_id_idx ON cars (model_id);
+
+ALTER TABLE cars DROP COLUMN IF EXISTS brand;
+
+-- +goose Down
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS brand VARCHAR(255);
+
+UPDATE cars c
+SET brand = b.name
+FROM brands b
+WHERE b.id = c.brand_id;
+
+ALTER TABLE cars ALTER COLUMN brand SET NOT NULL;
+
+DROP INDEX IF EXISTS cars_model_id_idx;
+DROP INDEX IF EXISTS cars_brand_id_idx;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS cars_model_fk;
+ALTER TABLE cars DROP COLUMN IF EXISTS model_id;
+ALTER TABLE cars DROP COLUMN IF EXISTS brand_id;
+DROP TABLE IF EXISTS model_aliases;
+DROP TABLE IF EXISTS models;
+DROP TABLE IF EXISTS brand_aliases;
+DROP TABLE IF EXISTS brands;
+DROP FUNCTION IF EXISTS reference_key(TEXT);
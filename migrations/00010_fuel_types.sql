@@ -0,0 +1,20 @@
+-- +goose Up
+-- Fuel types are a fixed set of lowercase values; the seed data and older
+-- clients wrote capitalized and alternative spellings such as "Gasoline"
+CREATE TYPE fuel_type AS ENUM ('petrol', 'diesel', 'electric', 'hybrid');
+
+ALTER TABLE cars ALTER COLUMN fuel_type TYPE fuel_type USING (
+    CASE lower(btrim(fuel_type))
+        WHEN 'gasoline' THEN 'petrol'
+        WHEN 'gas' THEN 'petrol'
+        WHEN 'ev' THEN 'electric'
+        WHEN 'bev' THEN 'electric'
+        WHEN 'hev' THEN 'hybrid'
+        WHEN 'phev' THEN 'hybrid'
+        ELSE lower(btrim(fuel_type))
+    END
+)::fuel_type;
+
+-- +goose Down
+ALTER TABLE cars ALTER COLUMN fuel_type TYPE VARCHAR(50) USING fuel_type::text;
+DROP TYPE IF EXISTS fuel_type;
@@ -0,0 +1,63 @@
+-- +goose Up
+-- Trim levels of a model line, such as "LX" or "Touring"
+CREATE TABLE IF NOT EXISTS trims (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT trims_id_model_id_key UNIQUE (id, model_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS trims_name_key ON trims (model_id, reference_key(name));
+
+-- A car's trim must belong to the car's model
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS trim_id UUID;
+ALTER TABLE cars ADD CONSTRAINT cars_trim_fk FOREIGN KEY (trim_id, model_id)
+    REFERENCES trims(id, model_id) ON DELETE RESTRICT;
+ALTER TABLE cars ADD CONSTRAINT cars_trim_needs_model CHECK (trim_id IS NULL OR model_id IS NOT NULL);
+CREATE INDEX IF NOT EXISTS cars_trim_id_idx ON cars (trim_id);
+
+-- Catalog of options and packages that can be fitted to a car
+CREATE TABLE IF NOT EXISTS options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('feature', 'package', 'color')),
+    price NUMERIC(18, 2) NOT NULL CHECK (price >= 0),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Pairs of options that cannot be fitted together, stored once per pair
+CREATE TABLE IF NOT EXISTS option_incompatibilities (
+    option_a UUID NOT NULL REFERENCES options(id) ON DELETE CASCADE,
+    option_b UUID NOT NULL REFERENCES options(id) ON DELETE CASCADE,
+    PRIMARY KEY (option_a, option_b),
+    CHECK (option_a < option_b)
+);
+
+CREATE INDEX IF NOT EXISTS option_incompatibilities_option_b_idx ON option_incompatibilities (option_b);
+
+-- Options fitted to a car, priced in the car's currency when they were fitted
+CREATE TABLE IF NOT EXISTS car_options (
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    option_id UUID NOT NULL REFERENCES options(id) ON DELETE RESTRICT,
+    price NUMERIC(18, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (car_id, option_id)
+);
+
+CREATE INDEX IF NOT EXISTS car_options_option_id_idx ON car_options (option_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS car_options;
+DROP TABLE IF EXISTS option_incompatibilities;
+DROP TABLE IF EXISTS options;
+DROP INDEX IF EXISTS cars_trim_id_idx;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS cars_trim_needs_model;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS cars_trim_fk;
+ALTER TABLE cars DROP COLUMN IF EXISTS trim_id;
+DROP TABLE IF EXISTS trims;
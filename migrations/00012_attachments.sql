@@ -0,0 +1,22 @@
+-- +goose Up
+-- Files attached to a car. The bytes live in blob storage under storage_key;
+-- images also get a JPEG thumbnail under thumbnail_key
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE RESTRICT,
+    kind VARCHAR(32) NOT NULL CHECK (kind IN ('photo', 'registration', 'inspection_report', 'other')),
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    width INT,
+    height INT,
+    storage_key VARCHAR(512) NOT NULL UNIQUE,
+    thumbnail_key VARCHAR(512),
+    uploaded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS attachments_car_id_idx ON attachments (car_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- Branches and warehouses that hold stock
+CREATE TABLE IF NOT EXISTS locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(32) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('branch', 'warehouse')),
+    address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Where a car is kept. It only changes through a received transfer once set
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS location_id UUID REFERENCES locations(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS cars_location_id_idx ON cars (location_id);
+
+-- Movements of a car between locations. A transfer is in transit until the
+-- destination receives the car or the transfer is cancelled
+CREATE TABLE IF NOT EXISTS transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    from_location_id UUID NOT NULL REFERENCES locations(id) ON DELETE RESTRICT,
+    to_location_id UUID NOT NULL REFERENCES locations(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_transit' CHECK (status IN ('in_transit', 'received', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    completed_by VARCHAR(255),
+    dispatched_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ,
+    CHECK (from_location_id <> to_location_id)
+);
+
+-- A car can only be on its way to one place at a time
+CREATE UNIQUE INDEX IF NOT EXISTS transfers_in_transit_key ON transfers (car_id) WHERE status = 'in_transit';
+CREATE INDEX IF NOT EXISTS transfers_car_id_idx ON transfers (car_id, dispatched_at);
+CREATE INDEX IF NOT EXISTS transfers_from_location_id_idx ON transfers (from_location_id);
+CREATE INDEX IF NOT EXISTS transfers_to_location_id_idx ON transfers (to_location_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS transfers;
+DROP INDEX IF EXISTS cars_location_id_idx;
+ALTER TABLE cars DROP COLUMN IF EXISTS location_id;
+DROP TABLE IF EXISTS locations;
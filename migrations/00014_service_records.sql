@@ -0,0 +1,36 @@
+-- +goose Up
+-- Maintenance performed on a car, in the order it happened
+CREATE TABLE IF NOT EXISTS service_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    service_date DATE NOT NULL,
+    odometer_km BIGINT NOT NULL CHECK (odometer_km >= 0),
+    work_done TEXT NOT NULL,
+    cost NUMERIC(18, 2) NOT NULL CHECK (cost >= 0),
+    currency CHAR(3) NOT NULL,
+    workshop VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS service_records_car_id_idx ON service_records (car_id, service_date);
+
+-- How often each powertrain needs servicing: whichever of the distance and
+-- the time interval comes first
+CREATE TABLE IF NOT EXISTS service_intervals (
+    powertrain VARCHAR(10) PRIMARY KEY CHECK (powertrain IN ('ice', 'hybrid', 'bev')),
+    interval_km BIGINT NOT NULL CHECK (interval_km > 0),
+    interval_months INT NOT NULL CHECK (interval_months > 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO service_intervals (powertrain, interval_km, interval_months) VALUES
+    ('ice', 15000, 12),
+    ('hybrid', 15000, 12),
+    ('bev', 30000, 24)
+ON CONFLICT (powertrain) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS service_intervals;
+DROP TABLE IF EXISTS service_records;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- Used-vehicle details. Existing cars are all new
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS condition VARCHAR(10) NOT NULL DEFAULT 'new'
+    CHECK (condition IN ('new', 'used'));
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS odometer_km BIGINT NOT NULL DEFAULT 0 CHECK (odometer_km >= 0);
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS condition_grade SMALLINT CHECK (condition_grade BETWEEN 1 AND 5);
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS previous_owners INT NOT NULL DEFAULT 0 CHECK (previous_owners >= 0);
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS accident_history VARCHAR(10) NOT NULL DEFAULT 'none'
+    CHECK (accident_history IN ('none', 'minor', 'major'));
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS registration_number VARCHAR(20);
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS registration_date DATE;
+ALTER TABLE cars ADD COLUMN IF NOT EXISTS registration_region VARCHAR(100);
+
+-- A new car has never been owned or damaged; a used car is graded
+ALTER TABLE cars ADD CONSTRAINT cars_new_condition_check CHECK (
+    condition = 'used' OR (previous_owners = 0 AND accident_history = 'none' AND condition_grade IS NULL)
+);
+ALTER TABLE cars ADD CONSTRAINT cars_used_grade_check CHECK (condition = 'new' OR condition_grade IS NOT NULL);
+ALTER TABLE cars ADD CONSTRAINT cars_registration_check CHECK (
+    registration_number IS NOT NULL OR (registration_date IS NULL AND registration_region IS NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS cars_registration_number_key ON cars (registration_number)
+    WHERE registration_number IS NOT NULL;
+CREATE INDEX IF NOT EXISTS cars_condition_idx ON cars (condition, odometer_km);
+
+-- Certified pre-owned inspection of a used car, one checklist per car
+CREATE TABLE IF NOT EXISTS car_inspections (
+    car_id UUID PRIMARY KEY REFERENCES cars(id) ON DELETE CASCADE,
+    inspector VARCHAR(255) NOT NULL,
+    inspected_on DATE NOT NULL,
+    items JSONB NOT NULL,
+    passed BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS car_inspections;
+DROP INDEX IF EXISTS cars_condition_idx;
+DROP INDEX IF EXISTS cars_registration_number_key;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS cars_registration_check;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS cars_used_grade_check;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS cars_new_condition_check;
+ALTER TABLE cars DROP COLUMN IF EXISTS registration_region;
+ALTER TABLE cars DROP COLUMN IF EXISTS registration_date;
+ALTER TABLE cars DROP COLUMN IF EXISTS registration_number;
+ALTER TABLE cars DROP COLUMN IF EXISTS accident_history;
+ALTER TABLE cars DROP COLUMN IF EXISTS previous_owners;
+ALTER TABLE cars DROP COLUMN IF EXISTS condition_grade;
+ALTER TABLE cars DROP COLUMN IF EXISTS odometer_km;
+ALTER TABLE cars DROP COLUMN IF EXISTS condition;
@@ -0,0 +1,69 @@
+-- +goose Up
+-- Precomputed report data. The report refresher rebuilds these views in the
+-- background; each has a unique index so it can be refreshed concurrently
+-- without blocking readers.
+
+-- Cars in stock grouped by every report dimension and currency. Totals are
+-- the 'total' dimension with the key 'all'
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_inventory_groups AS
+WITH stock AS (
+    SELECT
+        c.year::text AS year, c.fuel_type::text AS fuel_type, c.currency,
+        b.name AS brand, COALESCE(l.name, 'Unassigned') AS location,
+        c.price + COALESCE((SELECT SUM(co.price) FROM car_options co WHERE co.car_id = c.id), 0) AS value
+    FROM cars c
+    JOIN brands b ON b.id = c.brand_id
+    LEFT JOIN locations l ON l.id = c.location_id
+    WHERE NOT EXISTS (SELECT 1 FROM orders o WHERE o.car_id = c.id)
+)
+SELECT d.dimension, d.key, s.currency, COUNT(*) AS count, SUM(s.value) AS total_value,
+    ROUND(AVG(s.value), 2) AS average_value
+FROM stock s
+CROSS JOIN LATERAL (VALUES
+    ('total', 'all'),
+    ('brand', s.brand),
+    ('fuel_type', s.fuel_type),
+    ('year', s.year),
+    ('location', s.location)
+) AS d(dimension, key)
+GROUP BY d.dimension, d.key, s.currency;
+
+CREATE UNIQUE INDEX IF NOT EXISTS report_inventory_groups_key
+    ON report_inventory_groups (dimension, key, currency);
+
+-- Cars in stock per day they were added, for aging
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_stock_by_day AS
+SELECT c.created_at::date AS added_on, COUNT(*) AS count
+FROM cars c
+WHERE NOT EXISTS (SELECT 1 FROM orders o WHERE o.car_id = c.id)
+GROUP BY 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS report_stock_by_day_key ON report_stock_by_day (added_on);
+
+-- Cars sold per day and the days they had spent in stock, for sales velocity
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_daily_sales AS
+SELECT o.created_at::date AS day, COUNT(*) AS sales,
+    SUM(EXTRACT(EPOCH FROM o.created_at - c.created_at) / 86400) AS days_in_stock
+FROM orders o
+JOIN cars c ON c.id = o.car_id
+GROUP BY 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS report_daily_sales_key ON report_daily_sales (day);
+
+-- When each view was last refreshed, reported as data_as_of
+CREATE TABLE IF NOT EXISTS report_refreshes (
+    view_name VARCHAR(63) PRIMARY KEY,
+    refreshed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO report_refreshes (view_name) VALUES
+    ('report_inventory_groups'),
+    ('report_stock_by_day'),
+    ('report_daily_sales')
+ON CONFLICT (view_name) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS report_refreshes;
+DROP MATERIALIZED VIEW IF EXISTS report_daily_sales;
+DROP MATERIALIZED VIEW IF EXISTS report_stock_by_day;
+DROP MATERIALIZED VIEW IF EXISTS report_inventory_groups;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Who has a car open for editing. A row is one connection's interest in one
+-- car; connections refresh expires_at while they are open, so rows left by a
+-- crashed server run out on their own
+CREATE TABLE IF NOT EXISTS car_editors (
+    session_id UUID NOT NULL,
+    car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (session_id, car_id)
+);
+
+CREATE INDEX IF NOT EXISTS car_editors_car_id_idx ON car_editors (car_id);
+CREATE INDEX IF NOT EXISTS car_editors_expires_at_idx ON car_editors (expires_at);
+
+-- Soft locks: a claim to be the one editing a car, which others are shown
+-- but saves do not enforce. A lock past expires_at is free to take
+CREATE TABLE IF NOT EXISTS car_edit_locks (
+    car_id UUID PRIMARY KEY REFERENCES cars(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    acquired_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS car_edit_locks_expires_at_idx ON car_edit_locks (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS car_edit_locks;
+DROP TABLE IF EXISTS car_editors;
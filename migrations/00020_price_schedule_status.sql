@@ -0,0 +1,7 @@
+-- +goose Up
+-- Only the states ApplyDuePriceSchedules moves schedules through
+ALTER TABLE price_schedules ADD CONSTRAINT price_schedules_status_check
+    CHECK (status IN ('pending', 'active', 'completed', 'cancelled'));
+
+-- +goose Down
+ALTER TABLE price_schedules DROP CONSTRAINT IF EXISTS price_schedules_status_check;
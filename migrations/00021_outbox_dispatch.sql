@@ -0,0 +1,13 @@
+-- +goose Up
+-- Events are handed to the sinks inside the database, such as the webhook
+-- queue, separately from the external publisher, so an outage of the
+-- publisher does not hold them back. Events already published were handed
+-- over with it
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS dispatched_at TIMESTAMP;
+UPDATE outbox SET dispatched_at = published_at WHERE published_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS outbox_undispatched_idx ON outbox (aggregate_id, id) WHERE dispatched_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS outbox_undispatched_idx;
+ALTER TABLE outbox DROP COLUMN IF EXISTS dispatched_at;
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxAttachmentSize is the largest file that can be attached to a car.
+const MaxAttachmentSize = 10 << 20
+
+// Kinds of documents attached to a car.
+const (
+	AttachmentPhoto        = "photo"
+	AttachmentRegistration = "registration"
+	AttachmentInspection   = "inspection_report"
+	AttachmentOther        = "other"
+)
+
+var AttachmentKinds = []string{AttachmentPhoto, AttachmentRegistration, AttachmentInspection, AttachmentOther}
+
+// AttachmentContentTypes are the sniffed content types accepted for upload.
+var AttachmentContentTypes = []string{"image/jpeg", "image/png", "image/gif", "application/pdf"}
+
+type Attachment struct {
+	ID           uuid.UUID `json:"id"`
+	CarID        uuid.UUID `json:"car_id"`
+	Kind         string    `json:"kind"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	Width        *int      `json:"width,omitempty"`
+	Height       *int      `json:"height,omitempty"`
+	StorageKey   string    `json:"-"`
+	ThumbnailKey *string   `json:"-"`
+	UploadedBy   string    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	// DownloadURL and ThumbnailURL are signed and expire at URLExpiresAt.
+	DownloadURL  string     `json:"download_url,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty"`
+}
+
+// NormalizeAttachmentKind lower-cases a kind, defaulting it to other.
+func NormalizeAttachmentKind(kind string) string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		return AttachmentOther
+	}
+	return kind
+}
+
+func ValidateAttachment(attachment Attachment) error {
+	if !slices.Contains(AttachmentKinds, attachment.Kind) {
+		return errors.New("kind must be one of: " + strings.Join(AttachmentKinds, ", "))
+	}
+	if attachment.SizeBytes == 0 {
+		return errors.New("file is empty")
+	}
+	if attachment.SizeBytes > MaxAttachmentSize {
+		return errors.New("file must be at most 10 MiB")
+	}
+	if !slices.Contains(AttachmentContentTypes, attachment.ContentType) {
+		return errors.New("file must be a JPEG, PNG or GIF image or a PDF document")
+	}
+	if attachment.Kind == AttachmentPhoto && !strings.HasPrefix(attachment.ContentType, "image/") {
+		return errors.New("photos must be images")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// maxReferenceNameLength matches the VARCHAR(255) name and alias columns.
+const maxReferenceNameLength = 255
+
+type Brand struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type BrandRequest struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// VehicleModel is a model line of a brand, such as the Civic of Honda.
+type VehicleModel struct {
+	ID        uuid.UUID `json:"id"`
+	BrandID   uuid.UUID `json:"brand_id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type VehicleModelRequest struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// Trim is a trim level of a model line.
+type Trim struct {
+	ID          uuid.UUID `json:"id"`
+	ModelID     uuid.UUID `json:"model_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type TrimRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ReferenceKey is the form brand and model names are matched in: lower case
+// letters and digits only. It mirrors the reference_key database function.
+func ReferenceKey(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// NormalizeReferenceNames trims the name and aliases and drops aliases that
+// are blank or match the name or an earlier alias.
+func NormalizeReferenceNames(name *string, aliases *[]string) {
+	*name = strings.TrimSpace(*name)
+
+	seen := map[string]bool{ReferenceKey(*name): true}
+	kept := []string{}
+	for _, alias := range *aliases {
+		alias = strings.TrimSpace(alias)
+		key := ReferenceKey(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, alias)
+	}
+	*aliases = kept
+}
+
+func ValidateBrandRequest(brand BrandRequest) error {
+	return validateReferenceNames(brand.Name, brand.Aliases)
+}
+
+func ValidateVehicleModelRequest(model VehicleModelRequest) error {
+	return validateReferenceNames(model.Name, model.Aliases)
+}
+
+func ValidateTrimRequest(trim TrimRequest) error {
+	return validateReferenceName("name", trim.Name)
+}
+
+func validateReferenceNames(name string, aliases []string) error {
+	if err := validateReferenceName("name", name); err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		if err := validateReferenceName(fmt.Sprintf("alias %q", alias), alias); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateReferenceName(field, name string) error {
+	if name == "" {
+		return errors.New(field + " is required")
+	}
+	if len(name) > maxReferenceNameLength {
+		return fmt.Errorf("%s must be at most %d characters", field, maxReferenceNameLength)
+	}
+	if ReferenceKey(name) == "" {
+		return errors.New(field + " must contain a letter or digit")
+	}
+	return nil
+}
@@ -2,11 +2,13 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"slices"
 )
 
 type Car struct {
@@ -14,20 +16,64 @@ type Car struct {
 	Name      string    `json:"name"`
 	Year      string    `json:"year"`
 	Brand     string    `json:"brand"`
+	BrandID   uuid.UUID `json:"brand_id"`
+	Model     *string   `json:"model,omitempty"`
+	ModelID   *uuid.UUID `json:"model_id,omitempty"`
+	Trim      *string   `json:"trim,omitempty"`
+	TrimID    *uuid.UUID `json:"trim_id,omitempty"`
+	LocationID *uuid.UUID `json:"location_id,omitempty"`
+	Location  *string   `json:"location,omitempty"`
+	// InTransit is set while a transfer is moving the car away from its
+	// location.
+	InTransit bool      `json:"in_transit"`
+	Condition string    `json:"condition"`
+	OdometerKm int64    `json:"odometer_km"`
+	ConditionGrade *int `json:"condition_grade,omitempty"`
+	PreviousOwners int  `json:"previous_owners"`
+	AccidentHistory string `json:"accident_history"`
+	RegistrationNumber *string `json:"registration_number,omitempty"`
+	RegistrationDate *Date `json:"registration_date,omitempty"`
+	RegistrationRegion *string `json:"registration_region,omitempty"`
+	// CertifiedPreOwned is set on a used car whose inspection passed.
+	CertifiedPreOwned bool `json:"certified_pre_owned"`
 	FuelType  string    `json:"fuel_type"`
 	Engine    Engine    `json:"engine"`
-	Price     float64   `json:"price"`
+	Price     Money     `json:"price"`
+	Currency  string    `json:"currency"`
+	// TotalPrice is the price plus the options fitted to the car.
+	TotalPrice Money    `json:"total_price"`
+	// Options is only loaded where a listing says so.
+	Options []CarOption `json:"options,omitempty"`
+	// ConvertedPrice is only set when the client asked for another currency.
+	ConvertedPrice *ConvertedPrice `json:"converted_price,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// CarRequest names the brand and, optionally, the model line and trim in free
+// text. They are resolved against the reference data ignoring case and
+// punctuation. LocationID places a car that has no location yet; cars that
+// have one move through transfers.
 type CarRequest struct {
 	Name     string  `json:"name"`
 	Year     string  `json:"year"`
 	Brand    string  `json:"brand"`
+	Model    string  `json:"model"`
+	Trim     string  `json:"trim"`
+	LocationID *uuid.UUID `json:"location_id"`
+	// Condition defaults to new; used cars also carry the fields below it.
+	Condition string `json:"condition"`
+	OdometerKm int64 `json:"odometer_km"`
+	ConditionGrade *int `json:"condition_grade"`
+	PreviousOwners int `json:"previous_owners"`
+	AccidentHistory string `json:"accident_history"`
+	RegistrationNumber string `json:"registration_number"`
+	RegistrationDate *Date `json:"registration_date"`
+	RegistrationRegion string `json:"registration_region"`
 	FuelType string  `json:"fuel_type"`
 	Engine   Engine  `json:"engine"`
-	Price    float64 `json:"price"`
+	Price    Money   `json:"price"`
+	Currency string  `json:"currency"`
 }
 
 func ValidateCarRequest(car CarRequest) error {
@@ -40,15 +86,56 @@ func ValidateCarRequest(car CarRequest) error {
 	if err := validateBrand(car.Brand); err != nil {
 		return err
 	}
-	if err := validateFuelType(car.FuelType); err != nil {
+	if car.Trim != "" && car.Model == "" {
+		return errors.New("a trim requires a model")
+	}
+	if err := ValidateFuelType(car.FuelType); err != nil {
 		return err
 	}
-	if err := validateEngine(car.Engine); err != nil {
+	if err := validateEngine(car.Engine, car.FuelType); err != nil {
 		return err
 	}
 	if err := validatePrice(car.Price); err != nil {
 		return err
 	}
+	if err := ValidateCurrency(car.Currency); err != nil {
+		return err
+	}
+	if err := validateUsedDetails(car); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CarFilter narrows a car listing. Brand is required; the other fields are
+// optional and combine with AND.
+type CarFilter struct {
+	Brand    string
+	IsEngine bool
+	// Options lists option codes the car must all be fitted with.
+	Options         []string
+	Condition       string
+	MinOdometerKm   *int64
+	MaxOdometerKm   *int64
+	MinGrade        *int
+	MaxOwners       *int
+	AccidentHistory string
+	Certified       *bool
+}
+
+func ValidateCarFilter(filter CarFilter) error {
+	if filter.Condition != "" && !slices.Contains(Conditions, filter.Condition) {
+		return errors.New("condition must be one of: " + strings.Join(Conditions, ", "))
+	}
+	if filter.AccidentHistory != "" && !slices.Contains(AccidentHistories, filter.AccidentHistory) {
+		return errors.New("accident_history must be one of: " + strings.Join(AccidentHistories, ", "))
+	}
+	if filter.MinOdometerKm != nil && filter.MaxOdometerKm != nil && *filter.MinOdometerKm > *filter.MaxOdometerKm {
+		return errors.New("min_odometer must not exceed max_odometer")
+	}
+	if filter.MinGrade != nil && (*filter.MinGrade < MinConditionGrade || *filter.MinGrade > MaxConditionGrade) {
+		return fmt.Errorf("min_grade must be between %d and %d", MinConditionGrade, MaxConditionGrade)
+	}
 	return nil
 }
 
@@ -84,34 +171,17 @@ func validateBrand(brand string) error {
 	return nil
 }
 
-func validateFuelType(fuelType string) error {
-	validFuelTypes := []string{"petrol", "diesel", "electric", "hybrid", "Petrol", "Diesel", "Electric", "Hybrid"}
-	if slices.Contains(validFuelTypes, fuelType) {
+// validateEngine accepts either a reference to an existing engine, in which
+// case the spec fields are ignored and the store checks the stored engine, or
+// a complete inline spec suitable for the car's fuel type.
+func validateEngine(engine Engine, fuelType string) error {
+	if engine.EngineID != uuid.Nil {
 		return nil
 	}
-	return errors.New("fuel type must be one of: petrol, diesel, electric, hybrid")
-}
-
-func validateEngine(engine Engine) error {
-	if engine.EngineID == uuid.Nil {
-		return errors.New("engine ID is required")
-	}
-	if engine.Displacement <= 0 {
-		return errors.New("displacement must be greater than 0")
-	}
-	if engine.Displacement < 1000 {
-		return errors.New("displacement must be at least 1000")
-	}
-	if engine.NumberOfCylinders <= 0 {
-		return errors.New("number of cylinders must be greater than 0")
-	}
-	if engine.CarRange <= 0 {
-		return errors.New("car range must be greater than 0")
-	}
-	return nil
+	return ValidateEngineForFuelType(engine.EngineSpec, fuelType)
 }
 
-func validatePrice(price float64) error {
+func validatePrice(price Money) error {
 	if price <= 0 {
 		return errors.New("price must be greater than 0")
 	}
@@ -0,0 +1,272 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Cars compared side by side.
+const (
+	MinComparedCars = 2
+	MaxComparedCars = 5
+)
+
+// Which end of an attribute's range is better.
+const (
+	BetterLower  = "lower"
+	BetterHigher = "higher"
+)
+
+// CarComparison is an attribute matrix: every row holds one value per car,
+// in the order of Cars. Prices are in Currency.
+type CarComparison struct {
+	Currency string          `json:"currency"`
+	Cars     []ComparedCar   `json:"cars"`
+	Rows     []ComparisonRow `json:"rows"`
+}
+
+type ComparedCar struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Brand string    `json:"brand"`
+	Model *string   `json:"model,omitempty"`
+	Trim  *string   `json:"trim,omitempty"`
+	Year  string    `json:"year"`
+}
+
+// ComparisonRow is one attribute across the compared cars. A nil value means
+// the attribute does not apply to that car. Best lists the cars holding the
+// best value; it is empty when the attribute is not ranked or every car ties.
+type ComparisonRow struct {
+	Group  string      `json:"group"`
+	Key    string      `json:"key"`
+	Label  string      `json:"label"`
+	Unit   string      `json:"unit,omitempty"`
+	Better string      `json:"better,omitempty"`
+	Values []any       `json:"values"`
+	Best   []uuid.UUID `json:"best"`
+}
+
+// ValidateComparisonIDs requires between two and five distinct car ids.
+func ValidateComparisonIDs(ids []string) error {
+	if len(ids) < MinComparedCars || len(ids) > MaxComparedCars {
+		return fmt.Errorf("compare between %d and %d cars", MinComparedCars, MaxComparedCars)
+	}
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("invalid car id %q", id)
+		}
+		if seen[parsed] {
+			return errors.New("each car can only be compared once")
+		}
+		seen[parsed] = true
+	}
+	return nil
+}
+
+// accidentRanks orders accident histories from best to worst.
+var accidentRanks = map[string]float64{AccidentNone: 0, AccidentMinor: 1, AccidentMajor: 2}
+
+// comparisonAttribute describes how to read and rank one row. rank maps a
+// value onto a number to compare; attributes without one are not ranked.
+type comparisonAttribute struct {
+	group, key, label, unit, better string
+	value                           func(car Car) any
+	rank                            func(value any) float64
+}
+
+// NewCarComparison builds the comparison matrix of cars. Prices are
+// expressed in currency through each car's ConvertedPrice rate, which the
+// caller must set when a car is priced in another currency.
+func NewCarComparison(cars []Car, currency string) CarComparison {
+	comparison := CarComparison{Currency: currency, Cars: make([]ComparedCar, len(cars)), Rows: []ComparisonRow{}}
+	for i, car := range cars {
+		comparison.Cars[i] = ComparedCar{ID: car.ID, Name: car.Name, Brand: car.Brand, Model: car.Model, Trim: car.Trim, Year: car.Year}
+	}
+
+	for _, attribute := range comparisonAttributes() {
+		row := ComparisonRow{
+			Group:  attribute.group,
+			Key:    attribute.key,
+			Label:  attribute.label,
+			Unit:   attribute.unit,
+			Better: attribute.better,
+			Values: make([]any, len(cars)),
+		}
+		for i, car := range cars {
+			row.Values[i] = attribute.value(car)
+		}
+		row.Best = bestCars(cars, row.Values, attribute)
+		comparison.Rows = append(comparison.Rows, row)
+	}
+
+	comparison.Rows = append(comparison.Rows, optionRows(cars)...)
+	return comparison
+}
+
+func comparisonAttributes() []comparisonAttribute {
+	return []comparisonAttribute{
+		{group: "general", key: "brand", label: "Brand", value: func(c Car) any { return c.Brand }},
+		{group: "general", key: "model", label: "Model", value: func(c Car) any { return optional(c.Model) }},
+		{group: "general", key: "trim", label: "Trim", value: func(c Car) any { return optional(c.Trim) }},
+		{group: "general", key: "year", label: "Year", better: BetterHigher, value: func(c Car) any { return c.Year }, rank: rankYear},
+		{group: "general", key: "condition", label: "Condition", value: func(c Car) any { return c.Condition }},
+		{group: "general", key: "fuel_type", label: "Fuel type", value: func(c Car) any { return c.FuelType }},
+		{group: "general", key: "location", label: "Location", value: func(c Car) any { return optional(c.Location) }},
+
+		{group: "price", key: "price", label: "Price", better: BetterLower, value: func(c Car) any { return comparedAmount(c, c.Price) }, rank: rankNumber},
+		{group: "price", key: "total_price", label: "Price with options", better: BetterLower, value: func(c Car) any { return comparedAmount(c, c.TotalPrice) }, rank: rankNumber},
+
+		{group: "engine", key: "powertrain", label: "Powertrain", value: func(c Car) any { return c.Engine.Powertrain }},
+		{group: "engine", key: "power_kw", label: "Power", unit: "kW", better: BetterHigher, value: func(c Car) any { return positive(c.Engine.PowerKW) }, rank: rankNumber},
+		{group: "engine", key: "torque_nm", label: "Torque", unit: "Nm", better: BetterHigher, value: func(c Car) any { return positive(c.Engine.TorqueNm) }, rank: rankNumber},
+		{group: "engine", key: "displacement", label: "Displacement", unit: "cc", value: func(c Car) any { return positive(c.Engine.Displacement) }},
+		{group: "engine", key: "number_of_cylinders", label: "Cylinders", value: func(c Car) any { return positive(c.Engine.NumberOfCylinders) }},
+		{group: "engine", key: "car_range", label: "Range", unit: "km", better: BetterHigher, value: func(c Car) any { return positive(c.Engine.CarRange) }, rank: rankNumber},
+		{group: "engine", key: "battery_capacity_kwh", label: "Battery capacity", unit: "kWh", better: BetterHigher, value: func(c Car) any {
+			if c.Engine.BatteryCapacityKWh <= 0 {
+				return nil
+			}
+			return c.Engine.BatteryCapacityKWh
+		}, rank: rankNumber},
+		{group: "engine", key: "emissions_class", label: "Emissions class", value: func(c Car) any { return c.Engine.EmissionsClass }},
+		{group: "engine", key: "co2_g_per_km", label: "CO2 emissions", unit: "g/km", better: BetterLower, value: func(c Car) any { return c.Engine.CO2GPerKm }, rank: rankNumber},
+
+		{group: "history", key: "odometer_km", label: "Odometer", unit: "km", better: BetterLower, value: func(c Car) any { return c.OdometerKm }, rank: rankNumber},
+		{group: "history", key: "condition_grade", label: "Condition grade", better: BetterHigher, value: func(c Car) any {
+			if c.ConditionGrade == nil {
+				return nil
+			}
+			return *c.ConditionGrade
+		}, rank: rankNumber},
+		{group: "history", key: "previous_owners", label: "Previous owners", better: BetterLower, value: func(c Car) any { return c.PreviousOwners }, rank: rankNumber},
+		{group: "history", key: "accident_history", label: "Accident history", better: BetterLower, value: func(c Car) any { return c.AccidentHistory }, rank: func(v any) float64 {
+			return accidentRanks[v.(string)]
+		}},
+		{group: "history", key: "certified_pre_owned", label: "Certified pre-owned", better: BetterHigher, value: func(c Car) any { return c.CertifiedPreOwned }, rank: func(v any) float64 {
+			if v.(bool) {
+				return 1
+			}
+			return 0
+		}},
+	}
+}
+
+// optionRows lists every option fitted to any of the cars, with its fitted
+// price on the cars that have it.
+func optionRows(cars []Car) []ComparisonRow {
+	var rows []ComparisonRow
+	index := map[uuid.UUID]int{}
+	for i, car := range cars {
+		for _, option := range car.Options {
+			n, ok := index[option.OptionID]
+			if !ok {
+				n = len(rows)
+				index[option.OptionID] = n
+				rows = append(rows, ComparisonRow{
+					Group:  "options",
+					Key:    "option:" + option.Code,
+					Label:  option.Name,
+					Values: make([]any, len(cars)),
+					Best:   []uuid.UUID{},
+				})
+			}
+			rows[n].Values[i] = comparedAmount(car, option.Price)
+		}
+	}
+	return rows
+}
+
+// bestCars returns the cars with the best ranked value, or none when fewer
+// than two cars have a value or they all tie.
+func bestCars(cars []Car, values []any, attribute comparisonAttribute) []uuid.UUID {
+	best := []uuid.UUID{}
+	if attribute.rank == nil {
+		return best
+	}
+
+	var ranks []float64
+	var holders []int
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		ranks = append(ranks, attribute.rank(v))
+		holders = append(holders, i)
+	}
+	if len(ranks) < 2 {
+		return best
+	}
+
+	top, allEqual := ranks[0], true
+	for _, r := range ranks[1:] {
+		if r != ranks[0] {
+			allEqual = false
+		}
+		if (attribute.better == BetterLower && r < top) || (attribute.better == BetterHigher && r > top) {
+			top = r
+		}
+	}
+	if allEqual {
+		return best
+	}
+	for n, r := range ranks {
+		if r == top {
+			best = append(best, cars[holders[n]].ID)
+		}
+	}
+	return best
+}
+
+// comparedAmount expresses an amount of car's currency in the comparison
+// currency, or nil when the converted amount is out of range.
+func comparedAmount(car Car, amount Money) any {
+	if car.ConvertedPrice == nil {
+		return amount
+	}
+	converted, err := amount.Convert(car.ConvertedPrice.Rate)
+	if err != nil {
+		return nil
+	}
+	return converted
+}
+
+func rankNumber(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	case Money:
+		return float64(n)
+	}
+	return 0
+}
+
+func rankYear(v any) float64 {
+	var year int
+	fmt.Sscan(v.(string), &year)
+	return float64(year)
+}
+
+func optional(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// positive treats zero as not applicable, as for the displacement of an
+// electric car.
+func positive(n int64) any {
+	if n <= 0 {
+		return nil
+	}
+	return n
+}
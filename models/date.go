@@ -0,0 +1,65 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without a time of day, encoded as "2006-01-02".
+type Date struct {
+	time.Time
+}
+
+const dateLayout = "2006-01-02"
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return Date{t}, nil
+}
+
+// DateOf is the calendar date of t in its own time zone.
+func DateOf(t time.Time) Date {
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	v, err := ParseDate(unquoteNumber(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = Date{time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC)}
+		return nil
+	case string:
+		parsed, err := ParseDate(v)
+		*d = parsed
+		return err
+	case nil:
+		*d = Date{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into a date", src)
+	}
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
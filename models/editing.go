@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Commands an editing client sends.
+const (
+	EditingSubscribe   = "subscribe"
+	EditingUnsubscribe = "unsubscribe"
+	EditingLock        = "lock"
+	EditingUnlock      = "unlock"
+)
+
+// Messages an editing client receives. A presence message is sent to every
+// subscriber of a car whenever its editors or lock change.
+const (
+	EditingPresence   = "presence"
+	EditingLockDenied = "lock_denied"
+	EditingCarUpdated = "car_updated"
+	EditingCarDeleted = "car_deleted"
+	EditingError      = "error"
+)
+
+// EditingCommand is a message from an editing client.
+type EditingCommand struct {
+	Type  string    `json:"type"`
+	CarID uuid.UUID `json:"car_id"`
+}
+
+// EditingMessage is a message to an editing client. Presence is set on
+// presence messages, Lock on lock_denied with the lock that is held, and
+// Car on car_updated with the car as saved.
+type EditingMessage struct {
+	Type     string          `json:"type"`
+	CarID    *uuid.UUID      `json:"car_id,omitempty"`
+	Presence *CarPresence    `json:"presence,omitempty"`
+	Lock     *CarEditLock    `json:"lock,omitempty"`
+	EventID  int64           `json:"event_id,omitempty"`
+	Car      json.RawMessage `json:"car,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// CarPresence is who has a car open and who, if anyone, holds its lock.
+type CarPresence struct {
+	Editors []CarEditor  `json:"editors"`
+	Lock    *CarEditLock `json:"lock"`
+}
+
+// CarEditor is a user with a car open, in one or more connections.
+type CarEditor struct {
+	Username string    `json:"username"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// CarEditLock is a soft lock on a car. It is shown to other editors but not
+// enforced on saves, and lapses at ExpiresAt unless renewed.
+type CarEditLock struct {
+	Username   string    `json:"username"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// EditingSession is one editing connection. Messages is closed when the
+// session falls too far behind to catch up or the server stops, after which
+// the client reconnects.
+type EditingSession struct {
+	ID       uuid.UUID
+	Username string
+	Messages <-chan EditingMessage
+}
@@ -2,36 +2,147 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Powertrains an engine can have.
+const (
+	PowertrainICE    = "ice"
+	PowertrainHybrid = "hybrid"
+	PowertrainBEV    = "bev"
+)
+
+var Powertrains = []string{PowertrainICE, PowertrainHybrid, PowertrainBEV}
+
+// maxBatteryCapacityKWh bounds battery capacity to what the column can hold.
+const maxBatteryCapacityKWh = 100000
+
+// EngineSpec describes an engine. Combustion fields are zero for battery
+// electric engines and battery capacity is zero for pure combustion ones.
+type EngineSpec struct {
+	Powertrain         string  `json:"powertrain"`
+	Displacement       int64   `json:"displacement"`
+	NumberOfCylinders  int64   `json:"number_of_cylinders"`
+	CarRange           int64   `json:"car_range"`
+	PowerKW            int64   `json:"power_kw"`
+	TorqueNm           int64   `json:"torque_nm"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh"`
+	EmissionsClass     string  `json:"emissions_class"`
+	CO2GPerKm          int64   `json:"co2_g_per_km"`
+}
+
 type Engine struct {
 	EngineID uuid.UUID `json:"engine_id"`
-	Displacement int64 `json:"displacement"`
-	NumberOfCylinders int64 `json:"number_of_cylinders"`
-	CarRange int64 `json:"car_range"`
+	EngineSpec
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
 }
 
 type EngineRequest struct {
-	Displacement int64 `json:"displacement"`
-	NumberOfCylinders int64 `json:"number_of_cylinders"`
-	CarRange int64 `json:"car_range"`
-}	
+	EngineSpec
+}
 
 func ValidateEngineRequest(engine EngineRequest) error {
-	if err := validateDisplacement(engine.Displacement); err != nil {
-		return err
+	return validateEngineSpec(engine.EngineSpec)
+}
+
+// PowertrainForFuelType returns the powertrain a car running on fuelType
+// must have.
+func PowertrainForFuelType(fuelType string) string {
+	fuelType = NormalizeFuelType(fuelType)
+	for _, ft := range FuelTypes {
+		if ft.Value == fuelType {
+			return ft.Powertrain
+		}
 	}
-	if err := validateNumberOfCylinders(engine.NumberOfCylinders); err != nil {
-		return err
+	return PowertrainICE
+}
+
+// NormalizeEngineSpec lower-cases the powertrain, defaulting it to
+// defaultPowertrain when empty.
+func NormalizeEngineSpec(spec *EngineSpec, defaultPowertrain string) {
+	spec.Powertrain = strings.ToLower(strings.TrimSpace(spec.Powertrain))
+	if spec.Powertrain == "" {
+		spec.Powertrain = defaultPowertrain
+	}
+	spec.EmissionsClass = strings.TrimSpace(spec.EmissionsClass)
+}
+
+func validateEngineSpec(spec EngineSpec) error {
+	if !slices.Contains(Powertrains, spec.Powertrain) {
+		return errors.New("powertrain must be one of: " + strings.Join(Powertrains, ", "))
 	}
-	if err := validateCarRange(engine.CarRange); err != nil {
+	if err := validateCarRange(spec.CarRange); err != nil {
 		return err
 	}
+	if spec.PowerKW < 0 {
+		return errors.New("power must not be negative")
+	}
+	if spec.TorqueNm < 0 {
+		return errors.New("torque must not be negative")
+	}
+	if spec.BatteryCapacityKWh < 0 {
+		return errors.New("battery capacity must not be negative")
+	}
+	// Stored to one decimal place in NUMERIC(6,1)
+	if math.Round(spec.BatteryCapacityKWh*10) >= maxBatteryCapacityKWh*10 {
+		return fmt.Errorf("battery capacity must be less than %d kWh", maxBatteryCapacityKWh)
+	}
+	if spec.CO2GPerKm < 0 {
+		return errors.New("CO2 emissions must not be negative")
+	}
+
+	switch spec.Powertrain {
+	case PowertrainBEV:
+		if spec.Displacement != 0 || spec.NumberOfCylinders != 0 {
+			return errors.New("battery electric engines have no displacement or cylinders")
+		}
+		if spec.BatteryCapacityKWh <= 0 {
+			return errors.New("battery capacity is required for battery electric engines")
+		}
+		if spec.CO2GPerKm != 0 {
+			return errors.New("battery electric engines have no tailpipe CO2 emissions")
+		}
+	case PowertrainHybrid:
+		if err := validateCombustion(spec); err != nil {
+			return err
+		}
+		if spec.BatteryCapacityKWh <= 0 {
+			return errors.New("battery capacity is required for hybrid engines")
+		}
+	case PowertrainICE:
+		if err := validateCombustion(spec); err != nil {
+			return err
+		}
+		if spec.BatteryCapacityKWh != 0 {
+			return errors.New("combustion engines have no traction battery")
+		}
+	}
 	return nil
 }
 
+// ValidateEngineForFuelType checks that a car's engine can run on its fuel.
+func ValidateEngineForFuelType(spec EngineSpec, fuelType string) error {
+	want := PowertrainForFuelType(fuelType)
+	if spec.Powertrain != want {
+		return fmt.Errorf("a %s car needs a %s powertrain, got %s", strings.ToLower(fuelType), want, spec.Powertrain)
+	}
+	return validateEngineSpec(spec)
+}
+
+func validateCombustion(spec EngineSpec) error {
+	if err := validateDisplacement(spec.Displacement); err != nil {
+		return err
+	}
+	return validateNumberOfCylinders(spec.NumberOfCylinders)
+}
+
 func validateDisplacement(displacement int64) error {
 	if displacement <= 0 {
 		return errors.New("displacement must be greater than 0")
@@ -43,7 +154,7 @@ func validateNumberOfCylinders(numberOfCylinders int64) error {
 	if numberOfCylinders <= 0 {
 		return errors.New("number of cylinders must be greater than 0")
 	}
-	return nil	
+	return nil
 }
 
 func validateCarRange(carRange int64) error {
@@ -51,4 +162,58 @@ func validateCarRange(carRange int64) error {
 		return errors.New("car range must be greater than 0")
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// EngineFilter narrows and orders an engine listing. Nil bounds are ignored.
+type EngineFilter struct {
+	Powertrain      string
+	MinDisplacement *int64
+	MaxDisplacement *int64
+	Cylinders       *int64
+	MinRange        *int64
+	MaxRange        *int64
+	Sort            string
+	Descending      bool
+	Limit           int
+	Offset          int
+}
+
+// EngineUsage is an engine with statistics about the cars built on it.
+// Average prices are reported per currency since prices in different
+// currencies cannot be averaged together.
+type EngineUsage struct {
+	Engine
+	CarCount      int64            `json:"car_count"`
+	AveragePrices map[string]Money `json:"average_prices"`
+}
+
+// Sort keys accepted by engine listings. EngineSortCarCount only applies to
+// the usage view.
+const (
+	EngineSortDisplacement = "displacement"
+	EngineSortCylinders    = "number_of_cylinders"
+	EngineSortRange        = "car_range"
+	EngineSortPower        = "power_kw"
+	EngineSortCreatedAt    = "created_at"
+	EngineSortCarCount     = "car_count"
+)
+
+var EngineSorts = []string{
+	EngineSortDisplacement, EngineSortCylinders, EngineSortRange, EngineSortPower, EngineSortCreatedAt, EngineSortCarCount,
+}
+
+func ValidateEngineFilter(filter EngineFilter) error {
+	if filter.Powertrain != "" && !slices.Contains(Powertrains, filter.Powertrain) {
+		return errors.New("powertrain must be one of: " + strings.Join(Powertrains, ", "))
+	}
+	if filter.Sort != "" && !slices.Contains(EngineSorts, filter.Sort) {
+		return errors.New("sort must be one of: " + strings.Join(EngineSorts, ", "))
+	}
+	if filter.MinDisplacement != nil && filter.MaxDisplacement != nil && *filter.MinDisplacement > *filter.MaxDisplacement {
+		return errors.New("min_displacement must not exceed max_displacement")
+	}
+	if filter.MinRange != nil && filter.MaxRange != nil && *filter.MinRange > *filter.MaxRange {
+		return errors.New("min_range must not exceed max_range")
+	}
+	return ValidatePagination(filter.Limit, filter.Offset)
+}
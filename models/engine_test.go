@@ -0,0 +1,29 @@
+package models
+
+import "testing"
+
+func TestValidateEngineRequestBatteryCapacity(t *testing.T) {
+	tests := []struct {
+		kwh     float64
+		wantErr bool
+	}{
+		{kwh: 77.4},
+		{kwh: 99999.9},
+		{kwh: 99999.94},
+		{kwh: 99999.95, wantErr: true},
+		{kwh: 100000, wantErr: true},
+		{kwh: 1e12, wantErr: true},
+		{kwh: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		engine := EngineRequest{EngineSpec: EngineSpec{
+			Powertrain:         PowertrainBEV,
+			CarRange:           450,
+			BatteryCapacityKWh: tt.kwh,
+		}}
+		err := ValidateEngineRequest(engine)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("battery capacity %v: error = %v, want error %v", tt.kwh, err, tt.wantErr)
+		}
+	}
+}
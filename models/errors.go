@@ -0,0 +1,28 @@
+package models
+
+import "errors"
+
+// Sentinel errors shared by the store, service and handler layers. Stores and
+// services wrap them with context; handlers map them to HTTP status codes.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+)
+
+// ValidationError wraps err so that errors.Is(err, ErrValidation) holds while
+// keeping the original message for the client.
+func ValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &validationError{err: err}
+}
+
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string { return e.err.Error() }
+
+func (e *validationError) Unwrap() []error { return []error{ErrValidation, e.err} }
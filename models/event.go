@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of aggregate that emit events.
+const (
+	AggregateCar    = "car"
+	AggregateEngine = "engine"
+)
+
+// Event types written to the outbox.
+const (
+	EventCarCreated    = "car.created"
+	EventCarUpdated    = "car.updated"
+	EventCarDeleted    = "car.deleted"
+	EventCarPriced     = "car.priced"
+	EventCarSold       = "car.sold"
+	EventEngineCreated = "engine.created"
+	EventEngineUpdated = "engine.updated"
+	EventEngineDeleted = "engine.deleted"
+)
+
+// EventTypes lists every event type, in the order they are documented.
+var EventTypes = []string{
+	EventCarCreated, EventCarUpdated, EventCarDeleted, EventCarPriced, EventCarSold,
+	EventEngineCreated, EventEngineUpdated, EventEngineDeleted,
+}
+
+// Event is a change to an aggregate, recorded in the outbox in the same
+// transaction as the change and published afterwards. Events are delivered
+// at least once, so consumers should ignore IDs they have already seen.
+// IDs increase in the order the events of an aggregate happened.
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// DeletedPayload is the payload of a deletion event.
+type DeletedPayload struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// CarSoldPayload is the payload of a car.sold event. It leaves out the
+// buyer, whose details stay in the order.
+type CarSoldPayload struct {
+	CarID         uuid.UUID `json:"car_id"`
+	OrderID       uuid.UUID `json:"order_id"`
+	InvoiceNumber int64     `json:"invoice_number"`
+	SalePrice     Money     `json:"sale_price"`
+	Total         Money     `json:"total"`
+	Currency      string    `json:"currency"`
+	SoldAt        time.Time `json:"sold_at"`
+}
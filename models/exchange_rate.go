@@ -0,0 +1,147 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rate is an exchange rate scaled by 10^8, enough precision for any pair the
+// dealerships quote while staying exact.
+type Rate int64
+
+const rateScale = 8
+
+// RateOne is the identity rate.
+const RateOne Rate = 100000000
+
+// Rates are bounded to a million either way, which covers every currency
+// pair in use while keeping the inverse of any valid rate representable.
+const (
+	minRate Rate = 100
+	maxRate Rate = 1000000 * RateOne
+)
+
+type ExchangeRate struct {
+	ID            uuid.UUID `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          Rate      `json:"rate"`
+	EffectiveDate Date      `json:"effective_date"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ConvertedPrice is a car price expressed in another currency together with
+// the rate that produced it.
+type ConvertedPrice struct {
+	Amount        Money  `json:"amount"`
+	Currency      string `json:"currency"`
+	Rate          Rate   `json:"rate"`
+	EffectiveDate Date   `json:"effective_date"`
+}
+
+func ParseRate(s string) (Rate, error) {
+	v, err := parseFixed(s, rateScale)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q: %w", s, err)
+	}
+	return Rate(v), nil
+}
+
+func (r Rate) String() string {
+	return formatFixed(int64(r), rateScale)
+}
+
+// Inverse returns 1/r at the same precision. It fails for a zero rate and
+// for one so large that its inverse rounds to zero.
+func (r Rate) Inverse() (Rate, error) {
+	if r == 0 {
+		return 0, errOutOfRange
+	}
+	v, ok := mulDivRound(int64(RateOne), int64(RateOne), int64(r))
+	if !ok || v == 0 {
+		return 0, errOutOfRange
+	}
+	return Rate(v), nil
+}
+
+// Convert multiplies m by r, rounding half away from zero to the nearest
+// minor unit. The intermediate product is computed with big integers since
+// it can exceed int64 for large prices, and it fails when the result does
+// not fit in Money.
+func (m Money) Convert(r Rate) (Money, error) {
+	v, ok := mulDivRound(int64(m), int64(r), pow10(rateScale))
+	if !ok {
+		return 0, errOutOfRange
+	}
+	return Money(v), nil
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := ParseRate(unquoteNumber(data))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+func (r *Rate) Scan(src any) error {
+	v, err := scanFixed(src, rateScale)
+	if err != nil {
+		return err
+	}
+	*r = Rate(v)
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+func ValidateExchangeRate(rate ExchangeRate) error {
+	if err := ValidateCurrency(rate.BaseCurrency); err != nil {
+		return fmt.Errorf("base %w", err)
+	}
+	if err := ValidateCurrency(rate.QuoteCurrency); err != nil {
+		return fmt.Errorf("quote %w", err)
+	}
+	if rate.BaseCurrency == rate.QuoteCurrency {
+		return errors.New("base and quote currency must differ")
+	}
+	if rate.Rate < minRate || rate.Rate > maxRate {
+		return errors.New("rate must be between 0.000001 and 1000000")
+	}
+	if rate.EffectiveDate.IsZero() {
+		return errors.New("effective date is required")
+	}
+	return nil
+}
+
+// mulDivRoundBig computes a*b/d rounded half away from zero without
+// overflowing.
+func mulDivRoundBig(a, b, d int64) *big.Int {
+	n := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	den := big.NewInt(d)
+	q, r := new(big.Int).QuoRem(n, den, new(big.Int))
+	r.Abs(r).Lsh(r, 1)
+	if r.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if n.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Fuel types a car can run on. They match the fuel_type database enum.
+const (
+	FuelPetrol   = "petrol"
+	FuelDiesel   = "diesel"
+	FuelElectric = "electric"
+	FuelHybrid   = "hybrid"
+)
+
+// FuelType describes an accepted fuel type for clients building forms.
+type FuelType struct {
+	Value      string   `json:"value"`
+	Label      string   `json:"label"`
+	Powertrain string   `json:"powertrain"`
+	Aliases    []string `json:"aliases"`
+}
+
+var FuelTypes = []FuelType{
+	{Value: FuelPetrol, Label: "Petrol", Powertrain: PowertrainICE, Aliases: []string{"gasoline", "gas"}},
+	{Value: FuelDiesel, Label: "Diesel", Powertrain: PowertrainICE, Aliases: []string{}},
+	{Value: FuelElectric, Label: "Electric", Powertrain: PowertrainBEV, Aliases: []string{"ev", "bev"}},
+	{Value: FuelHybrid, Label: "Hybrid", Powertrain: PowertrainHybrid, Aliases: []string{"hev", "phev"}},
+}
+
+// NormalizeFuelType lower-cases a fuel type and maps aliases such as
+// "Gasoline" or "EV" to their canonical value. Unknown values are returned
+// lower-cased for validation to reject.
+func NormalizeFuelType(fuelType string) string {
+	fuelType = strings.ToLower(strings.TrimSpace(fuelType))
+	for _, ft := range FuelTypes {
+		if slices.Contains(ft.Aliases, fuelType) {
+			return ft.Value
+		}
+	}
+	return fuelType
+}
+
+func ValidateFuelType(fuelType string) error {
+	values := make([]string, len(FuelTypes))
+	for i, ft := range FuelTypes {
+		if ft.Value == fuelType {
+			return nil
+		}
+		values[i] = ft.Value
+	}
+	return errors.New("fuel type must be one of: " + strings.Join(values, ", "))
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of location. Branches sell cars; warehouses only store them.
+const (
+	LocationKindBranch    = "branch"
+	LocationKindWarehouse = "warehouse"
+)
+
+var LocationKinds = []string{LocationKindBranch, LocationKindWarehouse}
+
+// Lifecycle of a transfer. A car stays at its origin while in transit and
+// moves to the destination once the transfer is received.
+const (
+	TransferInTransit = "in_transit"
+	TransferReceived  = "received"
+	TransferCancelled = "cancelled"
+)
+
+var TransferStatuses = []string{TransferInTransit, TransferReceived, TransferCancelled}
+
+var locationCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{0,31}$`)
+
+// Location is a branch or warehouse that holds cars.
+type Location struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Address   string    `json:"address"`
+	CarCount  int       `json:"car_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type LocationRequest struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Address string `json:"address"`
+}
+
+// Transfer moves a car from one location to another.
+type Transfer struct {
+	ID             uuid.UUID  `json:"id"`
+	CarID          uuid.UUID  `json:"car_id"`
+	FromLocationID uuid.UUID  `json:"from_location_id"`
+	FromLocation   string     `json:"from_location"`
+	ToLocationID   uuid.UUID  `json:"to_location_id"`
+	ToLocation     string     `json:"to_location"`
+	Status         string     `json:"status"`
+	Notes          string     `json:"notes"`
+	RequestedBy    string     `json:"requested_by"`
+	CompletedBy    *string    `json:"completed_by,omitempty"`
+	DispatchedAt   time.Time  `json:"dispatched_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// TransferRequest sends a car from its current location to another one.
+type TransferRequest struct {
+	CarID        uuid.UUID `json:"car_id"`
+	ToLocationID uuid.UUID `json:"to_location_id"`
+	Notes        string    `json:"notes"`
+}
+
+// TransferFilter narrows the transfer history. LocationID matches transfers
+// leaving or arriving at the location.
+type TransferFilter struct {
+	CarID      string
+	LocationID string
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+// NormalizeLocationCode upper-cases a location code.
+func NormalizeLocationCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func ValidateLocationRequest(location LocationRequest) error {
+	if !locationCodePattern.MatchString(location.Code) {
+		return errors.New("code must be 1-32 uppercase letters, digits, dashes or underscores")
+	}
+	if err := validateReferenceName("name", location.Name); err != nil {
+		return err
+	}
+	if !slices.Contains(LocationKinds, location.Kind) {
+		return errors.New("kind must be one of: " + strings.Join(LocationKinds, ", "))
+	}
+	return nil
+}
+
+func ValidateTransferRequest(transfer TransferRequest) error {
+	if transfer.CarID == uuid.Nil {
+		return errors.New("car ID is required")
+	}
+	if transfer.ToLocationID == uuid.Nil {
+		return errors.New("destination location ID is required")
+	}
+	return nil
+}
+
+func ValidateTransferFilter(filter TransferFilter) error {
+	if filter.Status != "" && !slices.Contains(TransferStatuses, filter.Status) {
+		return errors.New("status must be one of: " + strings.Join(TransferStatuses, ", "))
+	}
+	return ValidatePagination(filter.Limit, filter.Offset)
+}
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor currency units (paise, cents). It is encoded in
+// JSON as a decimal number with two fractional digits and never passes
+// through float64, so totals add up exactly.
+type Money int64
+
+// Percent is a rate in basis points: 1800 is 18%.
+type Percent int64
+
+const moneyScale = 2
+
+// ParseMoney parses a decimal string such as "1234.5" into minor units.
+func ParseMoney(s string) (Money, error) {
+	v, err := parseFixed(s, moneyScale)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	return Money(v), nil
+}
+
+// ParsePercent parses a decimal percentage such as "9.5" into basis points.
+func ParsePercent(s string) (Percent, error) {
+	v, err := parseFixed(s, 2)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q: %w", s, err)
+	}
+	return Percent(v), nil
+}
+
+func (m Money) String() string {
+	return formatFixed(int64(m), moneyScale)
+}
+
+func (p Percent) String() string {
+	return formatFixed(int64(p), 2)
+}
+
+// Mul multiplies m by an integer quantity, failing instead of wrapping
+// around when the product does not fit in Money.
+func (m Money) Mul(n int64) (Money, error) {
+	v, ok := mulDivRound(int64(m), n, 1)
+	if !ok {
+		return 0, errOutOfRange
+	}
+	return Money(v), nil
+}
+
+// Percent returns p percent of m, rounded half away from zero to the nearest
+// minor unit. It fails when the result does not fit in Money.
+func (m Money) Percent(p Percent) (Money, error) {
+	v, ok := mulDivRound(int64(m), int64(p), 10000)
+	if !ok {
+		return 0, errOutOfRange
+	}
+	return Money(v), nil
+}
+
+// Add returns m+n, failing instead of wrapping around when the sum does not
+// fit in Money.
+func (m Money) Add(n Money) (Money, error) {
+	sum := m + n
+	if (n > 0 && sum < m) || (n < 0 && sum > m) {
+		return 0, errOutOfRange
+	}
+	return sum, nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	// Like the standard types, null leaves the value unchanged
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := ParseMoney(unquoteNumber(data))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+func (m *Money) Scan(src any) error {
+	v, err := scanFixed(src, moneyScale)
+	if err != nil {
+		return err
+	}
+	*m = Money(v)
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	// Like the standard types, null leaves the value unchanged
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := ParsePercent(unquoteNumber(data))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+func (p *Percent) Scan(src any) error {
+	v, err := scanFixed(src, 2)
+	if err != nil {
+		return err
+	}
+	*p = Percent(v)
+	return nil
+}
+
+func (p Percent) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+func unquoteNumber(data []byte) string {
+	return strings.Trim(strings.TrimSpace(string(data)), `"`)
+}
+
+func scanFixed(src any, scale int) (int64, error) {
+	switch v := src.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v * pow10(scale), nil
+	case float64:
+		return int64(math.Round(v * float64(pow10(scale)))), nil
+	case string:
+		return parseFixed(v, scale)
+	case []byte:
+		return parseFixed(string(v), scale)
+	default:
+		return 0, fmt.Errorf("cannot scan %T into a decimal amount", src)
+	}
+}
+
+// parseFixed parses a decimal string into an integer scaled by 10^scale.
+// Extra fractional digits are only accepted when they are zero.
+func parseFixed(s string, scale int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty value")
+	}
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, errors.New("no digits")
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if trimmed := strings.TrimRight(frac, "0"); len(trimmed) > scale {
+		return 0, fmt.Errorf("more than %d decimal places", scale)
+	} else if len(frac) > scale {
+		frac = frac[:scale]
+	}
+	frac += strings.Repeat("0", scale-len(frac))
+
+	for _, part := range []string{whole, frac} {
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return 0, errors.New("not a decimal number")
+			}
+		}
+	}
+
+	v, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, errOutOfRange
+	}
+	if neg {
+		v = -v
+	}
+	return v, nil
+}
+
+func formatFixed(v int64, scale int) string {
+	sign := ""
+	u := uint64(v)
+	if v < 0 {
+		sign = "-"
+		u = uint64(-v)
+	}
+	p := uint64(pow10(scale))
+	return fmt.Sprintf("%s%d.%0*d", sign, u/p, scale, u%p)
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
+var errOutOfRange = errors.New("out of range")
+
+// mulDivRound computes a*b/d rounded half away from zero. The product is
+// taken at full precision, and ok is false when the result does not fit in
+// an int64.
+func mulDivRound(a, b, d int64) (v int64, ok bool) {
+	q := mulDivRoundBig(a, b, d)
+	return q.Int64(), q.IsInt64()
+}
+
+// DefaultCurrency is assumed when a request does not name a currency.
+const DefaultCurrency = "INR"
+
+// Currencies lists the ISO 4217 codes the dealerships price in. All of them
+// use two minor digits, matching Money's scale.
+var Currencies = []string{"INR", "USD", "EUR", "GBP", "AED", "SGD"}
+
+func ValidateCurrency(currency string) error {
+	if slices.Contains(Currencies, currency) {
+		return nil
+	}
+	return errors.New("currency must be one of: " + strings.Join(Currencies, ", "))
+}
+
+// NormalizeCurrency upper-cases code and falls back to DefaultCurrency.
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
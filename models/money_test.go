@@ -0,0 +1,271 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "1234.5", want: 123450},
+		{in: "1234.50", want: 123450},
+		{in: "1234.500", want: 123450},
+		{in: ".5", want: 50},
+		{in: "7.", want: 700},
+		{in: "+3.25", want: 325},
+		{in: "-3.25", want: -325},
+		{in: " 10 ", want: 1000},
+		{in: "92233720368547758.07", want: math.MaxInt64},
+		{in: "-92233720368547758.07", want: -math.MaxInt64},
+		{in: "92233720368547758.08", wantErr: true},
+		{in: "1.005", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "12,50", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	for _, m := range []Money{0, 1, 50, 123450, -1, -99, -123456, math.MaxInt64, -math.MaxInt64} {
+		data, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal(%d): %v", m, err)
+		}
+		var got Money
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if got != m {
+			t.Errorf("round trip of %d through %s = %d", m, data, got)
+		}
+	}
+
+	tests := []struct {
+		json string
+		want Money
+	}{
+		{json: `12.5`, want: 1250},
+		{json: `"12.5"`, want: 1250},
+		{json: `-0.01`, want: -1},
+	}
+	for _, tt := range tests {
+		var got Money
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", tt.json, got, err, tt.want)
+		}
+	}
+	got := Money(1250)
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil || got != 1250 {
+		t.Errorf("Unmarshal(null) = %v, %v, want the value unchanged", got, err)
+	}
+	if got, _ := json.Marshal(Money(-5)); string(got) != "-0.05" {
+		t.Errorf("Marshal(-5) = %s, want -0.05", got)
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	tests := []struct {
+		m       Money
+		p       Percent
+		want    Money
+		wantErr bool
+	}{
+		{m: 10000, p: 1800, want: 1800},
+		{m: 1, p: 5000, want: 1},    // 0.5 rounds up
+		{m: 1, p: 4999, want: 0},    // just under half rounds down
+		{m: -1, p: 5000, want: -1},  // half rounds away from zero
+		{m: -1, p: 4999, want: 0},   // and toward it below half
+		{m: 333, p: 1500, want: 50}, // 49.95
+		{m: -333, p: 1500, want: -50},
+		{m: math.MaxInt64, p: 10000, want: math.MaxInt64},
+		{m: math.MaxInt64, p: 2800, want: 2582544170319337226},
+		{m: math.MaxInt64, p: 20000, wantErr: true},
+		{m: math.MinInt64, p: 10001, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tt.m.Percent(tt.p)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%d.Percent(%d) = %d, want an error", tt.m, tt.p, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%d.Percent(%d) = %d, %v, want %d", tt.m, tt.p, got, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyMul(t *testing.T) {
+	if got, err := Money(1250).Mul(3); err != nil || got != 3750 {
+		t.Errorf("1250.Mul(3) = %d, %v, want 3750", got, err)
+	}
+	if got, err := Money(-1250).Mul(3); err != nil || got != -3750 {
+		t.Errorf("-1250.Mul(3) = %d, %v, want -3750", got, err)
+	}
+	if got, err := Money(math.MaxInt64).Mul(2); err == nil {
+		t.Errorf("MaxInt64.Mul(2) = %d, want an error", got)
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	tests := []struct {
+		m       Money
+		r       Rate
+		want    Money
+		wantErr bool
+	}{
+		{m: 100000, r: RateOne, want: 100000},
+		{m: 100000, r: 1200000, want: 1200},   // 0.012
+		{m: 1, r: 50000000, want: 1},          // 0.5 rounds up
+		{m: -1, r: 50000000, want: -1},        // half rounds away from zero
+		{m: 1000, r: 8312345678, want: 83123}, // 83123.45678
+		{m: math.MaxInt64, r: 2 * RateOne, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tt.m.Convert(tt.r)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%d.Convert(%s) = %d, want an error", tt.m, tt.r, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%d.Convert(%s) = %d, %v, want %d", tt.m, tt.r, got, err, tt.want)
+		}
+	}
+}
+
+func TestRateInverse(t *testing.T) {
+	tests := []struct {
+		r       Rate
+		want    Rate
+		wantErr bool
+	}{
+		{r: RateOne, want: RateOne},
+		{r: 8312345678, want: 1203030}, // 1/83.12345678
+		{r: minRate, want: maxRate},
+		{r: 0, wantErr: true},
+		{r: math.MaxInt64, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tt.r.Inverse()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s.Inverse() = %s, want an error", tt.r, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s.Inverse() = %s, %v, want %s", tt.r, got, err, tt.want)
+		}
+	}
+}
+
+func TestCalculateTotals(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    Order
+		discount Money
+		taxes    []Money
+		tax      Money
+		total    Money
+		wantErr  bool
+	}{
+		{
+			name: "discounts then taxes",
+			order: Order{
+				SalePrice: 1000000,
+				Discounts: []OrderDiscount{{Amount: 50000}, {Amount: 25000}},
+				Taxes:     []OrderTax{{Name: "GST", Rate: 2800}, {Name: "Cess", Rate: 150}},
+			},
+			discount: 75000,
+			taxes:    []Money{259000, 13875},
+			tax:      272875,
+			total:    1197875,
+		},
+		{
+			name: "taxes round per line",
+			order: Order{
+				SalePrice: 999,
+				Taxes:     []OrderTax{{Name: "A", Rate: 950}, {Name: "B", Rate: 950}},
+			},
+			taxes: []Money{95, 95},
+			tax:   190,
+			total: 1189,
+		},
+		{
+			name:  "no discounts or taxes",
+			order: Order{SalePrice: 500},
+			total: 500,
+		},
+		{
+			name: "discounts exceed the sale price",
+			order: Order{
+				SalePrice: 100,
+				Discounts: []OrderDiscount{{Amount: 101}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "discount total overflows",
+			order: Order{
+				SalePrice: math.MaxInt64,
+				Discounts: []OrderDiscount{{Amount: math.MaxInt64}, {Amount: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "total overflows",
+			order: Order{
+				SalePrice: math.MaxInt64,
+				Taxes:     []OrderTax{{Name: "GST", Rate: 2800}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := tt.order
+			err := order.CalculateTotals()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CalculateTotals succeeded with total %d, want an error", order.Total)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CalculateTotals: %v", err)
+			}
+			if order.DiscountTotal != tt.discount || order.TaxTotal != tt.tax || order.Total != tt.total {
+				t.Errorf("totals = %d, %d, %d, want %d, %d, %d",
+					order.DiscountTotal, order.TaxTotal, order.Total, tt.discount, tt.tax, tt.total)
+			}
+			for i, want := range tt.taxes {
+				if order.Taxes[i].Amount != want {
+					t.Errorf("tax %d = %d, want %d", i, order.Taxes[i].Amount, want)
+				}
+			}
+		})
+	}
+}
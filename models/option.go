@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Option categories. A car can carry at most one color.
+const (
+	OptionCategoryFeature = "feature"
+	OptionCategoryPackage = "package"
+	OptionCategoryColor   = "color"
+)
+
+var OptionCategories = []string{OptionCategoryFeature, OptionCategoryPackage, OptionCategoryColor}
+
+// optionCodePattern keeps codes usable as query parameters, e.g.
+// ?option=sunroof.
+var optionCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Option is a catalog entry that can be fitted to a car.
+type Option struct {
+	ID               uuid.UUID   `json:"id"`
+	Code             string      `json:"code"`
+	Name             string      `json:"name"`
+	Category         string      `json:"category"`
+	Price            Money       `json:"price"`
+	Currency         string      `json:"currency"`
+	IncompatibleWith []uuid.UUID `json:"incompatible_with"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+type OptionRequest struct {
+	Code             string      `json:"code"`
+	Name             string      `json:"name"`
+	Category         string      `json:"category"`
+	Price            Money       `json:"price"`
+	Currency         string      `json:"currency"`
+	IncompatibleWith []uuid.UUID `json:"incompatible_with"`
+}
+
+// CarOption is an option fitted to a car, priced in the car's currency as of
+// when it was fitted.
+type CarOption struct {
+	OptionID uuid.UUID `json:"option_id"`
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	Category string    `json:"category"`
+	Price    Money     `json:"price"`
+}
+
+// CarOptions is the option list of a car together with its price breakdown.
+type CarOptions struct {
+	CarID        uuid.UUID   `json:"car_id"`
+	Options      []CarOption `json:"options"`
+	BasePrice    Money       `json:"base_price"`
+	OptionsTotal Money       `json:"options_total"`
+	TotalPrice   Money       `json:"total_price"`
+	Currency     string      `json:"currency"`
+}
+
+type CarOptionsRequest struct {
+	OptionIDs []uuid.UUID `json:"option_ids"`
+}
+
+// NormalizeOptionCode lower-cases an option code.
+func NormalizeOptionCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func ValidateOptionRequest(option OptionRequest) error {
+	if !optionCodePattern.MatchString(option.Code) {
+		return errors.New("code must be 1-64 lowercase letters, digits, dashes or underscores")
+	}
+	if err := validateReferenceName("name", option.Name); err != nil {
+		return err
+	}
+	if !slices.Contains(OptionCategories, option.Category) {
+		return errors.New("category must be one of: " + strings.Join(OptionCategories, ", "))
+	}
+	if option.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	if err := ValidateCurrency(option.Currency); err != nil {
+		return err
+	}
+	for _, id := range option.IncompatibleWith {
+		if id == uuid.Nil {
+			return errors.New("incompatible_with must contain option ids")
+		}
+	}
+	return nil
+}
+
+// ValidateOptionSelection checks that a set of options can be fitted to one
+// car: no duplicates, no incompatible pairs and at most one color.
+func ValidateOptionSelection(options []Option) error {
+	selected := make(map[uuid.UUID]Option, len(options))
+	var color *Option
+	for i, option := range options {
+		if _, ok := selected[option.ID]; ok {
+			return fmt.Errorf("option %s is selected more than once", option.Code)
+		}
+		selected[option.ID] = option
+
+		if option.Category == OptionCategoryColor {
+			if color != nil {
+				return fmt.Errorf("only one color can be chosen, got %s and %s", color.Code, option.Code)
+			}
+			color = &options[i]
+		}
+	}
+
+	for _, option := range options {
+		for _, id := range option.IncompatibleWith {
+			if other, ok := selected[id]; ok {
+				return fmt.Errorf("option %s cannot be combined with %s", option.Code, other.Code)
+			}
+		}
+	}
+	return nil
+}
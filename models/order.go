@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Order struct {
+	ID            uuid.UUID       `json:"id"`
+	InvoiceNumber int64           `json:"invoice_number"`
+	CarID         uuid.UUID       `json:"car_id"`
+	Car           *Car            `json:"car,omitempty"`
+	Buyer         Buyer           `json:"buyer"`
+	SalePrice     Money           `json:"sale_price"`
+	Discounts     []OrderDiscount `json:"discounts"`
+	Taxes         []OrderTax      `json:"taxes"`
+	DiscountTotal Money           `json:"discount_total"`
+	TaxTotal      Money           `json:"tax_total"`
+	Total         Money           `json:"total"`
+	Currency      string          `json:"currency"`
+	PaymentMethod string          `json:"payment_method"`
+	Salesperson   string          `json:"salesperson"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type Buyer struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+type OrderDiscount struct {
+	Description string `json:"description"`
+	Amount      Money  `json:"amount"`
+}
+
+// OrderTax is a tax applied to the discounted price. Amount is computed by
+// the service and ignored on input.
+type OrderTax struct {
+	Name   string  `json:"name"`
+	Rate   Percent `json:"rate"`
+	Amount Money   `json:"amount"`
+}
+
+type OrderRequest struct {
+	CarID         uuid.UUID       `json:"car_id"`
+	Buyer         Buyer           `json:"buyer"`
+	SalePrice     Money           `json:"sale_price"`
+	Discounts     []OrderDiscount `json:"discounts"`
+	Taxes         []OrderTax      `json:"taxes"`
+	PaymentMethod string          `json:"payment_method"`
+}
+
+var PaymentMethods = []string{"cash", "card", "bank_transfer", "upi", "cheque", "finance"}
+
+func ValidateOrderRequest(order OrderRequest) error {
+	if order.CarID == uuid.Nil {
+		return errors.New("car ID is required")
+	}
+	if strings.TrimSpace(order.Buyer.Name) == "" {
+		return errors.New("buyer name is required")
+	}
+	if order.SalePrice < 0 {
+		return errors.New("sale price must not be negative")
+	}
+	for _, d := range order.Discounts {
+		if d.Amount <= 0 {
+			return errors.New("discount amount must be greater than 0")
+		}
+	}
+	for _, t := range order.Taxes {
+		if t.Name == "" {
+			return errors.New("tax name is required")
+		}
+		if t.Rate < 0 || t.Rate > 10000 {
+			return errors.New("tax rate must be between 0 and 100")
+		}
+	}
+	if !slices.Contains(PaymentMethods, order.PaymentMethod) {
+		return errors.New("payment method must be one of: " + strings.Join(PaymentMethods, ", "))
+	}
+	return nil
+}
+
+// CalculateTotals applies the discounts to the sale price, then each tax to
+// the discounted amount, filling in every computed field.
+func (o *Order) CalculateTotals() error {
+	var err error
+	o.DiscountTotal = 0
+	for _, d := range o.Discounts {
+		if o.DiscountTotal, err = o.DiscountTotal.Add(d.Amount); err != nil {
+			return errors.New("discount total is out of range")
+		}
+	}
+	taxable := o.SalePrice - o.DiscountTotal
+	if taxable < 0 {
+		return errors.New("discounts exceed the sale price")
+	}
+
+	o.TaxTotal = 0
+	for i := range o.Taxes {
+		if o.Taxes[i].Amount, err = taxable.Percent(o.Taxes[i].Rate); err != nil {
+			return errors.New("tax amount is out of range")
+		}
+		if o.TaxTotal, err = o.TaxTotal.Add(o.Taxes[i].Amount); err != nil {
+			return errors.New("tax total is out of range")
+		}
+	}
+	if o.Total, err = taxable.Add(o.TaxTotal); err != nil {
+		return errors.New("order total is out of range")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import "errors"
+
+// Pagination defaults for list endpoints.
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Page is one page of a listing together with the total number of matches.
+type Page[T any] struct {
+	Items  []T   `json:"items"`
+	Total  int64 `json:"total"`
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+}
+
+func ValidatePagination(limit, offset int) error {
+	if limit < 1 || limit > MaxPageLimit {
+		return errors.New("limit must be between 1 and 100")
+	}
+	if offset < 0 {
+		return errors.New("offset must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons recorded against a price change.
+const (
+	PriceChangeInitial   = "initial"
+	PriceChangeManual    = "manual"
+	PriceChangeScheduled = "scheduled"
+	PriceChangeReverted  = "reverted"
+)
+
+// Lifecycle of a scheduled price. A schedule is pending until it starts,
+// active while its price is applied and completed once reverted (or once its
+// end has passed without a revert being needed).
+const (
+	PriceSchedulePending   = "pending"
+	PriceScheduleActive    = "active"
+	PriceScheduleCompleted = "completed"
+	PriceScheduleCancelled = "cancelled"
+)
+
+type PriceChange struct {
+	ID               uuid.UUID  `json:"id"`
+	CarID            uuid.UUID  `json:"car_id"`
+	Price            Money      `json:"price"`
+	Currency         string     `json:"currency"`
+	PreviousPrice    *Money     `json:"previous_price,omitempty"`
+	PreviousCurrency *string    `json:"previous_currency,omitempty"`
+	Reason           string     `json:"reason"`
+	ScheduleID       *uuid.UUID `json:"schedule_id,omitempty"`
+	ChangedAt        time.Time  `json:"changed_at"`
+}
+
+type PriceSchedule struct {
+	ID               uuid.UUID  `json:"id"`
+	CarID            uuid.UUID  `json:"car_id"`
+	Price            Money      `json:"price"`
+	Currency         string     `json:"currency"`
+	StartsAt         time.Time  `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at,omitempty"`
+	Status           string     `json:"status"`
+	OriginalPrice    *Money     `json:"original_price,omitempty"`
+	OriginalCurrency *string    `json:"original_currency,omitempty"`
+	CreatedBy        string     `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type PriceScheduleRequest struct {
+	Price    Money      `json:"price"`
+	Currency string     `json:"currency"`
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// PriceTimeline is the full price story of a car: what it was and what is
+// planned.
+type PriceTimeline struct {
+	CarID     uuid.UUID       `json:"car_id"`
+	History   []PriceChange   `json:"history"`
+	Scheduled []PriceSchedule `json:"scheduled"`
+}
+
+func ValidatePriceScheduleRequest(schedule PriceScheduleRequest, now time.Time) error {
+	if err := validatePrice(schedule.Price); err != nil {
+		return err
+	}
+	if err := ValidateCurrency(schedule.Currency); err != nil {
+		return err
+	}
+	if schedule.StartsAt.IsZero() {
+		return errors.New("starts_at is required")
+	}
+	if !schedule.StartsAt.After(now) {
+		return errors.New("starts_at must be in the future")
+	}
+	if schedule.EndsAt != nil && !schedule.EndsAt.After(schedule.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Dimensions the inventory is grouped by.
+const (
+	ReportByBrand    = "brand"
+	ReportByFuelType = "fuel_type"
+	ReportByYear     = "year"
+	ReportByLocation = "location"
+)
+
+var ReportDimensions = []string{ReportByBrand, ReportByFuelType, ReportByYear, ReportByLocation}
+
+// Lengths of a sales velocity window.
+const (
+	ReportIntervalDay   = "day"
+	ReportIntervalWeek  = "week"
+	ReportIntervalMonth = "month"
+)
+
+var ReportIntervals = []string{ReportIntervalDay, ReportIntervalWeek, ReportIntervalMonth}
+
+// DefaultReportDays is how far back the sales window reaches by default.
+const DefaultReportDays = 90
+
+// maxReportDays bounds the sales window so a daily series stays small.
+const maxReportDays = 5 * 366
+
+// AgingBuckets are the days-in-stock ranges the aging section counts cars
+// in. Each bucket starts at its MinDays; the last one is open ended.
+var AgingBuckets = []AgingBucket{
+	{Label: "0-30", MinDays: 0},
+	{Label: "31-60", MinDays: 31},
+	{Label: "61-90", MinDays: 61},
+	{Label: "91-180", MinDays: 91},
+	{Label: "181+", MinDays: 181},
+}
+
+// ReportFilter selects the period of a report. The inventory is a snapshot
+// at the end of To; sales are counted from From through To.
+type ReportFilter struct {
+	From     Date
+	To       Date
+	Interval string
+}
+
+// InventoryReport summarizes the stock on hand and recent sales. Values are
+// asking prices including options, so they are reported per currency.
+// Precomputed sections are as fresh as DataAsOf; later changes show up after
+// the next refresh.
+type InventoryReport struct {
+	From          Date             `json:"from"`
+	To            Date             `json:"to"`
+	Interval      string           `json:"interval"`
+	GeneratedAt   time.Time        `json:"generated_at"`
+	DataAsOf      time.Time        `json:"data_as_of"`
+	Totals        []InventoryGroup `json:"totals"`
+	ByBrand       []InventoryGroup `json:"by_brand"`
+	ByFuelType    []InventoryGroup `json:"by_fuel_type"`
+	ByYear        []InventoryGroup `json:"by_year"`
+	ByLocation    []InventoryGroup `json:"by_location"`
+	Aging         []AgingBucket    `json:"aging"`
+	SalesVelocity []SalesWindow    `json:"sales_velocity"`
+}
+
+// InventoryGroup counts the cars of one group priced in one currency.
+type InventoryGroup struct {
+	Key          string `json:"key"`
+	Currency     string `json:"currency"`
+	Count        int64  `json:"count"`
+	TotalValue   Money  `json:"total_value"`
+	AverageValue Money  `json:"average_value"`
+}
+
+// AgingBucket counts the cars that have been in stock for MinDays or more,
+// up to the next bucket.
+type AgingBucket struct {
+	Label       string  `json:"label"`
+	MinDays     int     `json:"min_days"`
+	Count       int64   `json:"count"`
+	AverageDays float64 `json:"average_days"`
+}
+
+// SalesWindow counts the cars sold in the window starting on Start, and how
+// long they had been in stock on average.
+type SalesWindow struct {
+	Start       Date    `json:"start"`
+	Sales       int64   `json:"sales"`
+	AverageDays float64 `json:"average_days_to_sell"`
+}
+
+// ReportRefresh records when a materialized report view was last rebuilt.
+type ReportRefresh struct {
+	View        string    `json:"view"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+func ValidateReportFilter(filter ReportFilter) error {
+	if filter.From.After(filter.To.Time) {
+		return errors.New("from must not be after to")
+	}
+	if filter.To.Sub(filter.From.Time) > maxReportDays*24*time.Hour {
+		return errors.New("the report period must not exceed 5 years")
+	}
+	if !slices.Contains(ReportIntervals, filter.Interval) {
+		return errors.New("interval must be one of: " + strings.Join(ReportIntervals, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxServiceWorkLength keeps work descriptions to a readable summary.
+const maxServiceWorkLength = 4000
+
+// ServiceRecord is maintenance performed on a car.
+type ServiceRecord struct {
+	ID          uuid.UUID `json:"id"`
+	CarID       uuid.UUID `json:"car_id"`
+	ServiceDate Date      `json:"service_date"`
+	OdometerKm  int64     `json:"odometer_km"`
+	WorkDone    string    `json:"work_done"`
+	Cost        Money     `json:"cost"`
+	Currency    string    `json:"currency"`
+	Workshop    string    `json:"workshop"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ServiceRecordRequest struct {
+	ServiceDate Date   `json:"service_date"`
+	OdometerKm  int64  `json:"odometer_km"`
+	WorkDone    string `json:"work_done"`
+	Cost        Money  `json:"cost"`
+	Currency    string `json:"currency"`
+	Workshop    string `json:"workshop"`
+}
+
+// ServiceInterval is how often cars of a powertrain are due for service:
+// after IntervalKm or IntervalMonths, whichever comes first.
+type ServiceInterval struct {
+	Powertrain     string    `json:"powertrain"`
+	IntervalKm     int64     `json:"interval_km"`
+	IntervalMonths int       `json:"interval_months"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ServiceIntervalRequest struct {
+	IntervalKm     int64 `json:"interval_km"`
+	IntervalMonths int   `json:"interval_months"`
+}
+
+// ServiceDue is a car whose next service falls on or before the date asked
+// about, or whose odometer has reached the next service mileage. A car never
+// serviced counts from the day it was added.
+type ServiceDue struct {
+	CarID             uuid.UUID `json:"car_id"`
+	Name              string    `json:"name"`
+	Brand             string    `json:"brand"`
+	Powertrain        string    `json:"powertrain"`
+	LastServiceDate   *Date     `json:"last_service_date,omitempty"`
+	LastOdometerKm    *int64    `json:"last_odometer_km,omitempty"`
+	OdometerKm        int64     `json:"odometer_km"`
+	NextDueDate       Date      `json:"next_due_date"`
+	NextDueOdometerKm int64     `json:"next_due_odometer_km"`
+	Overdue           bool      `json:"overdue"`
+}
+
+// ServiceDueFilter selects cars due for service by Before, optionally of one
+// powertrain. Overdue is judged against AsOf.
+type ServiceDueFilter struct {
+	AsOf       Date
+	Before     Date
+	Powertrain string
+	Limit      int
+	Offset     int
+}
+
+func ValidateServiceRecordRequest(record ServiceRecordRequest, now time.Time) error {
+	if record.ServiceDate.IsZero() {
+		return errors.New("service date is required")
+	}
+	if record.ServiceDate.After(DateOf(now).Time) {
+		return errors.New("service date must not be in the future")
+	}
+	if record.OdometerKm < 0 {
+		return errors.New("odometer must not be negative")
+	}
+	if record.WorkDone == "" {
+		return errors.New("work done is required")
+	}
+	if len(record.WorkDone) > maxServiceWorkLength {
+		return fmt.Errorf("work done must be at most %d characters", maxServiceWorkLength)
+	}
+	if record.Cost < 0 {
+		return errors.New("cost must not be negative")
+	}
+	if err := ValidateCurrency(record.Currency); err != nil {
+		return err
+	}
+	if len(record.Workshop) > maxReferenceNameLength {
+		return fmt.Errorf("workshop must be at most %d characters", maxReferenceNameLength)
+	}
+	return nil
+}
+
+func ValidateServiceInterval(interval ServiceInterval) error {
+	if !slices.Contains(Powertrains, interval.Powertrain) {
+		return errors.New("powertrain must be one of: " + strings.Join(Powertrains, ", "))
+	}
+	if interval.IntervalKm <= 0 {
+		return errors.New("interval_km must be greater than 0")
+	}
+	if interval.IntervalMonths <= 0 {
+		return errors.New("interval_months must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateServiceDueFilter(filter ServiceDueFilter) error {
+	if filter.Powertrain != "" && !slices.Contains(Powertrains, filter.Powertrain) {
+		return errors.New("powertrain must be one of: " + strings.Join(Powertrains, ", "))
+	}
+	if filter.Before.Before(filter.AsOf.Time) {
+		return errors.New("before must not be earlier than today")
+	}
+	return ValidatePagination(filter.Limit, filter.Offset)
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// StreamEvent is an event on the live stream, with the brand and location
+// of the car it concerns for filtering. They are empty for engine events
+// and for cars that no longer exist.
+type StreamEvent struct {
+	Event
+	Brand      string     `json:"-"`
+	LocationID *uuid.UUID `json:"-"`
+}
+
+// StreamFilter narrows a client's stream to the cars of a brand or at a
+// location. A filtered client receives no engine events; events about cars
+// that are gone, such as car.deleted, reach every client.
+type StreamFilter struct {
+	Brand      string
+	LocationID *uuid.UUID
+}
+
+func (f StreamFilter) Matches(event StreamEvent) bool {
+	if f.Brand == "" && f.LocationID == nil {
+		return true
+	}
+	if event.AggregateType != AggregateCar {
+		return false
+	}
+	if event.Brand == "" {
+		return true
+	}
+	if f.Brand != "" && !strings.EqualFold(f.Brand, event.Brand) {
+		return false
+	}
+	if f.LocationID != nil && (event.LocationID == nil || *event.LocationID != *f.LocationID) {
+		return false
+	}
+	return true
+}
+
+// StreamSubscription is a client's place on the live stream. Replay holds
+// the buffered events after the client's last event. Reset is set when that
+// event is no longer buffered, so the client should reload instead and
+// resume from LatestEventID, the newest event buffered. Events is closed
+// when the client falls behind, the stream loses events or the server
+// stops, after which the client reconnects; Close ends the subscription.
+type StreamSubscription struct {
+	Replay        []StreamEvent
+	Reset         bool
+	LatestEventID int64
+	Events        <-chan StreamEvent
+	Close         func()
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conditions a car is sold in.
+const (
+	ConditionNew  = "new"
+	ConditionUsed = "used"
+)
+
+var Conditions = []string{ConditionNew, ConditionUsed}
+
+// Accident history of a car, by the worst damage it has had repaired.
+const (
+	AccidentNone  = "none"
+	AccidentMinor = "minor"
+	AccidentMajor = "major"
+)
+
+var AccidentHistories = []string{AccidentNone, AccidentMinor, AccidentMajor}
+
+// Condition grades run from 1 (poor) to 5 (as new).
+const (
+	MinConditionGrade = 1
+	MaxConditionGrade = 5
+)
+
+// MaxNewCarOdometerKm allows for delivery and test-drive mileage on a new
+// car.
+const MaxNewCarOdometerKm = 1000
+
+// InspectionChecklist is every item a certified pre-owned inspection covers.
+var InspectionChecklist = []string{
+	"engine", "transmission", "brakes", "suspension", "steering", "tyres",
+	"electrics", "bodywork", "interior", "road_test",
+}
+
+var registrationNumberPattern = regexp.MustCompile(`^[A-Z0-9-]{1,20}$`)
+
+// InspectionItem is the outcome of one checklist item.
+type InspectionItem struct {
+	Item   string `json:"item"`
+	Passed bool   `json:"passed"`
+	Notes  string `json:"notes"`
+}
+
+// Inspection is the certified pre-owned checklist of a used car. The car is
+// certified while it is used and every item passed.
+type Inspection struct {
+	CarID       uuid.UUID        `json:"car_id"`
+	Inspector   string           `json:"inspector"`
+	InspectedOn Date             `json:"inspected_on"`
+	Items       []InspectionItem `json:"items"`
+	Passed      bool             `json:"passed"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type InspectionRequest struct {
+	InspectedOn Date             `json:"inspected_on"`
+	Items       []InspectionItem `json:"items"`
+}
+
+// NormalizeRegistrationNumber upper-cases a registration number and drops
+// its spaces, so "ka 01 ab 1234" and "KA01AB1234" are the same plate.
+func NormalizeRegistrationNumber(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+// validateUsedDetails checks the used-vehicle fields of a car agree with its
+// condition: a new car has no owners, damage, grade or registration and
+// hardly any mileage, while a used car must be graded.
+func validateUsedDetails(car CarRequest) error {
+	if !slices.Contains(Conditions, car.Condition) {
+		return errors.New("condition must be one of: " + strings.Join(Conditions, ", "))
+	}
+	if car.OdometerKm < 0 {
+		return errors.New("odometer must not be negative")
+	}
+	if car.PreviousOwners < 0 {
+		return errors.New("previous owners must not be negative")
+	}
+	if !slices.Contains(AccidentHistories, car.AccidentHistory) {
+		return errors.New("accident history must be one of: " + strings.Join(AccidentHistories, ", "))
+	}
+	if car.ConditionGrade != nil && (*car.ConditionGrade < MinConditionGrade || *car.ConditionGrade > MaxConditionGrade) {
+		return fmt.Errorf("condition grade must be between %d and %d", MinConditionGrade, MaxConditionGrade)
+	}
+
+	switch car.Condition {
+	case ConditionNew:
+		if car.PreviousOwners != 0 {
+			return errors.New("a new car cannot have previous owners")
+		}
+		if car.AccidentHistory != AccidentNone {
+			return errors.New("a new car cannot have an accident history")
+		}
+		if car.ConditionGrade != nil {
+			return errors.New("only used cars have a condition grade")
+		}
+		if car.OdometerKm > MaxNewCarOdometerKm {
+			return fmt.Errorf("a new car cannot have more than %d km on the odometer", MaxNewCarOdometerKm)
+		}
+		if car.RegistrationNumber != "" {
+			return errors.New("a new car cannot be registered yet")
+		}
+	case ConditionUsed:
+		if car.ConditionGrade == nil {
+			return errors.New("condition grade is required for a used car")
+		}
+	}
+
+	return validateRegistration(car)
+}
+
+func validateRegistration(car CarRequest) error {
+	if car.RegistrationNumber == "" {
+		if car.RegistrationDate != nil || car.RegistrationRegion != "" {
+			return errors.New("registration number is required with registration details")
+		}
+		return nil
+	}
+	if !registrationNumberPattern.MatchString(car.RegistrationNumber) {
+		return errors.New("registration number must be 1-20 letters, digits or dashes")
+	}
+	if len(car.RegistrationRegion) > 100 {
+		return errors.New("registration region must be at most 100 characters")
+	}
+	if car.RegistrationDate != nil {
+		if car.RegistrationDate.After(DateOf(time.Now()).Time) {
+			return errors.New("registration date must not be in the future")
+		}
+		if strconv.Itoa(car.RegistrationDate.Year()) < car.Year {
+			return errors.New("registration date must not be before the car's model year")
+		}
+	}
+	return nil
+}
+
+// ValidateInspectionRequest requires every checklist item exactly once.
+func ValidateInspectionRequest(inspection InspectionRequest, now time.Time) error {
+	if inspection.InspectedOn.IsZero() {
+		return errors.New("inspection date is required")
+	}
+	if inspection.InspectedOn.After(DateOf(now).Time) {
+		return errors.New("inspection date must not be in the future")
+	}
+
+	seen := map[string]bool{}
+	for _, item := range inspection.Items {
+		if !slices.Contains(InspectionChecklist, item.Item) {
+			return fmt.Errorf("unknown checklist item %q", item.Item)
+		}
+		if seen[item.Item] {
+			return fmt.Errorf("checklist item %q is listed more than once", item.Item)
+		}
+		seen[item.Item] = true
+	}
+	for _, item := range InspectionChecklist {
+		if !seen[item] {
+			return fmt.Errorf("checklist item %q is missing", item)
+		}
+	}
+	return nil
+}
+
+// InspectionPassed reports whether every item of a checklist passed.
+func InspectionPassed(items []InspectionItem) bool {
+	for _, item := range items {
+		if !item.Passed {
+			return false
+		}
+	}
+	return len(items) > 0
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lifecycle of a webhook delivery. A pending delivery is retried with
+// exponential backoff until it is delivered or runs out of attempts, when it
+// is dead-lettered and only retried on request.
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+	WebhookDead      = "dead"
+)
+
+var WebhookStatuses = []string{WebhookPending, WebhookDelivered, WebhookDead}
+
+// EventWebhookTest is the type of the event sent by a test fire.
+const EventWebhookTest = "webhook.test"
+
+// minWebhookSecretLength keeps supplied signing secrets from being guessable.
+const minWebhookSecretLength = 16
+
+// WebhookSubscription sends the events of the listed types to URL. Secret
+// signs the requests; it is only returned when the subscription is created
+// or the secret is replaced.
+type WebhookSubscription struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookRequest creates or replaces a subscription. An empty Secret keeps
+// the current one, or generates one for a new subscription. Active defaults
+// to true.
+type WebhookRequest struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description"`
+	Active      *bool    `json:"active"`
+	Secret      string   `json:"secret"`
+}
+
+// WebhookDelivery is one event sent to one subscription. Payload is the
+// exact request body.
+type WebhookDelivery struct {
+	ID             uuid.UUID        `json:"id"`
+	SubscriptionID uuid.UUID        `json:"subscription_id"`
+	EventID        *int64           `json:"event_id,omitempty"`
+	EventType      string           `json:"event_type"`
+	Payload        json.RawMessage  `json:"payload"`
+	Status         string           `json:"status"`
+	Attempts       int              `json:"attempts"`
+	NextAttemptAt  *time.Time       `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int             `json:"last_status_code,omitempty"`
+	LastError      *string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time       `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	AttemptLog     []WebhookAttempt `json:"attempt_log,omitempty"`
+
+	// URL and Secret are the subscription's, for sending.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookAttempt is the outcome of sending a delivery once. StatusCode is
+// nil when no response was received.
+type WebhookAttempt struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  *int      `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+}
+
+// Succeeded reports whether the receiver accepted the delivery.
+func (a WebhookAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode != nil && *a.StatusCode >= 200 && *a.StatusCode <= 299
+}
+
+type WebhookDeliveryFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+func ValidateWebhookRequest(webhook WebhookRequest) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if u.User != nil {
+		return errors.New("url must not contain credentials")
+	}
+	if len(webhook.EventTypes) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return errors.New("event types must be among: " + strings.Join(EventTypes, ", "))
+		}
+	}
+	if webhook.Secret != "" && len(webhook.Secret) < minWebhookSecretLength {
+		return errors.New("secret must be at least 16 characters")
+	}
+	if len(webhook.Description) > 500 {
+		return errors.New("description must be at most 500 characters")
+	}
+	return nil
+}
+
+func ValidateWebhookDeliveryFilter(filter WebhookDeliveryFilter) error {
+	if filter.Status != "" && !slices.Contains(WebhookStatuses, filter.Status) {
+		return errors.New("status must be one of: " + strings.Join(WebhookStatuses, ", "))
+	}
+	return ValidatePagination(filter.Limit, filter.Offset)
+}
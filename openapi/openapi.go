@@ -0,0 +1,150 @@
+// Package openapi describes the REST API as an OpenAPI 3.1 document, with
+// schemas derived from the model types, and serves it with a browsable
+// reference.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the API the document describes.
+const Version = "1.0.0"
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Document builds the OpenAPI document of the routes in operations.
+func Document() map[string]any {
+	s := &schemas{components: map[string]any{}}
+	paths := map[string]any{}
+	for _, op := range operations {
+		item, _ := paths[op.path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[op.path] = item
+		}
+		item[strings.ToLower(op.method)] = s.operation(op)
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "Car Management API",
+			"version":     Version,
+			"description": "Cars, engines and the records kept about them. Send the token from /login as a bearer token.",
+		},
+		"tags": []any{
+			map[string]any{"name": "cars"},
+			map[string]any{"name": "engines"},
+			map[string]any{"name": "auth"},
+		},
+		"paths":    paths,
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+		"components": map[string]any{
+			"schemas": s.components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+// JSON is the document as served.
+func JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Document(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (s *schemas) operation(op operation) map[string]any {
+	out := map[string]any{
+		"operationId": op.id,
+		"summary":     op.summary,
+		"tags":        []string{op.tag},
+	}
+	if op.public {
+		out["security"] = []any{}
+	}
+
+	var params []any
+	for _, match := range pathParamPattern.FindAllStringSubmatch(op.path, -1) {
+		params = append(params, map[string]any{
+			"name": match[1], "in": "path", "required": true,
+			"schema": map[string]any{"type": "string", "format": "uuid"},
+		})
+	}
+	for _, p := range op.query {
+		params = append(params, parameter(p, "query"))
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+
+	switch {
+	case op.body != nil:
+		out["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": s.schema(reflect.TypeOf(op.body), true)},
+			},
+		}
+	case op.form != nil:
+		properties := map[string]any{}
+		var required []string
+		for _, p := range op.form {
+			properties[p.name] = p.schema
+			if p.required {
+				required = append(required, p.name)
+			}
+		}
+		out["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"multipart/form-data": map[string]any{
+					"schema": map[string]any{"type": "object", "properties": properties, "required": required},
+				},
+			},
+		}
+	}
+
+	responses := map[string]any{}
+	success := map[string]any{"description": http.StatusText(op.status)}
+	if op.response != nil {
+		success["content"] = map[string]any{
+			"application/json": map[string]any{"schema": s.schema(reflect.TypeOf(op.response), false)},
+		}
+	}
+	responses[strconv.Itoa(op.status)] = success
+
+	errors := op.errors
+	if !op.public {
+		errors = append([]int{http.StatusUnauthorized}, errors...)
+	}
+	for _, status := range errors {
+		responses[strconv.Itoa(status)] = map[string]any{
+			"description": http.StatusText(status),
+			"content": map[string]any{
+				"text/plain": map[string]any{"schema": map[string]any{"type": "string"}},
+			},
+		}
+	}
+	out["responses"] = responses
+	return out
+}
+
+func parameter(p param, in string) map[string]any {
+	out := map[string]any{"name": p.name, "in": in, "schema": p.schema}
+	if p.description != "" {
+		out["description"] = p.description
+	}
+	if p.required {
+		out["required"] = true
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite openapi.json from the code")
+
+// TestDocumentMatchesFile fails when the models or operations change without
+// openapi.json being regenerated with
+//
+//	go test ./openapi -run TestDocumentMatchesFile -update
+func TestDocumentMatchesFile(t *testing.T) {
+	got, err := JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if *update {
+		if err := os.WriteFile("openapi.json", got, 0o644); err != nil {
+			t.Fatalf("write openapi.json: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatalf("read openapi.json: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("openapi.json is out of date; regenerate it with -update")
+	}
+}
+
+// documentedPrefixes are the parts of the API the document covers.
+var documentedPrefixes = []string{"/cars", "/engine", "/login"}
+
+// TestOperationsMatchRoutes fails when a route under a documented prefix is
+// added to or removed from main.go without updating operations.
+func TestOperationsMatchRoutes(t *testing.T) {
+	routes := routesInMain(t)
+
+	var documented []string
+	for _, op := range operations {
+		documented = append(documented, op.method+" "+op.path)
+	}
+
+	for _, route := range routes {
+		if !slices.Contains(documented, route) {
+			t.Errorf("%s is not documented", route)
+		}
+	}
+	for _, op := range documented {
+		if !slices.Contains(routes, op) {
+			t.Errorf("%s is documented but not routed", op)
+		}
+	}
+}
+
+// routesInMain finds the chi routes registered in main.go under the
+// documented prefixes, as "METHOD /path".
+func routesInMain(t *testing.T) []string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "../main.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse main.go: %v", err)
+	}
+
+	var routes []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		method := strings.ToUpper(selector.Sel.Name)
+		if !slices.Contains([]string{"GET", "POST", "PUT", "PATCH", "DELETE"}, method) {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		path, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		for _, prefix := range documentedPrefixes {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				routes = append(routes, method+" "+path)
+				break
+			}
+		}
+		return true
+	})
+	if len(routes) == 0 {
+		t.Fatal("found no routes in main.go")
+	}
+	return routes
+}
+
+// TestReferencesResolve checks every $ref names a component.
+func TestReferencesResolve(t *testing.T) {
+	data, err := JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	components := doc["components"].(map[string]any)["schemas"].(map[string]any)
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/components/schemas/")
+				if components[name] == nil {
+					t.Errorf("unresolved reference %s", ref)
+				}
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+}
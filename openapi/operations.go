@@ -0,0 +1,319 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// operation documents one route. Path parameters are read from the path;
+// body and response are zero values of the types the handler decodes and
+// encodes, and nil when there is none.
+type operation struct {
+	method  string
+	path    string
+	id      string
+	summary string
+	tag     string
+	query   []param
+	body    any
+	// form replaces body for multipart uploads.
+	form     []param
+	status   int
+	response any
+	// errors are the error statuses the handler writes, besides the 401 of
+	// every authenticated route.
+	errors []int
+	public bool
+}
+
+type param struct {
+	name        string
+	description string
+	schema      map[string]any
+	required    bool
+}
+
+func stringParam(name, description string) param {
+	return param{name: name, description: description, schema: map[string]any{"type": "string"}}
+}
+
+func integerParam(name, description string) param {
+	return param{name: name, description: description, schema: map[string]any{"type": "integer"}}
+}
+
+func booleanParam(name, description string) param {
+	return param{name: name, description: description, schema: map[string]any{"type": "boolean"}}
+}
+
+func enumParam(name, description string, values []string) param {
+	return param{name: name, description: description, schema: map[string]any{"type": "string", "enum": values}}
+}
+
+func required(p param) param {
+	p.required = true
+	return p
+}
+
+var pageParams = []param{
+	integerParam("limit", "Page size, from 1 to 100; defaults to 20"),
+	integerParam("offset", "Number of items to skip"),
+}
+
+var carFilterParams = []param{
+	required(stringParam("brand", "Brand name")),
+	booleanParam("isEngine", "Include each car's engine specification"),
+	{
+		name:        "option",
+		description: "Option code the car must be fitted with; repeat to require several",
+		schema:      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	},
+	enumParam("condition", "", models.Conditions),
+	integerParam("min_odometer", "Minimum odometer reading in km"),
+	integerParam("max_odometer", "Maximum odometer reading in km"),
+	integerParam("min_grade", "Minimum condition grade"),
+	integerParam("max_owners", "Maximum number of previous owners"),
+	enumParam("accident_history", "", models.AccidentHistories),
+	booleanParam("certified", "Only certified pre-owned cars, or only cars that are not"),
+	stringParam("currency", "Also price the cars in this currency"),
+}
+
+var engineFilterParams = append([]param{
+	enumParam("powertrain", "", models.Powertrains),
+	integerParam("min_displacement", "Minimum displacement in cc"),
+	integerParam("max_displacement", "Maximum displacement in cc"),
+	integerParam("cylinders", "Number of cylinders"),
+	integerParam("min_range", "Minimum range in km"),
+	integerParam("max_range", "Maximum range in km"),
+	{
+		name:        "sort",
+		description: "Sort key, prefixed with - to sort descending",
+		schema:      map[string]any{"type": "string", "enum": sortValues(models.EngineSorts)},
+	},
+}, pageParams...)
+
+// sortValues lists the sort keys in both directions.
+func sortValues(keys []string) []string {
+	values := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		values = append(values, key, "-"+key)
+	}
+	return values
+}
+
+// operations are the documented routes: everything under /cars, /engine and
+// /login. The list is maintained by hand. TestOperationsMatchRoutes catches
+// routes added to or removed from main.go, but not changes to the query
+// parameters or bodies a handler reads, so update those here along with the
+// handler.
+var operations = []operation{
+	{
+		method: http.MethodGet, path: "/cars", id: "listCars", tag: "cars",
+		summary: "List a brand's cars",
+		query:   carFilterParams,
+		status:  http.StatusOK, response: []models.Car{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPost, path: "/cars", id: "createCar", tag: "cars",
+		summary: "Create a car",
+		body:    models.CarRequest{},
+		status:  http.StatusOK, response: models.Car{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/compare", id: "compareCars", tag: "cars",
+		summary: "Compare two to five cars side by side",
+		query: []param{
+			required(stringParam("ids", "Comma-separated car IDs")),
+			stringParam("currency", "Compare prices in this currency"),
+		},
+		status: http.StatusOK, response: models.CarComparison{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}", id: "getCar", tag: "cars",
+		summary: "Get a car",
+		status:  http.StatusOK, response: models.Car{},
+		errors: []int{http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPut, path: "/cars/{id}", id: "updateCar", tag: "cars",
+		summary: "Update a car",
+		body:    models.CarRequest{},
+		status:  http.StatusOK, response: models.Car{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodDelete, path: "/cars/{id}", id: "deleteCar", tag: "cars",
+		summary: "Delete a car",
+		status:  http.StatusOK,
+		errors:  []int{http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/options", id: "getCarOptions", tag: "cars",
+		summary: "Get the options fitted to a car",
+		status:  http.StatusOK, response: models.CarOptions{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPut, path: "/cars/{id}/options", id: "setCarOptions", tag: "cars",
+		summary: "Replace the options fitted to a car",
+		body:    models.CarOptionsRequest{},
+		status:  http.StatusOK, response: models.CarOptions{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/attachments", id: "listAttachments", tag: "cars",
+		summary: "List a car's attachments with signed download links",
+		status:  http.StatusOK, response: []models.Attachment{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPost, path: "/cars/{id}/attachments", id: "uploadAttachment", tag: "cars",
+		summary: "Attach a photo or document to a car",
+		form: []param{
+			required(param{name: "file", schema: map[string]any{"type": "string", "contentMediaType": "application/octet-stream"}}),
+			enumParam("kind", "Defaults to other", models.AttachmentKinds),
+		},
+		status: http.StatusCreated, response: models.Attachment{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodDelete, path: "/cars/{id}/attachments/{attachmentID}", id: "deleteAttachment", tag: "cars",
+		summary: "Delete an attachment",
+		status:  http.StatusNoContent,
+		errors:  []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/inspection", id: "getInspection", tag: "cars",
+		summary: "Get a used car's certified pre-owned inspection",
+		status:  http.StatusOK, response: models.Inspection{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPut, path: "/cars/{id}/inspection", id: "setInspection", tag: "cars",
+		summary: "Record a used car's certified pre-owned inspection",
+		body:    models.InspectionRequest{},
+		status:  http.StatusOK, response: models.Inspection{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodDelete, path: "/cars/{id}/inspection", id: "deleteInspection", tag: "cars",
+		summary: "Delete a car's inspection",
+		status:  http.StatusNoContent,
+		errors:  []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/transfers", id: "listCarTransfers", tag: "cars",
+		summary: "List a car's transfers between locations",
+		query:   pageParams,
+		status:  http.StatusOK, response: models.Page[models.Transfer]{},
+		errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/services", id: "listServiceRecords", tag: "cars",
+		summary: "List a car's service history",
+		status:  http.StatusOK, response: []models.ServiceRecord{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPost, path: "/cars/{id}/services", id: "createServiceRecord", tag: "cars",
+		summary: "Record a service",
+		body:    models.ServiceRecordRequest{},
+		status:  http.StatusCreated, response: models.ServiceRecord{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/services/{serviceID}", id: "getServiceRecord", tag: "cars",
+		summary: "Get a service record",
+		status:  http.StatusOK, response: models.ServiceRecord{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPut, path: "/cars/{id}/services/{serviceID}", id: "updateServiceRecord", tag: "cars",
+		summary: "Update a service record",
+		body:    models.ServiceRecordRequest{},
+		status:  http.StatusOK, response: models.ServiceRecord{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodDelete, path: "/cars/{id}/services/{serviceID}", id: "deleteServiceRecord", tag: "cars",
+		summary: "Delete a service record",
+		status:  http.StatusNoContent,
+		errors:  []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/cars/{id}/prices", id: "getPriceTimeline", tag: "cars",
+		summary: "Get a car's price history and scheduled changes",
+		status:  http.StatusOK, response: models.PriceTimeline{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPost, path: "/cars/{id}/prices/schedules", id: "schedulePriceChange", tag: "cars",
+		summary: "Schedule a price change",
+		body:    models.PriceScheduleRequest{},
+		status:  http.StatusCreated, response: models.PriceSchedule{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodDelete, path: "/cars/{id}/prices/schedules/{scheduleID}", id: "cancelPriceSchedule", tag: "cars",
+		summary: "Cancel a scheduled price change",
+		status:  http.StatusNoContent,
+		errors:  []int{http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/engine", id: "listEngines", tag: "engines",
+		summary: "List engines",
+		query:   engineFilterParams,
+		status:  http.StatusOK, response: models.Page[models.Engine]{},
+		errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPost, path: "/engine", id: "createEngine", tag: "engines",
+		summary: "Create an engine",
+		body:    models.EngineRequest{},
+		status:  http.StatusOK, response: models.Engine{},
+		errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/engine/usage", id: "getEngineUsage", tag: "engines",
+		summary: "List engines with the number and average price of cars built on them",
+		query:   engineFilterParams,
+		status:  http.StatusOK, response: models.Page[models.EngineUsage]{},
+		errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/engine/{id}", id: "getEngine", tag: "engines",
+		summary: "Get an engine",
+		status:  http.StatusOK, response: models.Engine{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPut, path: "/engine/{id}", id: "updateEngine", tag: "engines",
+		summary: "Update an engine",
+		body:    models.EngineRequest{},
+		status:  http.StatusOK, response: models.Engine{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodDelete, path: "/engine/{id}", id: "deleteEngine", tag: "engines",
+		summary: "Delete an engine no car is built on",
+		status:  http.StatusOK, response: map[string]string{},
+		errors: []int{http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodGet, path: "/engine/{id}/cars", id: "listEngineCars", tag: "engines",
+		summary: "List the cars built on an engine",
+		status:  http.StatusOK, response: []models.Car{},
+		errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		method: http.MethodPost, path: "/login", id: "login", tag: "auth",
+		summary: "Exchange a username and password for a bearer token",
+		body:    models.Credentials{},
+		status:  http.StatusOK, response: models.LoginResponse{},
+		errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		public: true,
+	},
+}
@@ -0,0 +1,135 @@
+package openapi
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+// scalars are the types with their own JSON encoding. Amounts are written
+// as JSON numbers in decimal form and also accepted as strings.
+var scalars = map[reflect.Type]map[string]any{
+	reflect.TypeFor[time.Time]():       {"type": "string", "format": "date-time"},
+	reflect.TypeFor[uuid.UUID]():       {"type": "string", "format": "uuid"},
+	reflect.TypeFor[models.Date]():     {"type": "string", "format": "date"},
+	reflect.TypeFor[models.Money]():    {"type": "number", "description": "Decimal amount with two places"},
+	reflect.TypeFor[models.Rate]():     {"type": "number", "description": "Decimal exchange rate"},
+	reflect.TypeFor[models.Percent]():  {"type": "number", "description": "Percentage with two decimal places"},
+	reflect.TypeFor[json.RawMessage](): {},
+}
+
+// schemas derives JSON Schemas from Go types the way encoding/json encodes
+// them, collecting named structs as components.
+//
+// A field is required when it is always encoded, that is when it has no
+// omitempty or omitzero. The decoder fills in what a request leaves out, so
+// structs reached from a request body are described separately, as inputs
+// with nothing required: the request types keep their names and the other
+// structs take an Input suffix.
+type schemas struct {
+	components map[string]any
+}
+
+func (s *schemas) schema(t reflect.Type, input bool) map[string]any {
+	if scalar, ok := scalars[t]; ok {
+		return scalar
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(s.schema(t.Elem(), input))
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem(), input)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem(), input)}
+	case reflect.Struct:
+		name := componentName(t, input)
+		if _, ok := s.components[name]; !ok {
+			// Claim the name first so that recursive types terminate
+			s.components[name] = nil
+			s.components[name] = s.object(t, input)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		// any, such as the values of a comparison row
+		return map[string]any{}
+	}
+}
+
+// object describes a struct's fields, with those of embedded structs
+// promoted as encoding/json does.
+func (s *schemas) object(t reflect.Type, input bool) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	s.fields(t, input, properties, &required)
+
+	object := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 && !input {
+		object["required"] = required
+	}
+	return object
+}
+
+func (s *schemas) fields(t reflect.Type, input bool, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.fields(field.Type, input, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.schema(field.Type, input)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// componentName names a struct's component. An instance of a generic type
+// is named after its type argument, so Page[Car] is CarPage.
+func componentName(t reflect.Type, input bool) string {
+	name := t.Name()
+	if base, arg, ok := strings.Cut(name, "["); ok {
+		arg = strings.TrimSuffix(arg, "]")
+		name = arg[strings.LastIndex(arg, ".")+1:] + base
+	}
+	if input && !strings.HasSuffix(name, "Request") {
+		name += "Input"
+	}
+	return name
+}
+
+// nullable allows a schema to be null as well, for pointer fields.
+func nullable(schema map[string]any) map[string]any {
+	if t, ok := schema["type"].(string); ok {
+		copied := maps.Clone(schema)
+		copied["type"] = []string{t, "null"}
+		return copied
+	}
+	if len(schema) == 0 {
+		return schema
+	}
+	return map[string]any{"oneOf": []any{schema, map[string]any{"type": "null"}}}
+}
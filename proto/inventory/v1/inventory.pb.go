@@ -0,0 +1,1854 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: inventory/v1/inventory.proto
+
+// The inventory API for internal services. It mirrors the car and engine
+// REST routes: IDs are UUID strings, amounts are decimal strings such as
+// "24999.00" and dates are "YYYY-MM-DD".
+//
+// Calls are authenticated with the same JWTs as the REST API, sent as
+// "authorization: Bearer <token>" metadata.
+
+package inventoryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EngineSpec describes an engine. Combustion fields are zero for battery
+// electric engines and battery capacity is zero for pure combustion ones.
+type EngineSpec struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Powertrain         string                 `protobuf:"bytes,1,opt,name=powertrain,proto3" json:"powertrain,omitempty"`
+	Displacement       int64                  `protobuf:"varint,2,opt,name=displacement,proto3" json:"displacement,omitempty"`
+	NumberOfCylinders  int64                  `protobuf:"varint,3,opt,name=number_of_cylinders,json=numberOfCylinders,proto3" json:"number_of_cylinders,omitempty"`
+	CarRange           int64                  `protobuf:"varint,4,opt,name=car_range,json=carRange,proto3" json:"car_range,omitempty"`
+	PowerKw            int64                  `protobuf:"varint,5,opt,name=power_kw,json=powerKw,proto3" json:"power_kw,omitempty"`
+	TorqueNm           int64                  `protobuf:"varint,6,opt,name=torque_nm,json=torqueNm,proto3" json:"torque_nm,omitempty"`
+	BatteryCapacityKwh float64                `protobuf:"fixed64,7,opt,name=battery_capacity_kwh,json=batteryCapacityKwh,proto3" json:"battery_capacity_kwh,omitempty"`
+	EmissionsClass     string                 `protobuf:"bytes,8,opt,name=emissions_class,json=emissionsClass,proto3" json:"emissions_class,omitempty"`
+	Co2GPerKm          int64                  `protobuf:"varint,9,opt,name=co2_g_per_km,json=co2GPerKm,proto3" json:"co2_g_per_km,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EngineSpec) Reset() {
+	*x = EngineSpec{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngineSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineSpec) ProtoMessage() {}
+
+func (x *EngineSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineSpec.ProtoReflect.Descriptor instead.
+func (*EngineSpec) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EngineSpec) GetPowertrain() string {
+	if x != nil {
+		return x.Powertrain
+	}
+	return ""
+}
+
+func (x *EngineSpec) GetDisplacement() int64 {
+	if x != nil {
+		return x.Displacement
+	}
+	return 0
+}
+
+func (x *EngineSpec) GetNumberOfCylinders() int64 {
+	if x != nil {
+		return x.NumberOfCylinders
+	}
+	return 0
+}
+
+func (x *EngineSpec) GetCarRange() int64 {
+	if x != nil {
+		return x.CarRange
+	}
+	return 0
+}
+
+func (x *EngineSpec) GetPowerKw() int64 {
+	if x != nil {
+		return x.PowerKw
+	}
+	return 0
+}
+
+func (x *EngineSpec) GetTorqueNm() int64 {
+	if x != nil {
+		return x.TorqueNm
+	}
+	return 0
+}
+
+func (x *EngineSpec) GetBatteryCapacityKwh() float64 {
+	if x != nil {
+		return x.BatteryCapacityKwh
+	}
+	return 0
+}
+
+func (x *EngineSpec) GetEmissionsClass() string {
+	if x != nil {
+		return x.EmissionsClass
+	}
+	return ""
+}
+
+func (x *EngineSpec) GetCo2GPerKm() int64 {
+	if x != nil {
+		return x.Co2GPerKm
+	}
+	return 0
+}
+
+type Engine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EngineId      string                 `protobuf:"bytes,1,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	Spec          *EngineSpec            `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Engine) Reset() {
+	*x = Engine{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Engine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Engine) ProtoMessage() {}
+
+func (x *Engine) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Engine.ProtoReflect.Descriptor instead.
+func (*Engine) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Engine) GetEngineId() string {
+	if x != nil {
+		return x.EngineId
+	}
+	return ""
+}
+
+func (x *Engine) GetSpec() *EngineSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Engine) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Engine) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Car struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Year       string                 `protobuf:"bytes,3,opt,name=year,proto3" json:"year,omitempty"`
+	Brand      string                 `protobuf:"bytes,4,opt,name=brand,proto3" json:"brand,omitempty"`
+	BrandId    string                 `protobuf:"bytes,5,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	Model      *string                `protobuf:"bytes,6,opt,name=model,proto3,oneof" json:"model,omitempty"`
+	ModelId    *string                `protobuf:"bytes,7,opt,name=model_id,json=modelId,proto3,oneof" json:"model_id,omitempty"`
+	Trim       *string                `protobuf:"bytes,8,opt,name=trim,proto3,oneof" json:"trim,omitempty"`
+	TrimId     *string                `protobuf:"bytes,9,opt,name=trim_id,json=trimId,proto3,oneof" json:"trim_id,omitempty"`
+	LocationId *string                `protobuf:"bytes,10,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"`
+	Location   *string                `protobuf:"bytes,11,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	// in_transit is set while a transfer is moving the car away from its
+	// location.
+	InTransit          bool    `protobuf:"varint,12,opt,name=in_transit,json=inTransit,proto3" json:"in_transit,omitempty"`
+	Condition          string  `protobuf:"bytes,13,opt,name=condition,proto3" json:"condition,omitempty"`
+	OdometerKm         int64   `protobuf:"varint,14,opt,name=odometer_km,json=odometerKm,proto3" json:"odometer_km,omitempty"`
+	ConditionGrade     *int32  `protobuf:"varint,15,opt,name=condition_grade,json=conditionGrade,proto3,oneof" json:"condition_grade,omitempty"`
+	PreviousOwners     int32   `protobuf:"varint,16,opt,name=previous_owners,json=previousOwners,proto3" json:"previous_owners,omitempty"`
+	AccidentHistory    string  `protobuf:"bytes,17,opt,name=accident_history,json=accidentHistory,proto3" json:"accident_history,omitempty"`
+	RegistrationNumber *string `protobuf:"bytes,18,opt,name=registration_number,json=registrationNumber,proto3,oneof" json:"registration_number,omitempty"`
+	RegistrationDate   *string `protobuf:"bytes,19,opt,name=registration_date,json=registrationDate,proto3,oneof" json:"registration_date,omitempty"`
+	RegistrationRegion *string `protobuf:"bytes,20,opt,name=registration_region,json=registrationRegion,proto3,oneof" json:"registration_region,omitempty"`
+	// certified_pre_owned is set on a used car whose inspection passed.
+	CertifiedPreOwned bool    `protobuf:"varint,21,opt,name=certified_pre_owned,json=certifiedPreOwned,proto3" json:"certified_pre_owned,omitempty"`
+	FuelType          string  `protobuf:"bytes,22,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	Engine            *Engine `protobuf:"bytes,23,opt,name=engine,proto3" json:"engine,omitempty"`
+	Price             string  `protobuf:"bytes,24,opt,name=price,proto3" json:"price,omitempty"`
+	Currency          string  `protobuf:"bytes,25,opt,name=currency,proto3" json:"currency,omitempty"`
+	// total_price is the price plus the options fitted to the car.
+	TotalPrice string `protobuf:"bytes,26,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	// options is only set by listings that ask for them.
+	Options []*CarOption `protobuf:"bytes,27,rep,name=options,proto3" json:"options,omitempty"`
+	// converted_price is only set when the request asked for another currency.
+	ConvertedPrice *ConvertedPrice        `protobuf:"bytes,28,opt,name=converted_price,json=convertedPrice,proto3" json:"converted_price,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,29,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,30,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Car) Reset() {
+	*x = Car{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Car) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Car) ProtoMessage() {}
+
+func (x *Car) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Car.ProtoReflect.Descriptor instead.
+func (*Car) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Car) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Car) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Car) GetYear() string {
+	if x != nil {
+		return x.Year
+	}
+	return ""
+}
+
+func (x *Car) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Car) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *Car) GetModel() string {
+	if x != nil && x.Model != nil {
+		return *x.Model
+	}
+	return ""
+}
+
+func (x *Car) GetModelId() string {
+	if x != nil && x.ModelId != nil {
+		return *x.ModelId
+	}
+	return ""
+}
+
+func (x *Car) GetTrim() string {
+	if x != nil && x.Trim != nil {
+		return *x.Trim
+	}
+	return ""
+}
+
+func (x *Car) GetTrimId() string {
+	if x != nil && x.TrimId != nil {
+		return *x.TrimId
+	}
+	return ""
+}
+
+func (x *Car) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+func (x *Car) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *Car) GetInTransit() bool {
+	if x != nil {
+		return x.InTransit
+	}
+	return false
+}
+
+func (x *Car) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *Car) GetOdometerKm() int64 {
+	if x != nil {
+		return x.OdometerKm
+	}
+	return 0
+}
+
+func (x *Car) GetConditionGrade() int32 {
+	if x != nil && x.ConditionGrade != nil {
+		return *x.ConditionGrade
+	}
+	return 0
+}
+
+func (x *Car) GetPreviousOwners() int32 {
+	if x != nil {
+		return x.PreviousOwners
+	}
+	return 0
+}
+
+func (x *Car) GetAccidentHistory() string {
+	if x != nil {
+		return x.AccidentHistory
+	}
+	return ""
+}
+
+func (x *Car) GetRegistrationNumber() string {
+	if x != nil && x.RegistrationNumber != nil {
+		return *x.RegistrationNumber
+	}
+	return ""
+}
+
+func (x *Car) GetRegistrationDate() string {
+	if x != nil && x.RegistrationDate != nil {
+		return *x.RegistrationDate
+	}
+	return ""
+}
+
+func (x *Car) GetRegistrationRegion() string {
+	if x != nil && x.RegistrationRegion != nil {
+		return *x.RegistrationRegion
+	}
+	return ""
+}
+
+func (x *Car) GetCertifiedPreOwned() bool {
+	if x != nil {
+		return x.CertifiedPreOwned
+	}
+	return false
+}
+
+func (x *Car) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *Car) GetEngine() *Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+func (x *Car) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Car) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Car) GetTotalPrice() string {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return ""
+}
+
+func (x *Car) GetOptions() []*CarOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Car) GetConvertedPrice() *ConvertedPrice {
+	if x != nil {
+		return x.ConvertedPrice
+	}
+	return nil
+}
+
+func (x *Car) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Car) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CarOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptionId      string                 `protobuf:"bytes,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Price         string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarOption) Reset() {
+	*x = CarOption{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarOption) ProtoMessage() {}
+
+func (x *CarOption) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarOption.ProtoReflect.Descriptor instead.
+func (*CarOption) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CarOption) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *CarOption) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CarOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CarOption) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CarOption) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+type ConvertedPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        string                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	EffectiveDate string                 `protobuf:"bytes,4,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertedPrice) Reset() {
+	*x = ConvertedPrice{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertedPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertedPrice) ProtoMessage() {}
+
+func (x *ConvertedPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertedPrice.ProtoReflect.Descriptor instead.
+func (*ConvertedPrice) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConvertedPrice) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ConvertedPrice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ConvertedPrice) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ConvertedPrice) GetEffectiveDate() string {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return ""
+}
+
+// CarInput creates or replaces a car. The brand, model and trim are free
+// text resolved against the reference data. Set engine_id to build the car
+// on an existing engine, or engine to describe one.
+type CarInput struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Year       string                 `protobuf:"bytes,2,opt,name=year,proto3" json:"year,omitempty"`
+	Brand      string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model      string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Trim       string                 `protobuf:"bytes,5,opt,name=trim,proto3" json:"trim,omitempty"`
+	LocationId *string                `protobuf:"bytes,6,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"`
+	// condition defaults to new; used cars also carry the fields below it.
+	Condition          string      `protobuf:"bytes,7,opt,name=condition,proto3" json:"condition,omitempty"`
+	OdometerKm         int64       `protobuf:"varint,8,opt,name=odometer_km,json=odometerKm,proto3" json:"odometer_km,omitempty"`
+	ConditionGrade     *int32      `protobuf:"varint,9,opt,name=condition_grade,json=conditionGrade,proto3,oneof" json:"condition_grade,omitempty"`
+	PreviousOwners     int32       `protobuf:"varint,10,opt,name=previous_owners,json=previousOwners,proto3" json:"previous_owners,omitempty"`
+	AccidentHistory    string      `protobuf:"bytes,11,opt,name=accident_history,json=accidentHistory,proto3" json:"accident_history,omitempty"`
+	RegistrationNumber string      `protobuf:"bytes,12,opt,name=registration_number,json=registrationNumber,proto3" json:"registration_number,omitempty"`
+	RegistrationDate   *string     `protobuf:"bytes,13,opt,name=registration_date,json=registrationDate,proto3,oneof" json:"registration_date,omitempty"`
+	RegistrationRegion string      `protobuf:"bytes,14,opt,name=registration_region,json=registrationRegion,proto3" json:"registration_region,omitempty"`
+	FuelType           string      `protobuf:"bytes,15,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	EngineId           string      `protobuf:"bytes,16,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	Engine             *EngineSpec `protobuf:"bytes,17,opt,name=engine,proto3" json:"engine,omitempty"`
+	Price              string      `protobuf:"bytes,18,opt,name=price,proto3" json:"price,omitempty"`
+	Currency           string      `protobuf:"bytes,19,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CarInput) Reset() {
+	*x = CarInput{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarInput) ProtoMessage() {}
+
+func (x *CarInput) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarInput.ProtoReflect.Descriptor instead.
+func (*CarInput) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CarInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CarInput) GetYear() string {
+	if x != nil {
+		return x.Year
+	}
+	return ""
+}
+
+func (x *CarInput) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *CarInput) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CarInput) GetTrim() string {
+	if x != nil {
+		return x.Trim
+	}
+	return ""
+}
+
+func (x *CarInput) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+func (x *CarInput) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *CarInput) GetOdometerKm() int64 {
+	if x != nil {
+		return x.OdometerKm
+	}
+	return 0
+}
+
+func (x *CarInput) GetConditionGrade() int32 {
+	if x != nil && x.ConditionGrade != nil {
+		return *x.ConditionGrade
+	}
+	return 0
+}
+
+func (x *CarInput) GetPreviousOwners() int32 {
+	if x != nil {
+		return x.PreviousOwners
+	}
+	return 0
+}
+
+func (x *CarInput) GetAccidentHistory() string {
+	if x != nil {
+		return x.AccidentHistory
+	}
+	return ""
+}
+
+func (x *CarInput) GetRegistrationNumber() string {
+	if x != nil {
+		return x.RegistrationNumber
+	}
+	return ""
+}
+
+func (x *CarInput) GetRegistrationDate() string {
+	if x != nil && x.RegistrationDate != nil {
+		return *x.RegistrationDate
+	}
+	return ""
+}
+
+func (x *CarInput) GetRegistrationRegion() string {
+	if x != nil {
+		return x.RegistrationRegion
+	}
+	return ""
+}
+
+func (x *CarInput) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *CarInput) GetEngineId() string {
+	if x != nil {
+		return x.EngineId
+	}
+	return ""
+}
+
+func (x *CarInput) GetEngine() *EngineSpec {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+func (x *CarInput) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CarInput) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCarRequest) Reset() {
+	*x = GetCarRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCarRequest) ProtoMessage() {}
+
+func (x *GetCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCarRequest.ProtoReflect.Descriptor instead.
+func (*GetCarRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListCarsRequest takes the filters of GET /cars. brand is required.
+type ListCarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         string                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	IncludeEngine bool                   `protobuf:"varint,2,opt,name=include_engine,json=includeEngine,proto3" json:"include_engine,omitempty"`
+	// options lists option codes the cars must all be fitted with.
+	Options         []string `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	Condition       string   `protobuf:"bytes,4,opt,name=condition,proto3" json:"condition,omitempty"`
+	MinOdometerKm   *int64   `protobuf:"varint,5,opt,name=min_odometer_km,json=minOdometerKm,proto3,oneof" json:"min_odometer_km,omitempty"`
+	MaxOdometerKm   *int64   `protobuf:"varint,6,opt,name=max_odometer_km,json=maxOdometerKm,proto3,oneof" json:"max_odometer_km,omitempty"`
+	MinGrade        *int32   `protobuf:"varint,7,opt,name=min_grade,json=minGrade,proto3,oneof" json:"min_grade,omitempty"`
+	MaxOwners       *int32   `protobuf:"varint,8,opt,name=max_owners,json=maxOwners,proto3,oneof" json:"max_owners,omitempty"`
+	AccidentHistory string   `protobuf:"bytes,9,opt,name=accident_history,json=accidentHistory,proto3" json:"accident_history,omitempty"`
+	Certified       *bool    `protobuf:"varint,10,opt,name=certified,proto3,oneof" json:"certified,omitempty"`
+	// currency converts the prices into another currency.
+	Currency      string `protobuf:"bytes,11,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCarsRequest) Reset() {
+	*x = ListCarsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCarsRequest) ProtoMessage() {}
+
+func (x *ListCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCarsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCarsRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetIncludeEngine() bool {
+	if x != nil {
+		return x.IncludeEngine
+	}
+	return false
+}
+
+func (x *ListCarsRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ListCarsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetMinOdometerKm() int64 {
+	if x != nil && x.MinOdometerKm != nil {
+		return *x.MinOdometerKm
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetMaxOdometerKm() int64 {
+	if x != nil && x.MaxOdometerKm != nil {
+		return *x.MaxOdometerKm
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetMinGrade() int32 {
+	if x != nil && x.MinGrade != nil {
+		return *x.MinGrade
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetMaxOwners() int32 {
+	if x != nil && x.MaxOwners != nil {
+		return *x.MaxOwners
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetAccidentHistory() string {
+	if x != nil {
+		return x.AccidentHistory
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetCertified() bool {
+	if x != nil && x.Certified != nil {
+		return *x.Certified
+	}
+	return false
+}
+
+func (x *ListCarsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListCarsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cars          []*Car                 `protobuf:"bytes,1,rep,name=cars,proto3" json:"cars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCarsResponse) Reset() {
+	*x = ListCarsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCarsResponse) ProtoMessage() {}
+
+func (x *ListCarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCarsResponse.ProtoReflect.Descriptor instead.
+func (*ListCarsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCarsResponse) GetCars() []*Car {
+	if x != nil {
+		return x.Cars
+	}
+	return nil
+}
+
+type CreateCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           *CarInput              `protobuf:"bytes,1,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCarRequest) Reset() {
+	*x = CreateCarRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCarRequest) ProtoMessage() {}
+
+func (x *CreateCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCarRequest.ProtoReflect.Descriptor instead.
+func (*CreateCarRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateCarRequest) GetCar() *CarInput {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+type UpdateCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Car           *CarInput              `protobuf:"bytes,2,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCarRequest) Reset() {
+	*x = UpdateCarRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCarRequest) ProtoMessage() {}
+
+func (x *UpdateCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCarRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCarRequest) GetCar() *CarInput {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+type DeleteCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCarRequest) Reset() {
+	*x = DeleteCarRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCarRequest) ProtoMessage() {}
+
+func (x *DeleteCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCarRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EngineId      string                 `protobuf:"bytes,1,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEngineRequest) Reset() {
+	*x = GetEngineRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineRequest) ProtoMessage() {}
+
+func (x *GetEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineRequest.ProtoReflect.Descriptor instead.
+func (*GetEngineRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetEngineRequest) GetEngineId() string {
+	if x != nil {
+		return x.EngineId
+	}
+	return ""
+}
+
+// ListEnginesRequest takes the filters of GET /engine. limit defaults to
+// 20.
+type ListEnginesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Powertrain      string                 `protobuf:"bytes,1,opt,name=powertrain,proto3" json:"powertrain,omitempty"`
+	MinDisplacement *int64                 `protobuf:"varint,2,opt,name=min_displacement,json=minDisplacement,proto3,oneof" json:"min_displacement,omitempty"`
+	MaxDisplacement *int64                 `protobuf:"varint,3,opt,name=max_displacement,json=maxDisplacement,proto3,oneof" json:"max_displacement,omitempty"`
+	Cylinders       *int64                 `protobuf:"varint,4,opt,name=cylinders,proto3,oneof" json:"cylinders,omitempty"`
+	MinRange        *int64                 `protobuf:"varint,5,opt,name=min_range,json=minRange,proto3,oneof" json:"min_range,omitempty"`
+	MaxRange        *int64                 `protobuf:"varint,6,opt,name=max_range,json=maxRange,proto3,oneof" json:"max_range,omitempty"`
+	Sort            string                 `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending      bool                   `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	Limit           int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          int32                  `protobuf:"varint,10,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListEnginesRequest) Reset() {
+	*x = ListEnginesRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnginesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnginesRequest) ProtoMessage() {}
+
+func (x *ListEnginesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnginesRequest.ProtoReflect.Descriptor instead.
+func (*ListEnginesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListEnginesRequest) GetPowertrain() string {
+	if x != nil {
+		return x.Powertrain
+	}
+	return ""
+}
+
+func (x *ListEnginesRequest) GetMinDisplacement() int64 {
+	if x != nil && x.MinDisplacement != nil {
+		return *x.MinDisplacement
+	}
+	return 0
+}
+
+func (x *ListEnginesRequest) GetMaxDisplacement() int64 {
+	if x != nil && x.MaxDisplacement != nil {
+		return *x.MaxDisplacement
+	}
+	return 0
+}
+
+func (x *ListEnginesRequest) GetCylinders() int64 {
+	if x != nil && x.Cylinders != nil {
+		return *x.Cylinders
+	}
+	return 0
+}
+
+func (x *ListEnginesRequest) GetMinRange() int64 {
+	if x != nil && x.MinRange != nil {
+		return *x.MinRange
+	}
+	return 0
+}
+
+func (x *ListEnginesRequest) GetMaxRange() int64 {
+	if x != nil && x.MaxRange != nil {
+		return *x.MaxRange
+	}
+	return 0
+}
+
+func (x *ListEnginesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListEnginesRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListEnginesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEnginesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListEnginesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Engines       []*Engine              `protobuf:"bytes,1,rep,name=engines,proto3" json:"engines,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnginesResponse) Reset() {
+	*x = ListEnginesResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnginesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnginesResponse) ProtoMessage() {}
+
+func (x *ListEnginesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnginesResponse.ProtoReflect.Descriptor instead.
+func (*ListEnginesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListEnginesResponse) GetEngines() []*Engine {
+	if x != nil {
+		return x.Engines
+	}
+	return nil
+}
+
+func (x *ListEnginesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListEnginesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEnginesResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type CreateEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Engine        *EngineSpec            `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEngineRequest) Reset() {
+	*x = CreateEngineRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEngineRequest) ProtoMessage() {}
+
+func (x *CreateEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEngineRequest.ProtoReflect.Descriptor instead.
+func (*CreateEngineRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateEngineRequest) GetEngine() *EngineSpec {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+type UpdateEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EngineId      string                 `protobuf:"bytes,1,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	Engine        *EngineSpec            `protobuf:"bytes,2,opt,name=engine,proto3" json:"engine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEngineRequest) Reset() {
+	*x = UpdateEngineRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEngineRequest) ProtoMessage() {}
+
+func (x *UpdateEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEngineRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEngineRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateEngineRequest) GetEngineId() string {
+	if x != nil {
+		return x.EngineId
+	}
+	return ""
+}
+
+func (x *UpdateEngineRequest) GetEngine() *EngineSpec {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+type DeleteEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EngineId      string                 `protobuf:"bytes,1,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEngineRequest) Reset() {
+	*x = DeleteEngineRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEngineRequest) ProtoMessage() {}
+
+func (x *DeleteEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEngineRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEngineRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteEngineRequest) GetEngineId() string {
+	if x != nil {
+		return x.EngineId
+	}
+	return ""
+}
+
+var File_inventory_v1_inventory_proto protoreflect.FileDescriptor
+
+const file_inventory_v1_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x1cinventory/v1/inventory.proto\x12\finventory.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd1\x02\n" +
+	"\n" +
+	"EngineSpec\x12\x1e\n" +
+	"\n" +
+	"powertrain\x18\x01 \x01(\tR\n" +
+	"powertrain\x12\"\n" +
+	"\fdisplacement\x18\x02 \x01(\x03R\fdisplacement\x12.\n" +
+	"\x13number_of_cylinders\x18\x03 \x01(\x03R\x11numberOfCylinders\x12\x1b\n" +
+	"\tcar_range\x18\x04 \x01(\x03R\bcarRange\x12\x19\n" +
+	"\bpower_kw\x18\x05 \x01(\x03R\apowerKw\x12\x1b\n" +
+	"\ttorque_nm\x18\x06 \x01(\x03R\btorqueNm\x120\n" +
+	"\x14battery_capacity_kwh\x18\a \x01(\x01R\x12batteryCapacityKwh\x12'\n" +
+	"\x0femissions_class\x18\b \x01(\tR\x0eemissionsClass\x12\x1f\n" +
+	"\fco2_g_per_km\x18\t \x01(\x03R\tco2GPerKm\"\xc9\x01\n" +
+	"\x06Engine\x12\x1b\n" +
+	"\tengine_id\x18\x01 \x01(\tR\bengineId\x12,\n" +
+	"\x04spec\x18\x02 \x01(\v2\x18.inventory.v1.EngineSpecR\x04spec\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x86\n" +
+	"\n" +
+	"\x03Car\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\tR\x04year\x12\x14\n" +
+	"\x05brand\x18\x04 \x01(\tR\x05brand\x12\x19\n" +
+	"\bbrand_id\x18\x05 \x01(\tR\abrandId\x12\x19\n" +
+	"\x05model\x18\x06 \x01(\tH\x00R\x05model\x88\x01\x01\x12\x1e\n" +
+	"\bmodel_id\x18\a \x01(\tH\x01R\amodelId\x88\x01\x01\x12\x17\n" +
+	"\x04trim\x18\b \x01(\tH\x02R\x04trim\x88\x01\x01\x12\x1c\n" +
+	"\atrim_id\x18\t \x01(\tH\x03R\x06trimId\x88\x01\x01\x12$\n" +
+	"\vlocation_id\x18\n" +
+	" \x01(\tH\x04R\n" +
+	"locationId\x88\x01\x01\x12\x1f\n" +
+	"\blocation\x18\v \x01(\tH\x05R\blocation\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"in_transit\x18\f \x01(\bR\tinTransit\x12\x1c\n" +
+	"\tcondition\x18\r \x01(\tR\tcondition\x12\x1f\n" +
+	"\vodometer_km\x18\x0e \x01(\x03R\n" +
+	"odometerKm\x12,\n" +
+	"\x0fcondition_grade\x18\x0f \x01(\x05H\x06R\x0econditionGrade\x88\x01\x01\x12'\n" +
+	"\x0fprevious_owners\x18\x10 \x01(\x05R\x0epreviousOwners\x12)\n" +
+	"\x10accident_history\x18\x11 \x01(\tR\x0faccidentHistory\x124\n" +
+	"\x13registration_number\x18\x12 \x01(\tH\aR\x12registrationNumber\x88\x01\x01\x120\n" +
+	"\x11registration_date\x18\x13 \x01(\tH\bR\x10registrationDate\x88\x01\x01\x124\n" +
+	"\x13registration_region\x18\x14 \x01(\tH\tR\x12registrationRegion\x88\x01\x01\x12.\n" +
+	"\x13certified_pre_owned\x18\x15 \x01(\bR\x11certifiedPreOwned\x12\x1b\n" +
+	"\tfuel_type\x18\x16 \x01(\tR\bfuelType\x12,\n" +
+	"\x06engine\x18\x17 \x01(\v2\x14.inventory.v1.EngineR\x06engine\x12\x14\n" +
+	"\x05price\x18\x18 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x19 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vtotal_price\x18\x1a \x01(\tR\n" +
+	"totalPrice\x121\n" +
+	"\aoptions\x18\x1b \x03(\v2\x17.inventory.v1.CarOptionR\aoptions\x12E\n" +
+	"\x0fconverted_price\x18\x1c \x01(\v2\x1c.inventory.v1.ConvertedPriceR\x0econvertedPrice\x129\n" +
+	"\n" +
+	"created_at\x18\x1d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x1e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\b\n" +
+	"\x06_modelB\v\n" +
+	"\t_model_idB\a\n" +
+	"\x05_trimB\n" +
+	"\n" +
+	"\b_trim_idB\x0e\n" +
+	"\f_location_idB\v\n" +
+	"\t_locationB\x12\n" +
+	"\x10_condition_gradeB\x16\n" +
+	"\x14_registration_numberB\x14\n" +
+	"\x12_registration_dateB\x16\n" +
+	"\x14_registration_region\"\x82\x01\n" +
+	"\tCarOption\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\tR\boptionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\"\x7f\n" +
+	"\x0eConvertedPrice\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12%\n" +
+	"\x0eeffective_date\x18\x04 \x01(\tR\reffectiveDate\"\xc5\x05\n" +
+	"\bCarInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\tR\x04year\x12\x14\n" +
+	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x12\n" +
+	"\x04trim\x18\x05 \x01(\tR\x04trim\x12$\n" +
+	"\vlocation_id\x18\x06 \x01(\tH\x00R\n" +
+	"locationId\x88\x01\x01\x12\x1c\n" +
+	"\tcondition\x18\a \x01(\tR\tcondition\x12\x1f\n" +
+	"\vodometer_km\x18\b \x01(\x03R\n" +
+	"odometerKm\x12,\n" +
+	"\x0fcondition_grade\x18\t \x01(\x05H\x01R\x0econditionGrade\x88\x01\x01\x12'\n" +
+	"\x0fprevious_owners\x18\n" +
+	" \x01(\x05R\x0epreviousOwners\x12)\n" +
+	"\x10accident_history\x18\v \x01(\tR\x0faccidentHistory\x12/\n" +
+	"\x13registration_number\x18\f \x01(\tR\x12registrationNumber\x120\n" +
+	"\x11registration_date\x18\r \x01(\tH\x02R\x10registrationDate\x88\x01\x01\x12/\n" +
+	"\x13registration_region\x18\x0e \x01(\tR\x12registrationRegion\x12\x1b\n" +
+	"\tfuel_type\x18\x0f \x01(\tR\bfuelType\x12\x1b\n" +
+	"\tengine_id\x18\x10 \x01(\tR\bengineId\x120\n" +
+	"\x06engine\x18\x11 \x01(\v2\x18.inventory.v1.EngineSpecR\x06engine\x12\x14\n" +
+	"\x05price\x18\x12 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x13 \x01(\tR\bcurrencyB\x0e\n" +
+	"\f_location_idB\x12\n" +
+	"\x10_condition_gradeB\x14\n" +
+	"\x12_registration_date\"\x1f\n" +
+	"\rGetCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe3\x03\n" +
+	"\x0fListCarsRequest\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12%\n" +
+	"\x0einclude_engine\x18\x02 \x01(\bR\rincludeEngine\x12\x18\n" +
+	"\aoptions\x18\x03 \x03(\tR\aoptions\x12\x1c\n" +
+	"\tcondition\x18\x04 \x01(\tR\tcondition\x12+\n" +
+	"\x0fmin_odometer_km\x18\x05 \x01(\x03H\x00R\rminOdometerKm\x88\x01\x01\x12+\n" +
+	"\x0fmax_odometer_km\x18\x06 \x01(\x03H\x01R\rmaxOdometerKm\x88\x01\x01\x12 \n" +
+	"\tmin_grade\x18\a \x01(\x05H\x02R\bminGrade\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_owners\x18\b \x01(\x05H\x03R\tmaxOwners\x88\x01\x01\x12)\n" +
+	"\x10accident_history\x18\t \x01(\tR\x0faccidentHistory\x12!\n" +
+	"\tcertified\x18\n" +
+	" \x01(\bH\x04R\tcertified\x88\x01\x01\x12\x1a\n" +
+	"\bcurrency\x18\v \x01(\tR\bcurrencyB\x12\n" +
+	"\x10_min_odometer_kmB\x12\n" +
+	"\x10_max_odometer_kmB\f\n" +
+	"\n" +
+	"_min_gradeB\r\n" +
+	"\v_max_ownersB\f\n" +
+	"\n" +
+	"_certified\"9\n" +
+	"\x10ListCarsResponse\x12%\n" +
+	"\x04cars\x18\x01 \x03(\v2\x11.inventory.v1.CarR\x04cars\"<\n" +
+	"\x10CreateCarRequest\x12(\n" +
+	"\x03car\x18\x01 \x01(\v2\x16.inventory.v1.CarInputR\x03car\"L\n" +
+	"\x10UpdateCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x03car\x18\x02 \x01(\v2\x16.inventory.v1.CarInputR\x03car\"\"\n" +
+	"\x10DeleteCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x10GetEngineRequest\x12\x1b\n" +
+	"\tengine_id\x18\x01 \x01(\tR\bengineId\"\xb1\x03\n" +
+	"\x12ListEnginesRequest\x12\x1e\n" +
+	"\n" +
+	"powertrain\x18\x01 \x01(\tR\n" +
+	"powertrain\x12.\n" +
+	"\x10min_displacement\x18\x02 \x01(\x03H\x00R\x0fminDisplacement\x88\x01\x01\x12.\n" +
+	"\x10max_displacement\x18\x03 \x01(\x03H\x01R\x0fmaxDisplacement\x88\x01\x01\x12!\n" +
+	"\tcylinders\x18\x04 \x01(\x03H\x02R\tcylinders\x88\x01\x01\x12 \n" +
+	"\tmin_range\x18\x05 \x01(\x03H\x03R\bminRange\x88\x01\x01\x12 \n" +
+	"\tmax_range\x18\x06 \x01(\x03H\x04R\bmaxRange\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descending\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\n" +
+	" \x01(\x05R\x06offsetB\x13\n" +
+	"\x11_min_displacementB\x13\n" +
+	"\x11_max_displacementB\f\n" +
+	"\n" +
+	"_cylindersB\f\n" +
+	"\n" +
+	"_min_rangeB\f\n" +
+	"\n" +
+	"_max_range\"\x89\x01\n" +
+	"\x13ListEnginesResponse\x12.\n" +
+	"\aengines\x18\x01 \x03(\v2\x14.inventory.v1.EngineR\aengines\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"G\n" +
+	"\x13CreateEngineRequest\x120\n" +
+	"\x06engine\x18\x01 \x01(\v2\x18.inventory.v1.EngineSpecR\x06engine\"d\n" +
+	"\x13UpdateEngineRequest\x12\x1b\n" +
+	"\tengine_id\x18\x01 \x01(\tR\bengineId\x120\n" +
+	"\x06engine\x18\x02 \x01(\v2\x18.inventory.v1.EngineSpecR\x06engine\"2\n" +
+	"\x13DeleteEngineRequest\x12\x1b\n" +
+	"\tengine_id\x18\x01 \x01(\tR\bengineId2\xd6\x02\n" +
+	"\n" +
+	"CarService\x128\n" +
+	"\x06GetCar\x12\x1b.inventory.v1.GetCarRequest\x1a\x11.inventory.v1.Car\x12I\n" +
+	"\bListCars\x12\x1d.inventory.v1.ListCarsRequest\x1a\x1e.inventory.v1.ListCarsResponse\x12>\n" +
+	"\tCreateCar\x12\x1e.inventory.v1.CreateCarRequest\x1a\x11.inventory.v1.Car\x12>\n" +
+	"\tUpdateCar\x12\x1e.inventory.v1.UpdateCarRequest\x1a\x11.inventory.v1.Car\x12C\n" +
+	"\tDeleteCar\x12\x1e.inventory.v1.DeleteCarRequest\x1a\x16.google.protobuf.Empty2\x83\x03\n" +
+	"\rEngineService\x12A\n" +
+	"\tGetEngine\x12\x1e.inventory.v1.GetEngineRequest\x1a\x14.inventory.v1.Engine\x12R\n" +
+	"\vListEngines\x12 .inventory.v1.ListEnginesRequest\x1a!.inventory.v1.ListEnginesResponse\x12G\n" +
+	"\fCreateEngine\x12!.inventory.v1.CreateEngineRequest\x1a\x14.inventory.v1.Engine\x12G\n" +
+	"\fUpdateEngine\x12!.inventory.v1.UpdateEngineRequest\x1a\x14.inventory.v1.Engine\x12I\n" +
+	"\fDeleteEngine\x12!.inventory.v1.DeleteEngineRequest\x1a\x16.google.protobuf.EmptyBCZAgithub.com/LikhithMar14/management/proto/inventory/v1;inventoryv1b\x06proto3"
+
+var (
+	file_inventory_v1_inventory_proto_rawDescOnce sync.Once
+	file_inventory_v1_inventory_proto_rawDescData []byte
+)
+
+func file_inventory_v1_inventory_proto_rawDescGZIP() []byte {
+	file_inventory_v1_inventory_proto_rawDescOnce.Do(func() {
+		file_inventory_v1_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inventory_v1_inventory_proto_rawDesc), len(file_inventory_v1_inventory_proto_rawDesc)))
+	})
+	return file_inventory_v1_inventory_proto_rawDescData
+}
+
+var file_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_inventory_v1_inventory_proto_goTypes = []any{
+	(*EngineSpec)(nil),            // 0: inventory.v1.EngineSpec
+	(*Engine)(nil),                // 1: inventory.v1.Engine
+	(*Car)(nil),                   // 2: inventory.v1.Car
+	(*CarOption)(nil),             // 3: inventory.v1.CarOption
+	(*ConvertedPrice)(nil),        // 4: inventory.v1.ConvertedPrice
+	(*CarInput)(nil),              // 5: inventory.v1.CarInput
+	(*GetCarRequest)(nil),         // 6: inventory.v1.GetCarRequest
+	(*ListCarsRequest)(nil),       // 7: inventory.v1.ListCarsRequest
+	(*ListCarsResponse)(nil),      // 8: inventory.v1.ListCarsResponse
+	(*CreateCarRequest)(nil),      // 9: inventory.v1.CreateCarRequest
+	(*UpdateCarRequest)(nil),      // 10: inventory.v1.UpdateCarRequest
+	(*DeleteCarRequest)(nil),      // 11: inventory.v1.DeleteCarRequest
+	(*GetEngineRequest)(nil),      // 12: inventory.v1.GetEngineRequest
+	(*ListEnginesRequest)(nil),    // 13: inventory.v1.ListEnginesRequest
+	(*ListEnginesResponse)(nil),   // 14: inventory.v1.ListEnginesResponse
+	(*CreateEngineRequest)(nil),   // 15: inventory.v1.CreateEngineRequest
+	(*UpdateEngineRequest)(nil),   // 16: inventory.v1.UpdateEngineRequest
+	(*DeleteEngineRequest)(nil),   // 17: inventory.v1.DeleteEngineRequest
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 19: google.protobuf.Empty
+}
+var file_inventory_v1_inventory_proto_depIdxs = []int32{
+	0,  // 0: inventory.v1.Engine.spec:type_name -> inventory.v1.EngineSpec
+	18, // 1: inventory.v1.Engine.created_at:type_name -> google.protobuf.Timestamp
+	18, // 2: inventory.v1.Engine.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: inventory.v1.Car.engine:type_name -> inventory.v1.Engine
+	3,  // 4: inventory.v1.Car.options:type_name -> inventory.v1.CarOption
+	4,  // 5: inventory.v1.Car.converted_price:type_name -> inventory.v1.ConvertedPrice
+	18, // 6: inventory.v1.Car.created_at:type_name -> google.protobuf.Timestamp
+	18, // 7: inventory.v1.Car.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: inventory.v1.CarInput.engine:type_name -> inventory.v1.EngineSpec
+	2,  // 9: inventory.v1.ListCarsResponse.cars:type_name -> inventory.v1.Car
+	5,  // 10: inventory.v1.CreateCarRequest.car:type_name -> inventory.v1.CarInput
+	5,  // 11: inventory.v1.UpdateCarRequest.car:type_name -> inventory.v1.CarInput
+	1,  // 12: inventory.v1.ListEnginesResponse.engines:type_name -> inventory.v1.Engine
+	0,  // 13: inventory.v1.CreateEngineRequest.engine:type_name -> inventory.v1.EngineSpec
+	0,  // 14: inventory.v1.UpdateEngineRequest.engine:type_name -> inventory.v1.EngineSpec
+	6,  // 15: inventory.v1.CarService.GetCar:input_type -> inventory.v1.GetCarRequest
+	7,  // 16: inventory.v1.CarService.ListCars:input_type -> inventory.v1.ListCarsRequest
+	9,  // 17: inventory.v1.CarService.CreateCar:input_type -> inventory.v1.CreateCarRequest
+	10, // 18: inventory.v1.CarService.UpdateCar:input_type -> inventory.v1.UpdateCarRequest
+	11, // 19: inventory.v1.CarService.DeleteCar:input_type -> inventory.v1.DeleteCarRequest
+	12, // 20: inventory.v1.EngineService.GetEngine:input_type -> inventory.v1.GetEngineRequest
+	13, // 21: inventory.v1.EngineService.ListEngines:input_type -> inventory.v1.ListEnginesRequest
+	15, // 22: inventory.v1.EngineService.CreateEngine:input_type -> inventory.v1.CreateEngineRequest
+	16, // 23: inventory.v1.EngineService.UpdateEngine:input_type -> inventory.v1.UpdateEngineRequest
+	17, // 24: inventory.v1.EngineService.DeleteEngine:input_type -> inventory.v1.DeleteEngineRequest
+	2,  // 25: inventory.v1.CarService.GetCar:output_type -> inventory.v1.Car
+	8,  // 26: inventory.v1.CarService.ListCars:output_type -> inventory.v1.ListCarsResponse
+	2,  // 27: inventory.v1.CarService.CreateCar:output_type -> inventory.v1.Car
+	2,  // 28: inventory.v1.CarService.UpdateCar:output_type -> inventory.v1.Car
+	19, // 29: inventory.v1.CarService.DeleteCar:output_type -> google.protobuf.Empty
+	1,  // 30: inventory.v1.EngineService.GetEngine:output_type -> inventory.v1.Engine
+	14, // 31: inventory.v1.EngineService.ListEngines:output_type -> inventory.v1.ListEnginesResponse
+	1,  // 32: inventory.v1.EngineService.CreateEngine:output_type -> inventory.v1.Engine
+	1,  // 33: inventory.v1.EngineService.UpdateEngine:output_type -> inventory.v1.Engine
+	19, // 34: inventory.v1.EngineService.DeleteEngine:output_type -> google.protobuf.Empty
+	25, // [25:35] is the sub-list for method output_type
+	15, // [15:25] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_inventory_v1_inventory_proto_init() }
+func file_inventory_v1_inventory_proto_init() {
+	if File_inventory_v1_inventory_proto != nil {
+		return
+	}
+	file_inventory_v1_inventory_proto_msgTypes[2].OneofWrappers = []any{}
+	file_inventory_v1_inventory_proto_msgTypes[5].OneofWrappers = []any{}
+	file_inventory_v1_inventory_proto_msgTypes[7].OneofWrappers = []any{}
+	file_inventory_v1_inventory_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_v1_inventory_proto_rawDesc), len(file_inventory_v1_inventory_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_inventory_v1_inventory_proto_goTypes,
+		DependencyIndexes: file_inventory_v1_inventory_proto_depIdxs,
+		MessageInfos:      file_inventory_v1_inventory_proto_msgTypes,
+	}.Build()
+	File_inventory_v1_inventory_proto = out.File
+	file_inventory_v1_inventory_proto_goTypes = nil
+	file_inventory_v1_inventory_proto_depIdxs = nil
+}
@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LikhithMar14/management/models"
+	inventoryv1 "github.com/LikhithMar14/management/proto/inventory/v1"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CarServer struct {
+	inventoryv1.UnimplementedCarServiceServer
+	service service.CarService
+}
+
+func NewCarServer(service service.CarService) *CarServer {
+	return &CarServer{service: service}
+}
+
+func (s *CarServer) GetCar(ctx context.Context, req *inventoryv1.GetCarRequest) (*inventoryv1.Car, error) {
+	car, err := s.service.GetCarByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) ListCars(ctx context.Context, req *inventoryv1.ListCarsRequest) (*inventoryv1.ListCarsResponse, error) {
+	filter := models.CarFilter{
+		Brand:           req.GetBrand(),
+		IsEngine:        req.GetIncludeEngine(),
+		Options:         req.GetOptions(),
+		Condition:       req.GetCondition(),
+		MinOdometerKm:   req.MinOdometerKm,
+		MaxOdometerKm:   req.MaxOdometerKm,
+		MinGrade:        intPtr(req.MinGrade),
+		MaxOwners:       intPtr(req.MaxOwners),
+		AccidentHistory: req.GetAccidentHistory(),
+		Certified:       req.Certified,
+	}
+
+	cars, err := s.service.GetCarsByBrand(ctx, filter, req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventoryv1.ListCarsResponse{Cars: make([]*inventoryv1.Car, len(cars))}
+	for i, car := range cars {
+		resp.Cars[i] = carToProto(car)
+	}
+	return resp, nil
+}
+
+func (s *CarServer) CreateCar(ctx context.Context, req *inventoryv1.CreateCarRequest) (*inventoryv1.Car, error) {
+	input, err := carRequestFromProto(req.GetCar())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	car, err := s.service.CreateCar(ctx, &input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) UpdateCar(ctx context.Context, req *inventoryv1.UpdateCarRequest) (*inventoryv1.Car, error) {
+	input, err := carRequestFromProto(req.GetCar())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	car, err := s.service.UpdateCar(ctx, req.GetId(), &input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) DeleteCar(ctx context.Context, req *inventoryv1.DeleteCarRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteCar(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func carToProto(car models.Car) *inventoryv1.Car {
+	pb := &inventoryv1.Car{
+		Id:                 car.ID.String(),
+		Name:               car.Name,
+		Year:               car.Year,
+		Brand:              car.Brand,
+		BrandId:            car.BrandID.String(),
+		Model:              car.Model,
+		ModelId:            uuidString(car.ModelID),
+		Trim:               car.Trim,
+		TrimId:             uuidString(car.TrimID),
+		LocationId:         uuidString(car.LocationID),
+		Location:           car.Location,
+		InTransit:          car.InTransit,
+		Condition:          car.Condition,
+		OdometerKm:         car.OdometerKm,
+		ConditionGrade:     int32Ptr(car.ConditionGrade),
+		PreviousOwners:     int32(car.PreviousOwners),
+		AccidentHistory:    car.AccidentHistory,
+		RegistrationNumber: car.RegistrationNumber,
+		RegistrationRegion: car.RegistrationRegion,
+		CertifiedPreOwned:  car.CertifiedPreOwned,
+		FuelType:           car.FuelType,
+		Engine:             engineToProto(car.Engine),
+		Price:              car.Price.String(),
+		Currency:           car.Currency,
+		TotalPrice:         car.TotalPrice.String(),
+		CreatedAt:          timestamppb.New(car.CreatedAt),
+		UpdatedAt:          timestamppb.New(car.UpdatedAt),
+	}
+	if car.RegistrationDate != nil {
+		date := car.RegistrationDate.String()
+		pb.RegistrationDate = &date
+	}
+	for _, option := range car.Options {
+		pb.Options = append(pb.Options, &inventoryv1.CarOption{
+			OptionId: option.OptionID.String(),
+			Code:     option.Code,
+			Name:     option.Name,
+			Category: option.Category,
+			Price:    option.Price.String(),
+		})
+	}
+	if converted := car.ConvertedPrice; converted != nil {
+		pb.ConvertedPrice = &inventoryv1.ConvertedPrice{
+			Amount:        converted.Amount.String(),
+			Currency:      converted.Currency,
+			Rate:          converted.Rate.String(),
+			EffectiveDate: converted.EffectiveDate.String(),
+		}
+	}
+	return pb
+}
+
+// carRequestFromProto parses the fields the REST API would have decoded
+// from JSON. Everything else is left to the service's validation.
+func carRequestFromProto(input *inventoryv1.CarInput) (models.CarRequest, error) {
+	req := models.CarRequest{
+		Name:               input.GetName(),
+		Year:               input.GetYear(),
+		Brand:              input.GetBrand(),
+		Model:              input.GetModel(),
+		Trim:               input.GetTrim(),
+		Condition:          input.GetCondition(),
+		OdometerKm:         input.GetOdometerKm(),
+		ConditionGrade:     intPtr(input.ConditionGrade),
+		PreviousOwners:     int(input.GetPreviousOwners()),
+		AccidentHistory:    input.GetAccidentHistory(),
+		RegistrationNumber: input.GetRegistrationNumber(),
+		RegistrationRegion: input.GetRegistrationRegion(),
+		FuelType:           input.GetFuelType(),
+		Engine:             models.Engine{EngineSpec: engineSpecFromProto(input.GetEngine())},
+		Currency:           input.GetCurrency(),
+	}
+
+	if input.LocationId != nil {
+		id, err := uuid.Parse(input.GetLocationId())
+		if err != nil {
+			return models.CarRequest{}, errors.New("location_id must be a UUID")
+		}
+		req.LocationID = &id
+	}
+	if input.GetEngineId() != "" {
+		id, err := uuid.Parse(input.GetEngineId())
+		if err != nil {
+			return models.CarRequest{}, errors.New("engine_id must be a UUID")
+		}
+		req.Engine.EngineID = id
+	}
+	if input.RegistrationDate != nil {
+		date, err := models.ParseDate(input.GetRegistrationDate())
+		if err != nil {
+			return models.CarRequest{}, err
+		}
+		req.RegistrationDate = &date
+	}
+	if input.GetPrice() != "" {
+		price, err := models.ParseMoney(input.GetPrice())
+		if err != nil {
+			return models.CarRequest{}, err
+		}
+		req.Price = price
+	}
+	return req, nil
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func intPtr(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+	v := int(*n)
+	return &v
+}
+
+func int32Ptr(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+	v := int32(*n)
+	return &v
+}
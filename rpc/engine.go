@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/LikhithMar14/management/models"
+	inventoryv1 "github.com/LikhithMar14/management/proto/inventory/v1"
+	"github.com/LikhithMar14/management/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type EngineServer struct {
+	inventoryv1.UnimplementedEngineServiceServer
+	service service.EngineService
+}
+
+func NewEngineServer(service service.EngineService) *EngineServer {
+	return &EngineServer{service: service}
+}
+
+func (s *EngineServer) GetEngine(ctx context.Context, req *inventoryv1.GetEngineRequest) (*inventoryv1.Engine, error) {
+	engine, err := s.service.GetEngineByID(ctx, req.GetEngineId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) ListEngines(ctx context.Context, req *inventoryv1.ListEnginesRequest) (*inventoryv1.ListEnginesResponse, error) {
+	filter := models.EngineFilter{
+		Powertrain:      strings.ToLower(req.GetPowertrain()),
+		MinDisplacement: req.MinDisplacement,
+		MaxDisplacement: req.MaxDisplacement,
+		Cylinders:       req.Cylinders,
+		MinRange:        req.MinRange,
+		MaxRange:        req.MaxRange,
+		Sort:            req.GetSort(),
+		Descending:      req.GetDescending(),
+		Limit:           int(req.GetLimit()),
+		Offset:          int(req.GetOffset()),
+	}
+	if filter.Limit == 0 {
+		filter.Limit = models.DefaultPageLimit
+	}
+
+	page, err := s.service.ListEngines(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventoryv1.ListEnginesResponse{
+		Engines: make([]*inventoryv1.Engine, len(page.Items)),
+		Total:   page.Total,
+		Limit:   int32(page.Limit),
+		Offset:  int32(page.Offset),
+	}
+	for i, engine := range page.Items {
+		resp.Engines[i] = engineToProto(engine)
+	}
+	return resp, nil
+}
+
+func (s *EngineServer) CreateEngine(ctx context.Context, req *inventoryv1.CreateEngineRequest) (*inventoryv1.Engine, error) {
+	engine, err := s.service.CreateEngine(ctx, &models.EngineRequest{EngineSpec: engineSpecFromProto(req.GetEngine())})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) UpdateEngine(ctx context.Context, req *inventoryv1.UpdateEngineRequest) (*inventoryv1.Engine, error) {
+	engine, err := s.service.UpdateEngine(ctx, req.GetEngineId(), &models.EngineRequest{EngineSpec: engineSpecFromProto(req.GetEngine())})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) DeleteEngine(ctx context.Context, req *inventoryv1.DeleteEngineRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteEngine(ctx, req.GetEngineId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func engineToProto(engine models.Engine) *inventoryv1.Engine {
+	pb := &inventoryv1.Engine{
+		EngineId: engine.EngineID.String(),
+		Spec:     engineSpecToProto(engine.EngineSpec),
+	}
+	if !engine.CreatedAt.IsZero() {
+		pb.CreatedAt = timestamppb.New(engine.CreatedAt)
+	}
+	if !engine.UpdatedAt.IsZero() {
+		pb.UpdatedAt = timestamppb.New(engine.UpdatedAt)
+	}
+	return pb
+}
+
+func engineSpecToProto(spec models.EngineSpec) *inventoryv1.EngineSpec {
+	return &inventoryv1.EngineSpec{
+		Powertrain:         spec.Powertrain,
+		Displacement:       spec.Displacement,
+		NumberOfCylinders:  spec.NumberOfCylinders,
+		CarRange:           spec.CarRange,
+		PowerKw:            spec.PowerKW,
+		TorqueNm:           spec.TorqueNm,
+		BatteryCapacityKwh: spec.BatteryCapacityKWh,
+		EmissionsClass:     spec.EmissionsClass,
+		Co2GPerKm:          spec.CO2GPerKm,
+	}
+}
+
+func engineSpecFromProto(spec *inventoryv1.EngineSpec) models.EngineSpec {
+	return models.EngineSpec{
+		Powertrain:         spec.GetPowertrain(),
+		Displacement:       spec.GetDisplacement(),
+		NumberOfCylinders:  spec.GetNumberOfCylinders(),
+		CarRange:           spec.GetCarRange(),
+		PowerKW:            spec.GetPowerKw(),
+		TorqueNm:           spec.GetTorqueNm(),
+		BatteryCapacityKWh: spec.GetBatteryCapacityKwh(),
+		EmissionsClass:     spec.GetEmissionsClass(),
+		CO2GPerKm:          spec.GetCo2GPerKm(),
+	}
+}
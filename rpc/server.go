@@ -0,0 +1,62 @@
+// Package rpc serves the inventory API over gRPC, on top of the same
+// services as the REST handlers.
+package rpc
+
+//go:generate protoc -I ../proto --go_out=../proto --go_opt=paths=source_relative --go-grpc_out=../proto --go-grpc_opt=paths=source_relative inventory/v1/inventory.proto
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/LikhithMar14/management/middleware"
+	"github.com/LikhithMar14/management/models"
+	inventoryv1 "github.com/LikhithMar14/management/proto/inventory/v1"
+	"github.com/LikhithMar14/management/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns a gRPC server with the car and engine services
+// registered behind AuthInterceptor.
+func NewServer(cars service.CarService, engines service.EngineService) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(AuthInterceptor))
+	inventoryv1.RegisterCarServiceServer(server, NewCarServer(cars))
+	inventoryv1.RegisterEngineServiceServer(server, NewEngineServer(engines))
+	return server
+}
+
+// AuthInterceptor authenticates calls with the JWT in their "authorization"
+// metadata, as AuthMiddleware does for HTTP requests.
+func AuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	username, err := middleware.ParseToken(strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	return handler(middleware.WithUsername(ctx, username), req)
+}
+
+// toStatus maps the model sentinel errors to gRPC codes in the manner of
+// handler.WriteError. ErrConflict covers both duplicates and records still
+// in use, so it maps to FailedPrecondition rather than AlreadyExists.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		log.Printf("internal error: %v", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/LikhithMar14/management/models"
+	inventoryv1 "github.com/LikhithMar14/management/proto/inventory/v1"
+	"github.com/LikhithMar14/management/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// notFoundEngines is an engine service that finds nothing.
+type notFoundEngines struct {
+	service.EngineService
+}
+
+func (notFoundEngines) GetEngineByID(ctx context.Context, id string) (models.Engine, error) {
+	return models.Engine{}, fmt.Errorf("engine %s: %w", id, models.ErrNotFound)
+}
+
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(nil, notFoundEngines{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestCallsRequireToken(t *testing.T) {
+	client := inventoryv1.NewEngineServiceClient(dial(t))
+
+	for name, ctx := range map[string]context.Context{
+		"missing": context.Background(),
+		"invalid": metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer not-a-jwt"),
+	} {
+		_, err := client.GetEngine(ctx, &inventoryv1.GetEngineRequest{EngineId: "x"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s token: got %v, want Unauthenticated", name, err)
+		}
+	}
+}
+
+func TestErrorsMapToCodes(t *testing.T) {
+	_, err := NewEngineServer(notFoundEngines{}).GetEngine(context.Background(), &inventoryv1.GetEngineRequest{EngineId: "x"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got %v, want NotFound", err)
+	}
+
+	for err, want := range map[error]codes.Code{
+		models.ValidationError(fmt.Errorf("bad")):    codes.InvalidArgument,
+		fmt.Errorf("engine: %w", models.ErrConflict): codes.FailedPrecondition,
+		fmt.Errorf("boom"):                           codes.Internal,
+	} {
+		if got := status.Code(toStatus(err)); got != want {
+			t.Errorf("%v: got %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestCarRequestFromProto(t *testing.T) {
+	location := "not-a-uuid"
+	if _, err := carRequestFromProto(&inventoryv1.CarInput{LocationId: &location}); err == nil {
+		t.Error("invalid location_id was accepted")
+	}
+	if _, err := carRequestFromProto(&inventoryv1.CarInput{Price: "12.345.6"}); err == nil {
+		t.Error("invalid price was accepted")
+	}
+
+	date := "2021-03-04"
+	req, err := carRequestFromProto(&inventoryv1.CarInput{
+		Name:             "Corolla",
+		Price:            "24999.50",
+		RegistrationDate: &date,
+		EngineId:         "7f1a3c2e-0000-4000-8000-000000000001",
+	})
+	if err != nil {
+		t.Fatalf("carRequestFromProto: %v", err)
+	}
+	if req.Price.String() != "24999.50" || req.RegistrationDate.String() != date || req.Engine.EngineID.String() != "7f1a3c2e-0000-4000-8000-000000000001" {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
@@ -0,0 +1,218 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/LikhithMar14/management/blob"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+	"github.com/google/uuid"
+)
+
+// DefaultURLTTL is how long signed download URLs stay valid.
+const DefaultURLTTL = 15 * time.Minute
+
+// Variants of an attachment that can be downloaded.
+const (
+	VariantContent   = "content"
+	VariantThumbnail = "thumbnail"
+)
+
+type AttachmentService struct {
+	store  store.AttachmentStoreInterface
+	blobs  blob.Storage
+	signer *blob.URLSigner
+	ttl    time.Duration
+}
+
+func NewAttachmentService(store store.AttachmentStoreInterface, blobs blob.Storage, signer *blob.URLSigner, ttl time.Duration) *AttachmentService {
+	return &AttachmentService{
+		store:  store,
+		blobs:  blobs,
+		signer: signer,
+		ttl:    ttl,
+	}
+}
+
+// UploadAttachment stores a file for a car. The content type is sniffed from
+// the bytes rather than trusted from the client, and images get a thumbnail.
+func (s *AttachmentService) UploadAttachment(ctx context.Context, carID, kind, filename string, r io.Reader, uploadedBy string) (models.Attachment, error) {
+	car, err := uuid.Parse(carID)
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("car: %w", models.ErrNotFound)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, models.MaxAttachmentSize+1))
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	attachment := models.Attachment{
+		ID:          uuid.New(),
+		CarID:       car,
+		Kind:        models.NormalizeAttachmentKind(kind),
+		Filename:    cleanFilename(filename),
+		ContentType: sniffContentType(data),
+		SizeBytes:   int64(len(data)),
+		UploadedBy:  uploadedBy,
+	}
+	if err := models.ValidateAttachment(attachment); err != nil {
+		return models.Attachment{}, models.ValidationError(err)
+	}
+	attachment.StorageKey = fmt.Sprintf("cars/%s/%s", car, attachment.ID)
+
+	var thumb []byte
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		width, height, ok := imageSize(data)
+		if !ok {
+			return models.Attachment{}, models.ValidationError(errors.New("image could not be decoded"))
+		}
+		attachment.Width, attachment.Height = &width, &height
+
+		if width*height <= maxThumbnailPixels {
+			if thumb, err = thumbnail(data); err != nil {
+				return models.Attachment{}, models.ValidationError(fmt.Errorf("image could not be decoded: %w", err))
+			}
+			key := attachment.StorageKey + "-thumbnail.jpg"
+			attachment.ThumbnailKey = &key
+		}
+	}
+
+	if err := s.blobs.Put(ctx, attachment.StorageKey, attachment.ContentType, bytes.NewReader(data)); err != nil {
+		return models.Attachment{}, err
+	}
+	if thumb != nil {
+		if err := s.blobs.Put(ctx, *attachment.ThumbnailKey, "image/jpeg", bytes.NewReader(thumb)); err != nil {
+			s.deleteBlobs(attachment)
+			return models.Attachment{}, err
+		}
+	}
+
+	created, err := s.store.CreateAttachment(ctx, &attachment)
+	if err != nil {
+		s.deleteBlobs(attachment)
+		return models.Attachment{}, err
+	}
+	return s.withURLs(created), nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, carID string) ([]models.Attachment, error) {
+	attachments, err := s.store.ListAttachments(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attachments {
+		attachments[i] = s.withURLs(attachments[i])
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, carID, id string) error {
+	deleted, err := s.store.DeleteAttachment(ctx, carID, id)
+	if err != nil {
+		return err
+	}
+	s.deleteBlobs(deleted)
+	return nil
+}
+
+// OpenAttachment checks a signed download URL and opens the blob it points
+// at. The caller must close the returned reader.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, id, variant, signedPath string, query url.Values) (models.Attachment, io.ReadCloser, error) {
+	if err := s.signer.Verify(signedPath, query, time.Now()); err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	attachment, err := s.store.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	key := attachment.StorageKey
+	if variant == VariantThumbnail {
+		if attachment.ThumbnailKey == nil {
+			return models.Attachment{}, nil, fmt.Errorf("thumbnail: %w", models.ErrNotFound)
+		}
+		key = *attachment.ThumbnailKey
+		attachment.ContentType = "image/jpeg"
+	}
+
+	r, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+	return attachment, r, nil
+}
+
+// DownloadPath is the unsigned path an attachment variant is served from.
+func DownloadPath(id uuid.UUID, variant string) string {
+	return "/attachments/" + id.String() + "/" + variant
+}
+
+func (s *AttachmentService) withURLs(attachment models.Attachment) models.Attachment {
+	expires := time.Now().Add(s.ttl).Truncate(time.Second)
+	attachment.DownloadURL = s.signer.Sign(DownloadPath(attachment.ID, VariantContent), expires)
+	if attachment.ThumbnailKey != nil {
+		attachment.ThumbnailURL = s.signer.Sign(DownloadPath(attachment.ID, VariantThumbnail), expires)
+	}
+	attachment.URLExpiresAt = &expires
+	return attachment
+}
+
+// deleteBlobs removes an attachment's files on a best-effort basis; a
+// leftover blob is harmless once nothing references it.
+func (s *AttachmentService) deleteBlobs(attachment models.Attachment) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keys := []string{attachment.StorageKey}
+	if attachment.ThumbnailKey != nil {
+		keys = append(keys, *attachment.ThumbnailKey)
+	}
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil && !errors.Is(err, models.ErrNotFound) {
+			log.Printf("failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// sniffContentType detects the type from the file's leading bytes, dropping
+// parameters such as charset.
+func sniffContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// cleanFilename keeps the base name of an uploaded file without control
+// characters, falling back to "attachment".
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
@@ -0,0 +1,81 @@
+package attachment
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// thumbnailSize bounds the longer side of a thumbnail.
+	thumbnailSize = 320
+	// maxThumbnailPixels skips thumbnails for images too large to decode
+	// comfortably in memory.
+	maxThumbnailPixels = 50_000_000
+)
+
+// imageSize reports the dimensions of an image without decoding it.
+func imageSize(data []byte) (width, height int, ok bool) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
+
+// thumbnail scales an image down to fit a thumbnailSize square and encodes
+// it as JPEG. Each thumbnail pixel averages the source pixels it covers.
+func thumbnail(data []byte) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dw, dh := sw, sh
+	if sw > thumbnailSize || sh > thumbnailSize {
+		if sw >= sh {
+			dw, dh = thumbnailSize, max(1, sh*thumbnailSize/sw)
+		} else {
+			dw, dh = max(1, sw*thumbnailSize/sh), thumbnailSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := bounds.Min.Y+y*sh/dh, bounds.Min.Y+max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := bounds.Min.X+x*sw/dw, bounds.Min.X+max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{uint16(r / n), uint16(g / n), uint16(b / n), uint16(a / n)})
+		}
+	}
+
+	// JPEG has no alpha channel, so composite transparent images onto white.
+	flat := image.NewRGBA(dst.Bounds())
+	for i := 0; i < len(flat.Pix); i += 4 {
+		alpha := uint32(dst.Pix[i+3])
+		for c := 0; c < 3; c++ {
+			flat.Pix[i+c] = uint8((uint32(dst.Pix[i+c])*255 + 255*(255-alpha)) / 255)
+		}
+		flat.Pix[i+3] = 255
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,102 @@
+package brand
+
+import (
+	"context"
+	"strings"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type BrandService struct {
+	store store.BrandStoreInterface
+}
+
+func NewBrandService(store store.BrandStoreInterface) *BrandService {
+	return &BrandService{store: store}
+}
+
+func (s *BrandService) ListBrands(ctx context.Context) ([]models.Brand, error) {
+	return s.store.ListBrands(ctx)
+}
+
+func (s *BrandService) GetBrandByID(ctx context.Context, id string) (models.Brand, error) {
+	return s.store.GetBrandByID(ctx, id)
+}
+
+func (s *BrandService) CreateBrand(ctx context.Context, brand *models.BrandRequest) (models.Brand, error) {
+	models.NormalizeReferenceNames(&brand.Name, &brand.Aliases)
+	if err := models.ValidateBrandRequest(*brand); err != nil {
+		return models.Brand{}, models.ValidationError(err)
+	}
+	return s.store.CreateBrand(ctx, brand)
+}
+
+func (s *BrandService) UpdateBrand(ctx context.Context, id string, brand *models.BrandRequest) (models.Brand, error) {
+	models.NormalizeReferenceNames(&brand.Name, &brand.Aliases)
+	if err := models.ValidateBrandRequest(*brand); err != nil {
+		return models.Brand{}, models.ValidationError(err)
+	}
+	return s.store.UpdateBrand(ctx, id, brand)
+}
+
+func (s *BrandService) DeleteBrand(ctx context.Context, id string) error {
+	return s.store.DeleteBrand(ctx, id)
+}
+
+func (s *BrandService) ListModels(ctx context.Context, brandID string) ([]models.VehicleModel, error) {
+	return s.store.ListModels(ctx, brandID)
+}
+
+func (s *BrandService) GetModelByID(ctx context.Context, brandID, id string) (models.VehicleModel, error) {
+	return s.store.GetModelByID(ctx, brandID, id)
+}
+
+func (s *BrandService) CreateModel(ctx context.Context, brandID string, model *models.VehicleModelRequest) (models.VehicleModel, error) {
+	models.NormalizeReferenceNames(&model.Name, &model.Aliases)
+	if err := models.ValidateVehicleModelRequest(*model); err != nil {
+		return models.VehicleModel{}, models.ValidationError(err)
+	}
+	return s.store.CreateModel(ctx, brandID, model)
+}
+
+func (s *BrandService) UpdateModel(ctx context.Context, brandID, id string, model *models.VehicleModelRequest) (models.VehicleModel, error) {
+	models.NormalizeReferenceNames(&model.Name, &model.Aliases)
+	if err := models.ValidateVehicleModelRequest(*model); err != nil {
+		return models.VehicleModel{}, models.ValidationError(err)
+	}
+	return s.store.UpdateModel(ctx, brandID, id, model)
+}
+
+func (s *BrandService) DeleteModel(ctx context.Context, brandID, id string) error {
+	return s.store.DeleteModel(ctx, brandID, id)
+}
+
+func (s *BrandService) ListTrims(ctx context.Context, brandID, modelID string) ([]models.Trim, error) {
+	return s.store.ListTrims(ctx, brandID, modelID)
+}
+
+func (s *BrandService) CreateTrim(ctx context.Context, brandID, modelID string, trim *models.TrimRequest) (models.Trim, error) {
+	normalizeTrimRequest(trim)
+	if err := models.ValidateTrimRequest(*trim); err != nil {
+		return models.Trim{}, models.ValidationError(err)
+	}
+	return s.store.CreateTrim(ctx, brandID, modelID, trim)
+}
+
+func (s *BrandService) UpdateTrim(ctx context.Context, brandID, modelID, id string, trim *models.TrimRequest) (models.Trim, error) {
+	normalizeTrimRequest(trim)
+	if err := models.ValidateTrimRequest(*trim); err != nil {
+		return models.Trim{}, models.ValidationError(err)
+	}
+	return s.store.UpdateTrim(ctx, brandID, modelID, id, trim)
+}
+
+func (s *BrandService) DeleteTrim(ctx context.Context, brandID, modelID, id string) error {
+	return s.store.DeleteTrim(ctx, brandID, modelID, id)
+}
+
+func normalizeTrimRequest(trim *models.TrimRequest) {
+	trim.Name = strings.TrimSpace(trim.Name)
+	trim.Description = strings.TrimSpace(trim.Description)
+}
@@ -4,19 +4,24 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
 	"github.com/LikhithMar14/management/store"
 )
 
 
 type CarService struct {
 	store store.CarStoreInterface
+	rates service.ExchangeRateService
 }
 
-func NewCarService(store store.CarStoreInterface) *CarService {
+func NewCarService(store store.CarStoreInterface, rates service.ExchangeRateService) *CarService {
 	return &CarService {
 		store: store,
+		rates: rates,
 	}
 }
 
@@ -29,20 +34,103 @@ func (s *CarService) GetCarByID(ctx context.Context, id string) (models.Car, err
 	return car, nil
 }
 
-func (s *CarService) GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
-	cars, err := s.store.GetCarsByBrand(ctx, brand, isEngine)
-	log.Print("Brand name in car service: " ,brand)
+func (s *CarService) GetCarsByBrand(ctx context.Context, filter models.CarFilter, currency string) ([]models.Car, error) {
+	for i := range filter.Options {
+		filter.Options[i] = models.NormalizeOptionCode(filter.Options[i])
+	}
+	filter.Condition = strings.ToLower(strings.TrimSpace(filter.Condition))
+	filter.AccidentHistory = strings.ToLower(strings.TrimSpace(filter.AccidentHistory))
+	if err := models.ValidateCarFilter(filter); err != nil {
+		return []models.Car{}, models.ValidationError(err)
+	}
+	cars, err := s.store.GetCarsByBrand(ctx, filter)
+	log.Print("Brand name in car service: " ,filter.Brand)
 	log.Print(cars)
 	log.Print(err)
 	if err != nil {
 		return []models.Car{}, err
 	}
+	if currency != "" {
+		if err := s.convertPrices(ctx, cars, models.NormalizeCurrency(currency)); err != nil {
+			return []models.Car{}, err
+		}
+	}
 	return cars, nil
 }
 
+// CompareCars lines the cars with the given ids up side by side, in the
+// order asked for. Prices are compared in currency, or in the first car's
+// currency when none is given.
+func (s *CarService) CompareCars(ctx context.Context, ids []string, currency string) (models.CarComparison, error) {
+	for i := range ids {
+		ids[i] = strings.ToLower(strings.TrimSpace(ids[i]))
+	}
+	if err := models.ValidateComparisonIDs(ids); err != nil {
+		return models.CarComparison{}, models.ValidationError(err)
+	}
+
+	found, err := s.store.GetCarsByIDs(ctx, ids)
+	if err != nil {
+		return models.CarComparison{}, err
+	}
+	byID := make(map[string]models.Car, len(found))
+	for _, car := range found {
+		byID[car.ID.String()] = car
+	}
+
+	cars := make([]models.Car, len(ids))
+	for i, id := range ids {
+		car, ok := byID[id]
+		if !ok {
+			return models.CarComparison{}, fmt.Errorf("car %s: %w", id, models.ErrNotFound)
+		}
+		cars[i] = car
+	}
+
+	currency = models.NormalizeCurrency(currency)
+	if currency == "" {
+		currency = cars[0].Currency
+	}
+	if err := s.convertPrices(ctx, cars, currency); err != nil {
+		return models.CarComparison{}, err
+	}
+	return models.NewCarComparison(cars, currency), nil
+}
+
+// convertPrices sets ConvertedPrice on every car using today's rates. Each
+// source currency's rate is looked up once and reused for the other cars.
+func (s *CarService) convertPrices(ctx context.Context, cars []models.Car, currency string) error {
+	if err := models.ValidateCurrency(currency); err != nil {
+		return models.ValidationError(err)
+	}
+
+	now := time.Now()
+	converted := map[string]models.ConvertedPrice{}
+	for i := range cars {
+		price, ok := converted[cars[i].Currency]
+		if ok {
+			amount, err := cars[i].Price.Convert(price.Rate)
+			if err != nil {
+				return models.ValidationError(fmt.Errorf("price of car %s in %s is out of range", cars[i].ID, currency))
+			}
+			price.Amount = amount
+		} else {
+			var err error
+			price, err = s.rates.ConvertPrice(ctx, cars[i].Price, cars[i].Currency, currency, now)
+			if err != nil {
+				return err
+			}
+			converted[cars[i].Currency] = price
+		}
+		cars[i].ConvertedPrice = &price
+	}
+	return nil
+}
+
 func (s *CarService) CreateCar(ctx context.Context, car *models.CarRequest) (models.Car, error) {
+	normalizeCarRequest(car)
 	if err := models.ValidateCarRequest(*car); err != nil {
-		return models.Car{}, err
+		return models.Car{}, models.ValidationError(err)
 	}
 	createdCar, err := s.store.CreateCar(ctx, car)
 	if err != nil {
@@ -53,9 +141,10 @@ func (s *CarService) CreateCar(ctx context.Context, car *models.CarRequest) (mod
 
 func (s *CarService) UpdateCar(ctx context.Context, id string, car *models.CarRequest) (models.Car, error) {
 	fmt.Println("id in service: ",id)
+	normalizeCarRequest(car)
 	if err := models.ValidateCarRequest(*car); err != nil {
 		fmt.Println("ERROR IN VALIDATION: ",err)
-		return models.Car{}, err
+		return models.Car{}, models.ValidationError(err)
 	}
 	
 	fmt.Println("id: ",id)
@@ -73,4 +162,56 @@ func (s *CarService) DeleteCar(ctx context.Context, id string) error {
 		return err
 	}
 	return nil
-}	
\ No newline at end of file
+}
+
+func (s *CarService) GetCarsByEngineID(ctx context.Context, engineID string) ([]models.Car, error) {
+	cars, err := s.store.GetCarsByEngineID(ctx, engineID)
+	if err != nil {
+		return []models.Car{}, err
+	}
+	return cars, nil
+}
+
+func (s *CarService) GetCarsByLocation(ctx context.Context, locationID string) ([]models.Car, error) {
+	return s.store.GetCarsByLocation(ctx, locationID)
+}
+
+func (s *CarService) GetInspection(ctx context.Context, carID string) (models.Inspection, error) {
+	return s.store.GetInspection(ctx, carID)
+}
+
+// SetInspection records a certified pre-owned inspection. The car is
+// certified if every checklist item passed.
+func (s *CarService) SetInspection(ctx context.Context, carID string, inspection *models.InspectionRequest, inspector string) (models.Inspection, error) {
+	for i := range inspection.Items {
+		inspection.Items[i].Item = strings.ToLower(strings.TrimSpace(inspection.Items[i].Item))
+		inspection.Items[i].Notes = strings.TrimSpace(inspection.Items[i].Notes)
+	}
+	if err := models.ValidateInspectionRequest(*inspection, time.Now()); err != nil {
+		return models.Inspection{}, models.ValidationError(err)
+	}
+	return s.store.SetInspection(ctx, carID, inspection, inspector)
+}
+
+func (s *CarService) DeleteInspection(ctx context.Context, carID string) error {
+	return s.store.DeleteInspection(ctx, carID)
+}
+
+func normalizeCarRequest(car *models.CarRequest) {
+	car.Brand = strings.TrimSpace(car.Brand)
+	car.Model = strings.TrimSpace(car.Model)
+	car.Trim = strings.TrimSpace(car.Trim)
+	car.FuelType = models.NormalizeFuelType(car.FuelType)
+	car.Currency = models.NormalizeCurrency(car.Currency)
+	car.Condition = strings.ToLower(strings.TrimSpace(car.Condition))
+	if car.Condition == "" {
+		car.Condition = models.ConditionNew
+	}
+	car.AccidentHistory = strings.ToLower(strings.TrimSpace(car.AccidentHistory))
+	if car.AccidentHistory == "" {
+		car.AccidentHistory = models.AccidentNone
+	}
+	car.RegistrationNumber = models.NormalizeRegistrationNumber(car.RegistrationNumber)
+	car.RegistrationRegion = strings.TrimSpace(car.RegistrationRegion)
+	models.NormalizeEngineSpec(&car.Engine.EngineSpec, models.PowertrainForFuelType(car.FuelType))
+}
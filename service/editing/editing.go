@@ -0,0 +1,286 @@
+package editing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+	"github.com/google/uuid"
+)
+
+// DefaultLockTTL is how long a lock lasts unless its holder renews it by
+// locking again.
+const DefaultLockTTL = 2 * time.Minute
+
+// presenceTTL is how long a car stays open for a connection that has
+// stopped renewing it, such as one on a server that crashed.
+const presenceTTL = 45 * time.Second
+
+// maxSubscriptions is how many cars one connection may have open.
+const maxSubscriptions = 20
+
+// sessionBuffer is how many messages a session may fall behind by before it
+// is disconnected.
+const sessionBuffer = 32
+
+// EditingService tracks who has cars open for editing and who holds their
+// soft locks. The state is kept in the database so every server sees it;
+// each server sends changes to its own connections as they are announced.
+type EditingService struct {
+	store store.EditingStoreInterface
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*session
+	cars     map[uuid.UUID]map[*session]struct{}
+}
+
+type session struct {
+	id       uuid.UUID
+	username string
+	cars     map[uuid.UUID]struct{}
+	messages chan models.EditingMessage
+}
+
+func NewEditingService(store store.EditingStoreInterface) *EditingService {
+	return &EditingService{
+		store:    store,
+		sessions: make(map[uuid.UUID]*session),
+		cars:     make(map[uuid.UUID]map[*session]struct{}),
+	}
+}
+
+// Connect starts a session for a user's connection.
+func (s *EditingService) Connect(username string) models.EditingSession {
+	sess := &session{
+		id:       uuid.New(),
+		username: username,
+		cars:     make(map[uuid.UUID]struct{}),
+		messages: make(chan models.EditingMessage, sessionBuffer),
+	}
+
+	s.mu.Lock()
+	s.sessions[sess.id] = sess
+	s.mu.Unlock()
+
+	return models.EditingSession{ID: sess.id, Username: username, Messages: sess.messages}
+}
+
+// Disconnect ends a session, closing its cars and releasing its locks.
+func (s *EditingService) Disconnect(ctx context.Context, sessionID uuid.UUID) {
+	s.mu.Lock()
+	if sess, ok := s.sessions[sessionID]; ok {
+		s.drop(sess)
+	}
+	s.mu.Unlock()
+
+	if err := s.store.LeaveAll(ctx, sessionID); err != nil {
+		log.Printf("editing: leave session %s: %v", sessionID, err)
+	}
+}
+
+// Shutdown ends every session on this server so it can stop without
+// waiting on open connections. Their cars close as the connections do.
+func (s *EditingService) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sess := range s.sessions {
+		s.drop(sess)
+	}
+}
+
+// drop forgets a session and closes its messages. s.mu must be held.
+func (s *EditingService) drop(sess *session) {
+	for carID := range sess.cars {
+		s.removeSubscriber(carID, sess)
+	}
+	delete(s.sessions, sess.id)
+	close(sess.messages)
+}
+
+// removeSubscriber stops sending a car's changes to a session. s.mu must be
+// held.
+func (s *EditingService) removeSubscriber(carID uuid.UUID, sess *session) {
+	delete(sess.cars, carID)
+	delete(s.cars[carID], sess)
+	if len(s.cars[carID]) == 0 {
+		delete(s.cars, carID)
+	}
+}
+
+// session returns a connected session. s.mu must be held.
+func (s *EditingService) session(sessionID uuid.UUID) (*session, error) {
+	sess, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session: %w", models.ErrNotFound)
+	}
+	return sess, nil
+}
+
+// Subscribe opens a car for a session. The session, and everyone else with
+// the car open, is then sent its presence.
+func (s *EditingService) Subscribe(ctx context.Context, sessionID, carID uuid.UUID) error {
+	s.mu.Lock()
+	sess, err := s.session(sessionID)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	_, subscribed := sess.cars[carID]
+	if !subscribed {
+		if len(sess.cars) >= maxSubscriptions {
+			s.mu.Unlock()
+			return models.ValidationError(fmt.Errorf("at most %d cars can be open at once", maxSubscriptions))
+		}
+		sess.cars[carID] = struct{}{}
+		if s.cars[carID] == nil {
+			s.cars[carID] = make(map[*session]struct{})
+		}
+		s.cars[carID][sess] = struct{}{}
+	}
+	username := sess.username
+	s.mu.Unlock()
+
+	err = s.store.Join(ctx, sessionID, carID, username, presenceTTL)
+	if err != nil && !subscribed {
+		s.mu.Lock()
+		if _, ok := s.sessions[sessionID]; ok {
+			s.removeSubscriber(carID, sess)
+		}
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// Unsubscribe closes a car for a session, releasing its lock if the session
+// holds it.
+func (s *EditingService) Unsubscribe(ctx context.Context, sessionID, carID uuid.UUID) error {
+	s.mu.Lock()
+	sess, err := s.session(sessionID)
+	if err == nil {
+		s.removeSubscriber(carID, sess)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.store.Leave(ctx, sessionID, carID)
+}
+
+// Lock takes or renews the soft lock on a car the session has open. When
+// someone else holds it, the lock they hold is returned with false.
+func (s *EditingService) Lock(ctx context.Context, sessionID, carID uuid.UUID) (models.CarEditLock, bool, error) {
+	s.mu.Lock()
+	sess, err := s.session(sessionID)
+	if err != nil {
+		s.mu.Unlock()
+		return models.CarEditLock{}, false, err
+	}
+	_, subscribed := sess.cars[carID]
+	username := sess.username
+	s.mu.Unlock()
+
+	if !subscribed {
+		return models.CarEditLock{}, false, models.ValidationError(errors.New("subscribe to the car before locking it"))
+	}
+	return s.store.AcquireLock(ctx, sessionID, carID, username, DefaultLockTTL)
+}
+
+// Unlock releases the lock on a car if the session holds it.
+func (s *EditingService) Unlock(ctx context.Context, sessionID, carID uuid.UUID) error {
+	s.mu.Lock()
+	_, err := s.session(sessionID)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.store.ReleaseLock(ctx, sessionID, carID)
+}
+
+// send sends a message to everyone on this server with the car open. A
+// session whose buffer is full is disconnected rather than allowed to hold
+// up the others. s.mu must be held.
+func (s *EditingService) send(carID uuid.UUID, message models.EditingMessage) {
+	for sess := range s.cars[carID] {
+		select {
+		case sess.messages <- message:
+		default:
+			s.drop(sess)
+		}
+	}
+}
+
+// refresh sends the current presence of a car to everyone on this server
+// with it open.
+func (s *EditingService) refresh(ctx context.Context, carID uuid.UUID) {
+	s.mu.Lock()
+	_, open := s.cars[carID]
+	s.mu.Unlock()
+	if !open {
+		return
+	}
+
+	presence, err := s.store.GetPresence(ctx, carID)
+	if err != nil {
+		log.Printf("editing: load presence of car %s: %v", carID, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.send(carID, models.EditingMessage{Type: models.EditingPresence, CarID: &carID, Presence: &presence})
+}
+
+// handleEvent sends a saved car to everyone editing it, and tells them when
+// it is deleted.
+func (s *EditingService) handleEvent(event models.StreamEvent) {
+	if event.AggregateType != models.AggregateCar {
+		return
+	}
+	carID := event.AggregateID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch event.Type {
+	case models.EventCarUpdated:
+		s.send(carID, models.EditingMessage{
+			Type: models.EditingCarUpdated, CarID: &carID, EventID: event.ID, Car: event.Payload,
+		})
+	case models.EventCarDeleted:
+		s.send(carID, models.EditingMessage{Type: models.EditingCarDeleted, CarID: &carID, EventID: event.ID})
+		// The car's editors and lock were deleted with it
+		for sess := range s.cars[carID] {
+			s.removeSubscriber(carID, sess)
+		}
+	}
+}
+
+// sessionIDs returns the sessions connected to this server.
+func (s *EditingService) sessionIDs() []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// openCars returns the cars open on this server.
+func (s *EditingService) openCars() []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(s.cars))
+	for id := range s.cars {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,117 @@
+package editing
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/google/uuid"
+)
+
+// heartbeatInterval is how often open cars are renewed and expired editors
+// and locks are cleared. It is well inside presenceTTL so that a missed
+// beat does not close anyone's car.
+const heartbeatInterval = 15 * time.Second
+
+// Listener reconnects back off from firstReconnectDelay to maxReconnectDelay.
+const (
+	firstReconnectDelay = time.Second
+	maxReconnectDelay   = 30 * time.Second
+)
+
+// StartListener sends presence to this server's connections whenever a
+// car's editors or lock change on any server, until ctx is cancelled. It
+// reconnects with backoff when the connection drops, and once listening
+// again sends the presence of every open car, in case changes were missed.
+func (s *EditingService) StartListener(ctx context.Context) {
+	go func() {
+		delay := firstReconnectDelay
+		connected := false
+		for {
+			ready := func() {
+				if connected {
+					for _, carID := range s.openCars() {
+						s.refresh(ctx, carID)
+					}
+				}
+				connected = true
+				delay = firstReconnectDelay
+			}
+			err := s.store.Listen(ctx, ready, func(payload string) {
+				carID, err := uuid.Parse(payload)
+				if err != nil {
+					log.Printf("editing: invalid notification %q", payload)
+					return
+				}
+				s.refresh(ctx, carID)
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("editing: listener stopped, retrying in %s: %v", delay, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}()
+}
+
+// StartHeartbeat keeps the cars open on this server from expiring and
+// clears the editors and locks that have, until ctx is cancelled.
+func (s *EditingService) StartHeartbeat(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.store.Touch(ctx, s.sessionIDs(), presenceTTL); err != nil {
+				log.Printf("editing: renew open cars: %v", err)
+			}
+			if _, err := s.store.PurgeExpired(ctx); err != nil {
+				log.Printf("editing: purge expired: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StartEventFeed sends saved and deleted cars from the event stream to the
+// connections editing them, until ctx is cancelled. The stream ends a
+// subscription that falls behind, so the feed subscribes again.
+func (s *EditingService) StartEventFeed(ctx context.Context, stream service.EventStreamService) {
+	go func() {
+		for {
+			subscription := stream.Subscribe(models.StreamFilter{}, nil)
+			s.feed(ctx, subscription)
+			subscription.Close()
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+}
+
+func (s *EditingService) feed(ctx context.Context, subscription models.StreamSubscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			s.handleEvent(event)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 
@@ -26,9 +27,22 @@ func (s *EngineService) GetEngineByID(ctx context.Context, id string) (models.En
 	return engine, nil
 }
 
+// GetEnginesByIDs loads several engines at once. IDs that are not UUIDs
+// cannot match an engine and are skipped rather than failing the batch.
+func (s *EngineService) GetEnginesByIDs(ctx context.Context, ids []string) ([]models.Engine, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	return s.store.GetEnginesByIDs(ctx, valid)
+}
+
 func (s *EngineService) CreateEngine(ctx context.Context, engine *models.EngineRequest) (models.Engine, error) {
+	models.NormalizeEngineSpec(&engine.EngineSpec, models.PowertrainICE)
 	if err := models.ValidateEngineRequest(*engine); err != nil {
-		return models.Engine{}, err
+		return models.Engine{}, models.ValidationError(err)
 	}
 
 	createdEngine, err := s.store.CreateEngine(ctx, engine)
@@ -40,8 +54,9 @@ func (s *EngineService) CreateEngine(ctx context.Context, engine *models.EngineR
 }
 
 func (s *EngineService) UpdateEngine(ctx context.Context, id string, engine *models.EngineRequest) (models.Engine, error) {
+	models.NormalizeEngineSpec(&engine.EngineSpec, models.PowertrainICE)
 	if err := models.ValidateEngineRequest(*engine); err != nil {
-		return models.Engine{}, err
+		return models.Engine{}, models.ValidationError(err)
 	}
 
 	updatedEngine, err := s.store.UpdateEngine(ctx, id, engine)
@@ -59,7 +74,7 @@ func (s *EngineService) DeleteEngine(ctx context.Context, id string) error {
 	}
 
 	if engine.EngineID == uuid.Nil {
-		return errors.New("engine not found")
+		return fmt.Errorf("engine: %w", models.ErrNotFound)
 	}
 
 	err = s.store.DeleteEngine(ctx, id)
@@ -67,4 +82,21 @@ func (s *EngineService) DeleteEngine(ctx context.Context, id string) error {
 		return err
 	}
 	return nil
-}	
+}
+
+func (s *EngineService) ListEngines(ctx context.Context, filter models.EngineFilter) (models.Page[models.Engine], error) {
+	if filter.Sort == models.EngineSortCarCount {
+		return models.Page[models.Engine]{}, models.ValidationError(errors.New("sort by car_count is only available for engine usage"))
+	}
+	if err := models.ValidateEngineFilter(filter); err != nil {
+		return models.Page[models.Engine]{}, models.ValidationError(err)
+	}
+	return s.store.ListEngines(ctx, filter)
+}
+
+func (s *EngineService) GetEngineUsage(ctx context.Context, filter models.EngineFilter) (models.Page[models.EngineUsage], error) {
+	if err := models.ValidateEngineFilter(filter); err != nil {
+		return models.Page[models.EngineUsage]{}, models.ValidationError(err)
+	}
+	return s.store.GetEngineUsage(ctx, filter)
+}
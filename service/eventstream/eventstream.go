@@ -0,0 +1,204 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+// ReplaySize is how many recent events are kept for clients resuming with
+// Last-Event-ID.
+const ReplaySize = 1000
+
+// clientBuffer is how many events a client may fall behind by before it is
+// disconnected to catch up from the replay buffer.
+const clientBuffer = 64
+
+// Listener reconnects back off from firstReconnectDelay to maxReconnectDelay.
+const (
+	firstReconnectDelay = time.Second
+	maxReconnectDelay   = 30 * time.Second
+)
+
+// EventStreamService fans relayed events out to the clients of this server.
+// Every server listens for the events announced by any relay, so all of
+// them stream the same events, in roughly the same order.
+type EventStreamService struct {
+	store store.EventStreamStoreInterface
+
+	mu      sync.Mutex
+	replay  []models.StreamEvent
+	seen    map[int64]struct{}
+	clients map[*client]struct{}
+}
+
+type client struct {
+	filter models.StreamFilter
+	events chan models.StreamEvent
+}
+
+func NewEventStreamService(store store.EventStreamStoreInterface) *EventStreamService {
+	return &EventStreamService{
+		store:   store,
+		seen:    make(map[int64]struct{}),
+		clients: make(map[*client]struct{}),
+	}
+}
+
+// Subscribe starts a client's stream. With lastEventID the client resumes
+// after that event if it is still buffered, and is told to reset otherwise.
+func (s *EventStreamService) Subscribe(filter models.StreamFilter, lastEventID *int64) models.StreamSubscription {
+	c := &client{filter: filter, events: make(chan models.StreamEvent, clientBuffer)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription := models.StreamSubscription{Events: c.events}
+	if len(s.replay) > 0 {
+		subscription.LatestEventID = s.replay[len(s.replay)-1].ID
+	}
+	if lastEventID != nil {
+		if _, ok := s.seen[*lastEventID]; !ok {
+			subscription.Reset = true
+		} else {
+			for i, event := range s.replay {
+				if event.ID != *lastEventID {
+					continue
+				}
+				for _, event := range s.replay[i+1:] {
+					if filter.Matches(event) {
+						subscription.Replay = append(subscription.Replay, event)
+					}
+				}
+				break
+			}
+		}
+	}
+
+	s.clients[c] = struct{}{}
+	subscription.Close = func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.disconnect(c)
+	}
+	return subscription
+}
+
+// disconnect removes a client and closes its channel. s.mu must be held.
+func (s *EventStreamService) disconnect(c *client) {
+	if _, ok := s.clients[c]; ok {
+		delete(s.clients, c)
+		close(c.events)
+	}
+}
+
+// broadcast buffers an event and sends it to the clients it matches. An
+// event relayed twice is only sent once. A client whose buffer is full is
+// disconnected rather than allowed to hold up the others.
+func (s *EventStreamService) broadcast(event models.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.seen[event.ID]; ok {
+		return
+	}
+	if len(s.replay) == ReplaySize {
+		delete(s.seen, s.replay[0].ID)
+		s.replay = s.replay[1:]
+	}
+	s.replay = append(s.replay, event)
+	s.seen[event.ID] = struct{}{}
+
+	for c := range s.clients {
+		if !c.filter.Matches(event) {
+			continue
+		}
+		select {
+		case c.events <- event:
+		default:
+			s.disconnect(c)
+		}
+	}
+}
+
+// reset forgets the buffered events and disconnects every client. It is
+// used when events may have been missed, so that clients reconnect, find
+// their last event gone and reload.
+func (s *EventStreamService) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replay = nil
+	s.seen = make(map[int64]struct{})
+	for c := range s.clients {
+		s.disconnect(c)
+	}
+}
+
+// Shutdown disconnects every client so the server can stop without waiting
+// on open streams. Clients reconnect to another server and resume there.
+func (s *EventStreamService) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.clients {
+		s.disconnect(c)
+	}
+}
+
+func (s *EventStreamService) handleNotification(ctx context.Context, payload string) {
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		log.Printf("event stream: invalid notification %q", payload)
+		return
+	}
+	event, err := s.store.GetStreamEvent(ctx, id)
+	if errors.Is(err, models.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		log.Printf("event stream: load event %d: %v", id, err)
+		return
+	}
+	s.broadcast(event)
+}
+
+// StartListener listens for announced events until ctx is cancelled,
+// reconnecting with backoff when the connection drops. Notifications sent
+// while it was not listening are lost, so the stream is reset once it
+// listens again.
+func (s *EventStreamService) StartListener(ctx context.Context) {
+	go func() {
+		delay := firstReconnectDelay
+		connected := false
+		for {
+			ready := func() {
+				if connected {
+					s.reset()
+				}
+				connected = true
+				delay = firstReconnectDelay
+			}
+			err := s.store.Listen(ctx, ready, func(payload string) {
+				s.handleNotification(ctx, payload)
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("event stream: listener stopped, retrying in %s: %v", delay, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}()
+}
@@ -0,0 +1,119 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type ExchangeRateService struct {
+	store store.ExchangeRateStoreInterface
+}
+
+func NewExchangeRateService(store store.ExchangeRateStoreInterface) *ExchangeRateService {
+	return &ExchangeRateService{store: store}
+}
+
+func (s *ExchangeRateService) ListExchangeRates(ctx context.Context) ([]models.ExchangeRate, error) {
+	return s.store.ListExchangeRates(ctx)
+}
+
+func (s *ExchangeRateService) SetExchangeRates(ctx context.Context, rates []models.ExchangeRate) ([]models.ExchangeRate, error) {
+	if len(rates) == 0 {
+		return nil, models.ValidationError(errors.New("at least one rate is required"))
+	}
+	for i := range rates {
+		rates[i].BaseCurrency = models.NormalizeCurrency(rates[i].BaseCurrency)
+		rates[i].QuoteCurrency = models.NormalizeCurrency(rates[i].QuoteCurrency)
+		if err := models.ValidateExchangeRate(rates[i]); err != nil {
+			return nil, models.ValidationError(fmt.Errorf("rate %d: %w", i+1, err))
+		}
+	}
+	return s.store.UpsertExchangeRates(ctx, rates)
+}
+
+// ImportExchangeRates reads CSV rows of base,quote,rate,effective_date. A
+// leading header row is skipped.
+func (s *ExchangeRateService) ImportExchangeRates(ctx context.Context, r io.Reader) ([]models.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	var rates []models.ExchangeRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, models.ValidationError(err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "base_currency") {
+			continue
+		}
+
+		rate, err := models.ParseRate(record[2])
+		if err != nil {
+			return nil, models.ValidationError(fmt.Errorf("line %d: %w", line, err))
+		}
+		date, err := models.ParseDate(record[3])
+		if err != nil {
+			return nil, models.ValidationError(fmt.Errorf("line %d: %w", line, err))
+		}
+		rates = append(rates, models.ExchangeRate{
+			BaseCurrency:  record[0],
+			QuoteCurrency: record[1],
+			Rate:          rate,
+			EffectiveDate: date,
+		})
+	}
+	return s.SetExchangeRates(ctx, rates)
+}
+
+// ConvertPrice converts price from one currency to another using the rate in
+// effect on asOf. When only the opposite pair is stored its inverse is used.
+func (s *ExchangeRateService) ConvertPrice(ctx context.Context, price models.Money, from, to string, asOf time.Time) (models.ConvertedPrice, error) {
+	if from == to {
+		return models.ConvertedPrice{
+			Amount:        price,
+			Currency:      to,
+			Rate:          models.RateOne,
+			EffectiveDate: models.Date{Time: asOf},
+		}, nil
+	}
+
+	rate, err := s.store.GetExchangeRate(ctx, from, to, asOf)
+	if errors.Is(err, models.ErrNotFound) {
+		rate, err = s.store.GetExchangeRate(ctx, to, from, asOf)
+		if err == nil {
+			if rate.Rate, err = rate.Rate.Inverse(); err != nil {
+				err = fmt.Errorf("exchange rate from %s to %s cannot be inverted", to, from)
+			}
+		}
+	}
+	if errors.Is(err, models.ErrNotFound) {
+		return models.ConvertedPrice{}, fmt.Errorf("no exchange rate from %s to %s: %w", from, to, models.ErrNotFound)
+	}
+	if err != nil {
+		return models.ConvertedPrice{}, err
+	}
+
+	amount, err := price.Convert(rate.Rate)
+	if err != nil {
+		return models.ConvertedPrice{}, models.ValidationError(fmt.Errorf("price in %s is out of range", to))
+	}
+
+	return models.ConvertedPrice{
+		Amount:        amount,
+		Currency:      to,
+		Rate:          rate.Rate,
+		EffectiveDate: rate.EffectiveDate,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package location
+
+import (
+	"context"
+	"strings"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type LocationService struct {
+	store store.LocationStoreInterface
+}
+
+func NewLocationService(store store.LocationStoreInterface) *LocationService {
+	return &LocationService{store: store}
+}
+
+func (s *LocationService) ListLocations(ctx context.Context) ([]models.Location, error) {
+	return s.store.ListLocations(ctx)
+}
+
+func (s *LocationService) GetLocationByID(ctx context.Context, id string) (models.Location, error) {
+	return s.store.GetLocationByID(ctx, id)
+}
+
+func (s *LocationService) CreateLocation(ctx context.Context, location *models.LocationRequest) (models.Location, error) {
+	normalizeLocationRequest(location)
+	if err := models.ValidateLocationRequest(*location); err != nil {
+		return models.Location{}, models.ValidationError(err)
+	}
+	return s.store.CreateLocation(ctx, location)
+}
+
+func (s *LocationService) UpdateLocation(ctx context.Context, id string, location *models.LocationRequest) (models.Location, error) {
+	normalizeLocationRequest(location)
+	if err := models.ValidateLocationRequest(*location); err != nil {
+		return models.Location{}, models.ValidationError(err)
+	}
+	return s.store.UpdateLocation(ctx, id, location)
+}
+
+func (s *LocationService) DeleteLocation(ctx context.Context, id string) error {
+	return s.store.DeleteLocation(ctx, id)
+}
+
+func (s *LocationService) ListTransfers(ctx context.Context, filter models.TransferFilter) (models.Page[models.Transfer], error) {
+	if err := models.ValidateTransferFilter(filter); err != nil {
+		return models.Page[models.Transfer]{}, models.ValidationError(err)
+	}
+	return s.store.ListTransfers(ctx, filter)
+}
+
+func (s *LocationService) GetTransferByID(ctx context.Context, id string) (models.Transfer, error) {
+	return s.store.GetTransferByID(ctx, id)
+}
+
+func (s *LocationService) CreateTransfer(ctx context.Context, transfer *models.TransferRequest, requestedBy string) (models.Transfer, error) {
+	transfer.Notes = strings.TrimSpace(transfer.Notes)
+	if err := models.ValidateTransferRequest(*transfer); err != nil {
+		return models.Transfer{}, models.ValidationError(err)
+	}
+	return s.store.CreateTransfer(ctx, transfer, requestedBy)
+}
+
+func (s *LocationService) ReceiveTransfer(ctx context.Context, id, receivedBy string) (models.Transfer, error) {
+	return s.store.ReceiveTransfer(ctx, id, receivedBy)
+}
+
+func (s *LocationService) CancelTransfer(ctx context.Context, id, cancelledBy string) (models.Transfer, error) {
+	return s.store.CancelTransfer(ctx, id, cancelledBy)
+}
+
+func normalizeLocationRequest(location *models.LocationRequest) {
+	location.Code = models.NormalizeLocationCode(location.Code)
+	location.Name = strings.TrimSpace(location.Name)
+	location.Kind = strings.ToLower(strings.TrimSpace(location.Kind))
+	location.Address = strings.TrimSpace(location.Address)
+}
@@ -0,0 +1,124 @@
+package option
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/service"
+	"github.com/LikhithMar14/management/store"
+	"github.com/google/uuid"
+)
+
+type OptionService struct {
+	store store.OptionStoreInterface
+	rates service.ExchangeRateService
+}
+
+func NewOptionService(store store.OptionStoreInterface, rates service.ExchangeRateService) *OptionService {
+	return &OptionService{
+		store: store,
+		rates: rates,
+	}
+}
+
+func (s *OptionService) ListOptions(ctx context.Context, category string) ([]models.Option, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category != "" && !slices.Contains(models.OptionCategories, category) {
+		return nil, models.ValidationError(errors.New("category must be one of: " + strings.Join(models.OptionCategories, ", ")))
+	}
+	return s.store.ListOptions(ctx, category)
+}
+
+func (s *OptionService) GetOptionByID(ctx context.Context, id string) (models.Option, error) {
+	return s.store.GetOptionByID(ctx, id)
+}
+
+func (s *OptionService) CreateOption(ctx context.Context, option *models.OptionRequest) (models.Option, error) {
+	normalizeOptionRequest(option)
+	if err := models.ValidateOptionRequest(*option); err != nil {
+		return models.Option{}, models.ValidationError(err)
+	}
+	return s.store.CreateOption(ctx, option)
+}
+
+func (s *OptionService) UpdateOption(ctx context.Context, id string, option *models.OptionRequest) (models.Option, error) {
+	normalizeOptionRequest(option)
+	if err := models.ValidateOptionRequest(*option); err != nil {
+		return models.Option{}, models.ValidationError(err)
+	}
+	return s.store.UpdateOption(ctx, id, option)
+}
+
+func (s *OptionService) DeleteOption(ctx context.Context, id string) error {
+	return s.store.DeleteOption(ctx, id)
+}
+
+func (s *OptionService) GetCarOptions(ctx context.Context, carID string) (models.CarOptions, error) {
+	return s.store.GetCarOptions(ctx, carID)
+}
+
+// SetCarOptions fits exactly the requested options to a car. Options priced
+// in another currency than the car are converted at today's rate.
+func (s *OptionService) SetCarOptions(ctx context.Context, carID string, req *models.CarOptionsRequest) (models.CarOptions, error) {
+	current, err := s.store.GetCarOptions(ctx, carID)
+	if err != nil {
+		return models.CarOptions{}, err
+	}
+
+	options := make([]models.Option, 0, len(req.OptionIDs))
+	for _, id := range req.OptionIDs {
+		option, err := s.store.GetOptionByID(ctx, id.String())
+		if errors.Is(err, models.ErrNotFound) {
+			return models.CarOptions{}, models.ValidationError(fmt.Errorf("option %s does not exist", id))
+		}
+		if err != nil {
+			return models.CarOptions{}, err
+		}
+		options = append(options, option)
+	}
+	if err := models.ValidateOptionSelection(options); err != nil {
+		return models.CarOptions{}, models.ValidationError(err)
+	}
+
+	now := time.Now()
+	fitted := make([]models.CarOption, 0, len(options))
+	for _, option := range options {
+		price := option.Price
+		if option.Currency != current.Currency {
+			converted, err := s.rates.ConvertPrice(ctx, option.Price, option.Currency, current.Currency, now)
+			if err != nil {
+				return models.CarOptions{}, err
+			}
+			price = converted.Amount
+		}
+		fitted = append(fitted, models.CarOption{
+			OptionID: option.ID,
+			Code:     option.Code,
+			Name:     option.Name,
+			Category: option.Category,
+			Price:    price,
+		})
+	}
+
+	return s.store.SetCarOptions(ctx, carID, current.Currency, fitted)
+}
+
+func normalizeOptionRequest(option *models.OptionRequest) {
+	option.Code = models.NormalizeOptionCode(option.Code)
+	option.Name = strings.TrimSpace(option.Name)
+	option.Category = strings.ToLower(strings.TrimSpace(option.Category))
+	option.Currency = models.NormalizeCurrency(option.Currency)
+
+	incompatible := []uuid.UUID{}
+	for _, id := range option.IncompatibleWith {
+		if !slices.Contains(incompatible, id) {
+			incompatible = append(incompatible, id)
+		}
+	}
+	option.IncompatibleWith = incompatible
+}
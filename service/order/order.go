@@ -0,0 +1,74 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type OrderService struct {
+	store    store.OrderStoreInterface
+	carStore store.CarStoreInterface
+}
+
+func NewOrderService(store store.OrderStoreInterface, carStore store.CarStoreInterface) *OrderService {
+	return &OrderService{
+		store:    store,
+		carStore: carStore,
+	}
+}
+
+func (s *OrderService) GetOrderByID(ctx context.Context, id string) (models.Order, error) {
+	order, err := s.store.GetOrderByID(ctx, id)
+	if err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+// CreateOrder records the sale of a car. When no sale price is given the
+// car's list price is used.
+func (s *OrderService) CreateOrder(ctx context.Context, order *models.OrderRequest, salesperson string) (models.Order, error) {
+	if err := models.ValidateOrderRequest(*order); err != nil {
+		return models.Order{}, models.ValidationError(err)
+	}
+	if salesperson == "" {
+		return models.Order{}, models.ValidationError(errors.New("salesperson is required"))
+	}
+
+	car, err := s.carStore.GetCarByID(ctx, order.CarID.String())
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	newOrder := models.Order{
+		CarID:         order.CarID,
+		Buyer:         order.Buyer,
+		SalePrice:     order.SalePrice,
+		Discounts:     order.Discounts,
+		Taxes:         order.Taxes,
+		PaymentMethod: order.PaymentMethod,
+		Salesperson:   salesperson,
+	}
+	if newOrder.SalePrice == 0 {
+		newOrder.SalePrice = car.Price
+	}
+	newOrder.Currency = car.Currency
+	if newOrder.Discounts == nil {
+		newOrder.Discounts = []models.OrderDiscount{}
+	}
+	if newOrder.Taxes == nil {
+		newOrder.Taxes = []models.OrderTax{}
+	}
+	if err := newOrder.CalculateTotals(); err != nil {
+		return models.Order{}, models.ValidationError(err)
+	}
+
+	createdOrder, err := s.store.CreateOrder(ctx, &newOrder)
+	if err != nil {
+		return models.Order{}, err
+	}
+	return createdOrder, nil
+}
@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/LikhithMar14/management/events"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+// relayBatchSize is how many events are claimed at a time.
+const relayBatchSize = 100
+
+// relayLease is how long claimed events are kept from other relays. It
+// covers a batch whose every publish times out.
+const relayLease = relayBatchSize * events.DefaultHTTPTimeout
+
+// PublishedRetention is how long published events are kept before the relay
+// deletes them.
+const PublishedRetention = 7 * 24 * time.Hour
+
+type OutboxService struct {
+	store     store.OutboxStoreInterface
+	publisher events.Publisher
+}
+
+func NewOutboxService(store store.OutboxStoreInterface, publisher events.Publisher) *OutboxService {
+	return &OutboxService{store: store, publisher: publisher}
+}
+
+// DispatchPending hands new events to the sinks inside the database in
+// batches until none are left and returns how many it handed over.
+func (s *OutboxService) DispatchPending(ctx context.Context) (int, error) {
+	dispatched := 0
+	for ctx.Err() == nil {
+		n, err := s.store.DispatchPending(ctx, relayBatchSize)
+		dispatched += n
+		if err != nil || n == 0 {
+			return dispatched, err
+		}
+	}
+	return dispatched, nil
+}
+
+// RelayPending publishes the due events in batches until none are left and
+// returns how many were published. Events that fail are retried by a later
+// run. An aggregate's next event only becomes due once the one before it is
+// published, so it is picked up by the following batch.
+func (s *OutboxService) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+	publish := func(ctx context.Context, event models.Event) error {
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			log.Printf("outbox relay: event %d (%s): %v", event.ID, event.Type, err)
+			return err
+		}
+		published++
+		return nil
+	}
+
+	for ctx.Err() == nil {
+		n, err := s.store.PublishPending(ctx, relayBatchSize, relayLease, publish)
+		if err != nil {
+			return published, err
+		}
+		if n == 0 {
+			break
+		}
+	}
+	return published, nil
+}
+
+// PurgePublished deletes the events published more than PublishedRetention
+// before now.
+func (s *OutboxService) PurgePublished(ctx context.Context, now time.Time) (int64, error) {
+	return s.store.PurgePublished(ctx, now.Add(-PublishedRetention))
+}
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultRelayInterval is how often the relay looks for new events when
+// OUTBOX_RELAY_INTERVAL is not set.
+const DefaultRelayInterval = 5 * time.Second
+
+// StartRelay dispatches and publishes pending events every interval until ctx
+// is cancelled, and deletes old published events as it goes. Dispatching and
+// publishing run in separate loops so that a slow or failing publisher does
+// not hold back the sinks inside the database. Both run once immediately so
+// that events recorded while the server was down are delivered on start.
+func (s *OutboxService) StartRelay(ctx context.Context, interval time.Duration) {
+	every(ctx, interval, func() {
+		n, err := s.DispatchPending(ctx)
+		if err != nil {
+			log.Printf("outbox relay: dispatch: %v", err)
+		} else if n > 0 {
+			log.Printf("outbox relay: dispatched %d event(s)", n)
+		}
+	})
+
+	every(ctx, interval, func() {
+		n, err := s.RelayPending(ctx)
+		if err != nil {
+			log.Printf("outbox relay: %v", err)
+		} else if n > 0 {
+			log.Printf("outbox relay: published %d event(s)", n)
+		}
+		if _, err := s.PurgePublished(ctx, time.Now()); err != nil {
+			log.Printf("outbox relay: purge: %v", err)
+		}
+	})
+}
+
+// every runs fn now and then every interval until ctx is cancelled.
+func every(ctx context.Context, interval time.Duration, fn func()) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			fn()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
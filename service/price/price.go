@@ -0,0 +1,65 @@
+package price
+
+import (
+	"context"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type PriceService struct {
+	store    store.PriceStoreInterface
+	carStore store.CarStoreInterface
+}
+
+func NewPriceService(store store.PriceStoreInterface, carStore store.CarStoreInterface) *PriceService {
+	return &PriceService{
+		store:    store,
+		carStore: carStore,
+	}
+}
+
+func (s *PriceService) GetPriceTimeline(ctx context.Context, carID string) (models.PriceTimeline, error) {
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil {
+		return models.PriceTimeline{}, err
+	}
+
+	history, err := s.store.GetPriceHistory(ctx, carID)
+	if err != nil {
+		return models.PriceTimeline{}, err
+	}
+	scheduled, err := s.store.ListPriceSchedules(ctx, carID)
+	if err != nil {
+		return models.PriceTimeline{}, err
+	}
+
+	return models.PriceTimeline{
+		CarID:     car.ID,
+		History:   history,
+		Scheduled: scheduled,
+	}, nil
+}
+
+func (s *PriceService) SchedulePriceChange(ctx context.Context, carID string, schedule *models.PriceScheduleRequest, createdBy string) (models.PriceSchedule, error) {
+	schedule.Currency = models.NormalizeCurrency(schedule.Currency)
+	if err := models.ValidatePriceScheduleRequest(*schedule, time.Now()); err != nil {
+		return models.PriceSchedule{}, models.ValidationError(err)
+	}
+
+	created, err := s.store.CreatePriceSchedule(ctx, carID, schedule, createdBy)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	return created, nil
+}
+
+func (s *PriceService) CancelPriceSchedule(ctx context.Context, carID, scheduleID string) error {
+	return s.store.CancelPriceSchedule(ctx, carID, scheduleID)
+}
+
+// ApplyDueSchedules starts and ends every schedule due at now.
+func (s *PriceService) ApplyDueSchedules(ctx context.Context, now time.Time) (int, error) {
+	return s.store.ApplyDuePriceSchedules(ctx, now)
+}
@@ -0,0 +1,36 @@
+package price
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultSchedulerInterval is how often the scheduler looks for due price
+// changes when PRICE_SCHEDULER_INTERVAL is not set.
+const DefaultSchedulerInterval = time.Minute
+
+// StartScheduler applies due price schedules every interval until ctx is
+// cancelled. It runs once immediately so that schedules missed while the
+// server was down are caught up on start.
+func (s *PriceService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			n, err := s.ApplyDueSchedules(ctx, time.Now())
+			if err != nil {
+				log.Printf("price scheduler: %v", err)
+			} else if n > 0 {
+				log.Printf("price scheduler: processed %d schedule(s)", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,36 @@
+package report
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultRefreshInterval is how often the report views are refreshed when
+// REPORT_REFRESH_INTERVAL is not set.
+const DefaultRefreshInterval = 15 * time.Minute
+
+// StartRefresher refreshes the report views every interval until ctx is
+// cancelled. It runs once immediately so that reports do not serve data
+// from before the server was started.
+func (s *ReportService) StartRefresher(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			start := time.Now()
+			if _, err := s.RefreshViews(ctx); err != nil {
+				log.Printf("report refresher: %v", err)
+			} else {
+				log.Printf("report refresher: refreshed views in %s", time.Since(start).Round(time.Millisecond))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type ReportService struct {
+	store store.ReportStoreInterface
+}
+
+func NewReportService(store store.ReportStoreInterface) *ReportService {
+	return &ReportService{store: store}
+}
+
+// GetInventoryReport reports the stock at the end of filter.To, today by
+// default, and weekly sales over the DefaultReportDays before it unless the
+// filter says otherwise.
+func (s *ReportService) GetInventoryReport(ctx context.Context, filter models.ReportFilter) (models.InventoryReport, error) {
+	now := time.Now()
+	if filter.To.IsZero() {
+		filter.To = models.DateOf(now)
+	}
+	if filter.From.IsZero() {
+		filter.From = models.Date{Time: filter.To.AddDate(0, 0, -models.DefaultReportDays)}
+	}
+	if filter.Interval == "" {
+		filter.Interval = models.ReportIntervalWeek
+	}
+	if err := models.ValidateReportFilter(filter); err != nil {
+		return models.InventoryReport{}, models.ValidationError(err)
+	}
+
+	report, err := s.store.GetInventoryReport(ctx, filter)
+	if err != nil {
+		return models.InventoryReport{}, err
+	}
+	report.GeneratedAt = now
+	return report, nil
+}
+
+func (s *ReportService) RefreshViews(ctx context.Context) ([]models.ReportRefresh, error) {
+	return s.store.RefreshViews(ctx)
+}
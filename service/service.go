@@ -2,21 +2,140 @@ package service
 
 import (
 	"context"
+	"io"
+	"net/url"
+	"time"
 
 	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
 )
 
 type CarService interface {
 	GetCarByID(ctx context.Context, id string) (models.Car, error)
-	GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error)
+	GetCarsByBrand(ctx context.Context, filter models.CarFilter, currency string) ([]models.Car, error)
 	CreateCar(ctx context.Context, car *models.CarRequest) (models.Car, error)
 	UpdateCar(ctx context.Context, id string, car *models.CarRequest) (models.Car, error)
 	DeleteCar(ctx context.Context, id string) error
+	GetCarsByEngineID(ctx context.Context, engineID string) ([]models.Car, error)
+	GetCarsByLocation(ctx context.Context, locationID string) ([]models.Car, error)
+	CompareCars(ctx context.Context, ids []string, currency string) (models.CarComparison, error)
+	GetInspection(ctx context.Context, carID string) (models.Inspection, error)
+	SetInspection(ctx context.Context, carID string, inspection *models.InspectionRequest, inspector string) (models.Inspection, error)
+	DeleteInspection(ctx context.Context, carID string) error
 }
 
 type EngineService interface {
 	GetEngineByID(ctx context.Context, id string) (models.Engine, error)
+	GetEnginesByIDs(ctx context.Context, ids []string) ([]models.Engine, error)
 	CreateEngine(ctx context.Context, engine *models.EngineRequest) (models.Engine, error)
 	UpdateEngine(ctx context.Context, id string, engine *models.EngineRequest) (models.Engine, error)
 	DeleteEngine(ctx context.Context, id string) error
+	ListEngines(ctx context.Context, filter models.EngineFilter) (models.Page[models.Engine], error)
+	GetEngineUsage(ctx context.Context, filter models.EngineFilter) (models.Page[models.EngineUsage], error)
+}
+
+type OrderService interface {
+	GetOrderByID(ctx context.Context, id string) (models.Order, error)
+	CreateOrder(ctx context.Context, order *models.OrderRequest, salesperson string) (models.Order, error)
+}
+
+type ExchangeRateService interface {
+	ListExchangeRates(ctx context.Context) ([]models.ExchangeRate, error)
+	SetExchangeRates(ctx context.Context, rates []models.ExchangeRate) ([]models.ExchangeRate, error)
+	ImportExchangeRates(ctx context.Context, r io.Reader) ([]models.ExchangeRate, error)
+	ConvertPrice(ctx context.Context, price models.Money, from, to string, asOf time.Time) (models.ConvertedPrice, error)
+}
+
+type PriceService interface {
+	GetPriceTimeline(ctx context.Context, carID string) (models.PriceTimeline, error)
+	SchedulePriceChange(ctx context.Context, carID string, schedule *models.PriceScheduleRequest, createdBy string) (models.PriceSchedule, error)
+	CancelPriceSchedule(ctx context.Context, carID, scheduleID string) error
+}
+
+type BrandService interface {
+	ListBrands(ctx context.Context) ([]models.Brand, error)
+	GetBrandByID(ctx context.Context, id string) (models.Brand, error)
+	CreateBrand(ctx context.Context, brand *models.BrandRequest) (models.Brand, error)
+	UpdateBrand(ctx context.Context, id string, brand *models.BrandRequest) (models.Brand, error)
+	DeleteBrand(ctx context.Context, id string) error
+	ListModels(ctx context.Context, brandID string) ([]models.VehicleModel, error)
+	GetModelByID(ctx context.Context, brandID, id string) (models.VehicleModel, error)
+	CreateModel(ctx context.Context, brandID string, model *models.VehicleModelRequest) (models.VehicleModel, error)
+	UpdateModel(ctx context.Context, brandID, id string, model *models.VehicleModelRequest) (models.VehicleModel, error)
+	DeleteModel(ctx context.Context, brandID, id string) error
+	ListTrims(ctx context.Context, brandID, modelID string) ([]models.Trim, error)
+	CreateTrim(ctx context.Context, brandID, modelID string, trim *models.TrimRequest) (models.Trim, error)
+	UpdateTrim(ctx context.Context, brandID, modelID, id string, trim *models.TrimRequest) (models.Trim, error)
+	DeleteTrim(ctx context.Context, brandID, modelID, id string) error
+}
+
+type OptionService interface {
+	ListOptions(ctx context.Context, category string) ([]models.Option, error)
+	GetOptionByID(ctx context.Context, id string) (models.Option, error)
+	CreateOption(ctx context.Context, option *models.OptionRequest) (models.Option, error)
+	UpdateOption(ctx context.Context, id string, option *models.OptionRequest) (models.Option, error)
+	DeleteOption(ctx context.Context, id string) error
+	GetCarOptions(ctx context.Context, carID string) (models.CarOptions, error)
+	SetCarOptions(ctx context.Context, carID string, req *models.CarOptionsRequest) (models.CarOptions, error)
+}
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, carID, kind, filename string, r io.Reader, uploadedBy string) (models.Attachment, error)
+	ListAttachments(ctx context.Context, carID string) ([]models.Attachment, error)
+	DeleteAttachment(ctx context.Context, carID, id string) error
+	OpenAttachment(ctx context.Context, id, variant, signedPath string, query url.Values) (models.Attachment, io.ReadCloser, error)
+}
+
+type LocationService interface {
+	ListLocations(ctx context.Context) ([]models.Location, error)
+	GetLocationByID(ctx context.Context, id string) (models.Location, error)
+	CreateLocation(ctx context.Context, location *models.LocationRequest) (models.Location, error)
+	UpdateLocation(ctx context.Context, id string, location *models.LocationRequest) (models.Location, error)
+	DeleteLocation(ctx context.Context, id string) error
+	ListTransfers(ctx context.Context, filter models.TransferFilter) (models.Page[models.Transfer], error)
+	GetTransferByID(ctx context.Context, id string) (models.Transfer, error)
+	CreateTransfer(ctx context.Context, transfer *models.TransferRequest, requestedBy string) (models.Transfer, error)
+	ReceiveTransfer(ctx context.Context, id, receivedBy string) (models.Transfer, error)
+	CancelTransfer(ctx context.Context, id, cancelledBy string) (models.Transfer, error)
+}
+
+type ServiceRecordService interface {
+	ListServiceRecords(ctx context.Context, carID string) ([]models.ServiceRecord, error)
+	GetServiceRecord(ctx context.Context, carID, id string) (models.ServiceRecord, error)
+	CreateServiceRecord(ctx context.Context, carID string, record *models.ServiceRecordRequest, createdBy string) (models.ServiceRecord, error)
+	UpdateServiceRecord(ctx context.Context, carID, id string, record *models.ServiceRecordRequest) (models.ServiceRecord, error)
+	DeleteServiceRecord(ctx context.Context, carID, id string) error
+	ListServiceIntervals(ctx context.Context) ([]models.ServiceInterval, error)
+	SetServiceInterval(ctx context.Context, powertrain string, interval *models.ServiceIntervalRequest) (models.ServiceInterval, error)
+	ListServicesDue(ctx context.Context, filter models.ServiceDueFilter) (models.Page[models.ServiceDue], error)
+}
+
+type ReportService interface {
+	GetInventoryReport(ctx context.Context, filter models.ReportFilter) (models.InventoryReport, error)
+	RefreshViews(ctx context.Context) ([]models.ReportRefresh, error)
+}
+
+type WebhookService interface {
+	ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetWebhookByID(ctx context.Context, id string) (models.WebhookSubscription, error)
+	CreateWebhook(ctx context.Context, webhook *models.WebhookRequest, createdBy string) (models.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id string, webhook *models.WebhookRequest) (models.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	TestWebhook(ctx context.Context, id string) (models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID string, filter models.WebhookDeliveryFilter) (models.Page[models.WebhookDelivery], error)
+	GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error)
+}
+
+type EventStreamService interface {
+	Subscribe(filter models.StreamFilter, lastEventID *int64) models.StreamSubscription
+}
+
+type EditingService interface {
+	Connect(username string) models.EditingSession
+	Disconnect(ctx context.Context, sessionID uuid.UUID)
+	Subscribe(ctx context.Context, sessionID, carID uuid.UUID) error
+	Unsubscribe(ctx context.Context, sessionID, carID uuid.UUID) error
+	Lock(ctx context.Context, sessionID, carID uuid.UUID) (models.CarEditLock, bool, error)
+	Unlock(ctx context.Context, sessionID, carID uuid.UUID) error
 }
\ No newline at end of file
@@ -0,0 +1,81 @@
+package servicerecord
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+)
+
+type ServiceRecordService struct {
+	store store.ServiceRecordStoreInterface
+}
+
+func NewServiceRecordService(store store.ServiceRecordStoreInterface) *ServiceRecordService {
+	return &ServiceRecordService{store: store}
+}
+
+func (s *ServiceRecordService) ListServiceRecords(ctx context.Context, carID string) ([]models.ServiceRecord, error) {
+	return s.store.ListServiceRecords(ctx, carID)
+}
+
+func (s *ServiceRecordService) GetServiceRecord(ctx context.Context, carID, id string) (models.ServiceRecord, error) {
+	return s.store.GetServiceRecord(ctx, carID, id)
+}
+
+func (s *ServiceRecordService) CreateServiceRecord(ctx context.Context, carID string, record *models.ServiceRecordRequest, createdBy string) (models.ServiceRecord, error) {
+	normalizeServiceRecordRequest(record)
+	if err := models.ValidateServiceRecordRequest(*record, time.Now()); err != nil {
+		return models.ServiceRecord{}, models.ValidationError(err)
+	}
+	return s.store.CreateServiceRecord(ctx, carID, record, createdBy)
+}
+
+func (s *ServiceRecordService) UpdateServiceRecord(ctx context.Context, carID, id string, record *models.ServiceRecordRequest) (models.ServiceRecord, error) {
+	normalizeServiceRecordRequest(record)
+	if err := models.ValidateServiceRecordRequest(*record, time.Now()); err != nil {
+		return models.ServiceRecord{}, models.ValidationError(err)
+	}
+	return s.store.UpdateServiceRecord(ctx, carID, id, record)
+}
+
+func (s *ServiceRecordService) DeleteServiceRecord(ctx context.Context, carID, id string) error {
+	return s.store.DeleteServiceRecord(ctx, carID, id)
+}
+
+func (s *ServiceRecordService) ListServiceIntervals(ctx context.Context) ([]models.ServiceInterval, error) {
+	return s.store.ListServiceIntervals(ctx)
+}
+
+func (s *ServiceRecordService) SetServiceInterval(ctx context.Context, powertrain string, req *models.ServiceIntervalRequest) (models.ServiceInterval, error) {
+	interval := models.ServiceInterval{
+		Powertrain:     strings.ToLower(strings.TrimSpace(powertrain)),
+		IntervalKm:     req.IntervalKm,
+		IntervalMonths: req.IntervalMonths,
+	}
+	if err := models.ValidateServiceInterval(interval); err != nil {
+		return models.ServiceInterval{}, models.ValidationError(err)
+	}
+	return s.store.SetServiceInterval(ctx, &interval)
+}
+
+// ListServicesDue lists the cars due for service by filter.Before, which
+// defaults to today.
+func (s *ServiceRecordService) ListServicesDue(ctx context.Context, filter models.ServiceDueFilter) (models.Page[models.ServiceDue], error) {
+	filter.AsOf = models.DateOf(time.Now())
+	if filter.Before.IsZero() {
+		filter.Before = filter.AsOf
+	}
+	if err := models.ValidateServiceDueFilter(filter); err != nil {
+		return models.Page[models.ServiceDue]{}, models.ValidationError(err)
+	}
+	return s.store.ListServicesDue(ctx, filter)
+}
+
+func normalizeServiceRecordRequest(record *models.ServiceRecordRequest) {
+	record.WorkDone = strings.TrimSpace(record.WorkDone)
+	record.Workshop = strings.TrimSpace(record.Workshop)
+	record.Currency = models.NormalizeCurrency(record.Currency)
+}
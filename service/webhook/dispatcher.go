@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+// DefaultDispatchInterval is how often the dispatcher looks for due
+// deliveries when WEBHOOK_DISPATCH_INTERVAL is not set.
+const DefaultDispatchInterval = 10 * time.Second
+
+// dispatchBatchSize is how many deliveries are claimed and sent at once.
+const dispatchBatchSize = 20
+
+// DispatchDue sends the due deliveries in batches until none are left and
+// returns how many were sent. The deliveries of a batch are sent in
+// parallel, so a slow receiver does not hold up the others.
+func (s *WebhookService) DispatchDue(ctx context.Context) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		deliveries, err := s.store.ClaimDueDeliveries(ctx, dispatchBatchSize, sendLease)
+		if err != nil {
+			return sent, err
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.deliver(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+		sent += len(deliveries)
+	}
+	return sent, nil
+}
+
+// deliver sends a delivery once and schedules what comes next: nothing once
+// it is delivered, a retry after a failure, or the dead-letter state once
+// the attempts are used up.
+func (s *WebhookService) deliver(ctx context.Context, delivery models.WebhookDelivery) {
+	attempt := s.sender.Send(ctx, delivery)
+	attempts := delivery.Attempts + 1
+
+	status, next := models.WebhookDelivered, attempt.AttemptedAt
+	if !attempt.Succeeded() {
+		if attempts >= MaxAttempts {
+			status = models.WebhookDead
+			log.Printf("webhook dispatcher: delivery %s dead after %d attempts: %s", delivery.ID, attempts, attempt.Error)
+		} else {
+			status, next = models.WebhookPending, time.Now().Add(retryDelay(attempts))
+		}
+	}
+
+	if err := s.store.RecordAttempt(ctx, delivery.ID, attempt, status, next); err != nil {
+		log.Printf("webhook dispatcher: record attempt for delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// StartDispatcher sends due deliveries every interval until ctx is
+// cancelled. It runs once immediately so that retries that fell due while
+// the server was down are sent on start.
+func (s *WebhookService) StartDispatcher(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			n, err := s.DispatchDue(ctx)
+			if err != nil {
+				log.Printf("webhook dispatcher: %v", err)
+			} else if n > 0 {
+				log.Printf("webhook dispatcher: sent %d webhook(s)", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LikhithMar14/management/events"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+	"github.com/google/uuid"
+)
+
+// fakeStore keeps deliveries in memory. Every pending delivery is due, so a
+// single DispatchDue keeps retrying a failing one until it is dead.
+type fakeStore struct {
+	store.WebhookStoreInterface
+
+	mu         sync.Mutex
+	deliveries map[uuid.UUID]*models.WebhookDelivery
+	attempts   []recordedAttempt
+}
+
+type recordedAttempt struct {
+	attempt models.WebhookAttempt
+	status  string
+	next    time.Time
+}
+
+func newFakeStore(deliveries ...models.WebhookDelivery) *fakeStore {
+	s := &fakeStore{deliveries: map[uuid.UUID]*models.WebhookDelivery{}}
+	for _, d := range deliveries {
+		s.deliveries[d.ID] = &d
+	}
+	return s
+}
+
+func (s *fakeStore) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []models.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == models.WebhookPending && len(due) < limit {
+			due = append(due, *d)
+		}
+	}
+	return due, nil
+}
+
+func (s *fakeStore) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt models.WebhookAttempt, status string, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.deliveries[deliveryID]
+	d.Attempts++
+	d.Status = status
+	s.attempts = append(s.attempts, recordedAttempt{attempt: attempt, status: status, next: nextAttemptAt})
+	return nil
+}
+
+func testDelivery(url string) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:        uuid.New(),
+		EventType: models.EventCarCreated,
+		Payload:   []byte(`{"id":1}`),
+		Status:    models.WebhookPending,
+		URL:       url,
+		Secret:    "secret",
+	}
+}
+
+func TestDispatchDueMarksAcceptedDeliveryDelivered(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := testDelivery(server.URL)
+	fake := newFakeStore(delivery)
+	service := NewWebhookService(fake, events.NewWebhookSender(server.Client()))
+
+	sent, err := service.DispatchDue(context.Background())
+	if err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+	if sent != 1 || requests != 1 {
+		t.Fatalf("sent %d with %d request(s), want 1", sent, requests)
+	}
+	if len(fake.attempts) != 1 || fake.attempts[0].status != models.WebhookDelivered {
+		t.Fatalf("attempts = %+v, want one delivered", fake.attempts)
+	}
+	if code := fake.attempts[0].attempt.StatusCode; code == nil || *code != http.StatusNoContent {
+		t.Errorf("recorded status code %v, want 204", code)
+	}
+}
+
+func TestDispatchDueRetriesThenDeadLetters(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	fake := newFakeStore(testDelivery(server.URL))
+	service := NewWebhookService(fake, events.NewWebhookSender(server.Client()))
+
+	if _, err := service.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+	if requests != MaxAttempts || len(fake.attempts) != MaxAttempts {
+		t.Fatalf("made %d request(s) and %d attempt(s), want %d", requests, len(fake.attempts), MaxAttempts)
+	}
+
+	var previous time.Duration
+	for i, recorded := range fake.attempts {
+		if recorded.attempt.Error == "" {
+			t.Errorf("attempt %d recorded no error", i+1)
+		}
+		if i == MaxAttempts-1 {
+			if recorded.status != models.WebhookDead {
+				t.Errorf("last attempt status = %s, want dead", recorded.status)
+			}
+			continue
+		}
+		if recorded.status != models.WebhookPending {
+			t.Errorf("attempt %d status = %s, want pending", i+1, recorded.status)
+		}
+		delay := recorded.next.Sub(recorded.attempt.AttemptedAt)
+		if delay <= previous {
+			t.Errorf("retry %d after %s, want longer than %s", i+1, delay, previous)
+		}
+		if want := retryDelay(i + 1); delay < want || delay > want+time.Minute {
+			t.Errorf("retry %d after %s, want about %s", i+1, delay, want)
+		}
+		previous = delay
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 10, want: 256 * time.Minute},
+		{attempts: 11, want: maxRetryDelay},
+		{attempts: 1000, want: maxRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
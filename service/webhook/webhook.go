@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/events"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store"
+	"github.com/google/uuid"
+)
+
+// MaxAttempts is how often a delivery is tried before it is dead-lettered.
+const MaxAttempts = 8
+
+// Retries back off exponentially from firstRetryDelay up to maxRetryDelay:
+// with eight attempts the last retry comes about an hour after the first try.
+const (
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = 6 * time.Hour
+)
+
+// sendLease bounds how long a claimed delivery is kept from other
+// dispatchers. It comfortably covers a send, which times out sooner.
+const sendLease = 2 * time.Minute
+
+type WebhookService struct {
+	store  store.WebhookStoreInterface
+	sender *events.WebhookSender
+}
+
+func NewWebhookService(store store.WebhookStoreInterface, sender *events.WebhookSender) *WebhookService {
+	return &WebhookService{store: store, sender: sender}
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.store.ListWebhooks(ctx)
+}
+
+func (s *WebhookService) GetWebhookByID(ctx context.Context, id string) (models.WebhookSubscription, error) {
+	return s.store.GetWebhookByID(ctx, id)
+}
+
+// CreateWebhook subscribes a URL to events. The response carries the signing
+// secret, generated unless one was given; it is not shown again.
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *models.WebhookRequest, createdBy string) (models.WebhookSubscription, error) {
+	if err := normalizeWebhook(webhook); err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	if webhook.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return models.WebhookSubscription{}, err
+		}
+		webhook.Secret = secret
+	}
+
+	created, err := s.store.CreateWebhook(ctx, webhook, createdBy)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+// UpdateWebhook replaces a subscription. A new secret is echoed back; an
+// omitted one keeps the current secret.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id string, webhook *models.WebhookRequest) (models.WebhookSubscription, error) {
+	if err := normalizeWebhook(webhook); err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	updated, err := s.store.UpdateWebhook(ctx, id, webhook)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	updated.Secret = webhook.Secret
+	return updated, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	return s.store.DeleteWebhook(ctx, id)
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID string, filter models.WebhookDeliveryFilter) (models.Page[models.WebhookDelivery], error) {
+	if err := models.ValidateWebhookDeliveryFilter(filter); err != nil {
+		return models.Page[models.WebhookDelivery]{}, models.ValidationError(err)
+	}
+	return s.store.ListDeliveries(ctx, subscriptionID, filter)
+}
+
+func (s *WebhookService) GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error) {
+	return s.store.GetDelivery(ctx, subscriptionID, deliveryID)
+}
+
+func (s *WebhookService) RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error) {
+	return s.store.RetryDelivery(ctx, subscriptionID, deliveryID)
+}
+
+// TestWebhook sends a webhook.test event to a subscription right away, even
+// when it is inactive, and returns the logged delivery. A test is tried only
+// once: a failure is dead-lettered instead of retried.
+func (s *WebhookService) TestWebhook(ctx context.Context, id string) (models.WebhookDelivery, error) {
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		return models.WebhookDelivery{}, models.ValidationError(err)
+	}
+	payload, err := json.Marshal(models.Event{
+		Type:          models.EventWebhookTest,
+		AggregateType: "webhook",
+		AggregateID:   subscriptionID,
+		Payload:       json.RawMessage(`{"message":"This is a test delivery."}`),
+		OccurredAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.store.CreateTestDelivery(ctx, id, payload, sendLease)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	attempt := s.sender.Send(ctx, delivery)
+	status := models.WebhookDelivered
+	if !attempt.Succeeded() {
+		status = models.WebhookDead
+	}
+	if err := s.store.RecordAttempt(ctx, delivery.ID, attempt, status, attempt.AttemptedAt); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return s.store.GetDelivery(ctx, id, delivery.ID.String())
+}
+
+// retryDelay is how long to wait before the next try after the given number
+// of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+func normalizeWebhook(webhook *models.WebhookRequest) error {
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	webhook.Description = strings.TrimSpace(webhook.Description)
+	if webhook.Active == nil {
+		active := true
+		webhook.Active = &active
+	}
+	if err := models.ValidateWebhookRequest(*webhook); err != nil {
+		return models.ValidationError(err)
+	}
+	return nil
+}
+
+// newSecret returns a random signing secret.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
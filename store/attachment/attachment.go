@@ -0,0 +1,120 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+)
+
+type AttachmentStore struct {
+	db *sql.DB
+}
+
+func NewAttachmentStore(db *sql.DB) *AttachmentStore {
+	return &AttachmentStore{db: db}
+}
+
+const attachmentColumns = `
+	id, car_id, kind, filename, content_type, size_bytes, width, height,
+	storage_key, thumbnail_key, uploaded_by, created_at
+`
+
+func scanAttachment(row interface{ Scan(...any) error }) (models.Attachment, error) {
+	var a models.Attachment
+	err := row.Scan(
+		&a.ID, &a.CarID, &a.Kind, &a.Filename, &a.ContentType, &a.SizeBytes, &a.Width, &a.Height,
+		&a.StorageKey, &a.ThumbnailKey, &a.UploadedBy, &a.CreatedAt,
+	)
+	return a, err
+}
+
+// CreateAttachment records an attachment whose blobs have already been
+// stored.
+func (s *AttachmentStore) CreateAttachment(ctx context.Context, attachment *models.Attachment) (models.Attachment, error) {
+	query := `
+		INSERT INTO attachments (
+			id, car_id, kind, filename, content_type, size_bytes, width, height, storage_key, thumbnail_key, uploaded_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING ` + attachmentColumns
+
+	created, err := scanAttachment(s.db.QueryRowContext(ctx, query,
+		attachment.ID, attachment.CarID, attachment.Kind, attachment.Filename, attachment.ContentType,
+		attachment.SizeBytes, attachment.Width, attachment.Height, attachment.StorageKey, attachment.ThumbnailKey,
+		attachment.UploadedBy,
+	))
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return models.Attachment{}, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.Attachment{}, err
+	}
+	return created, nil
+}
+
+func (s *AttachmentStore) ListAttachments(ctx context.Context, carID string) ([]models.Attachment, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM cars WHERE id = $1)`, carID).Scan(&exists)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return nil, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("car: %w", models.ErrNotFound)
+	}
+
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE car_id = $1 ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentStore) GetAttachmentByID(ctx context.Context, id string) (models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1`
+
+	attachment, err := scanAttachment(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Attachment{}, fmt.Errorf("attachment: %w", models.ErrNotFound)
+		}
+		return models.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment removes the record and returns it so the caller can
+// delete its blobs.
+func (s *AttachmentStore) DeleteAttachment(ctx context.Context, carID, id string) (models.Attachment, error) {
+	query := `DELETE FROM attachments WHERE id = $1 AND car_id = $2 RETURNING ` + attachmentColumns
+
+	deleted, err := scanAttachment(s.db.QueryRowContext(ctx, query, id, carID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Attachment{}, fmt.Errorf("attachment: %w", models.ErrNotFound)
+		}
+		return models.Attachment{}, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,610 @@
+package brand
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+type BrandStore struct {
+	db *sql.DB
+}
+
+func NewBrandStore(db *sql.DB) *BrandStore {
+	return &BrandStore{db: db}
+}
+
+// Queryer is satisfied by both *sql.DB and *sql.Tx.
+type Queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ResolveBrand finds the brand a free-text name or alias refers to. It fails
+// with ErrNotFound when no brand matches.
+func ResolveBrand(ctx context.Context, q Queryer, name string) (uuid.UUID, string, error) {
+	query := `
+		SELECT id, name FROM brands WHERE reference_key(name) = reference_key($1)
+		UNION ALL
+		SELECT b.id, b.name
+		FROM brand_aliases a
+		JOIN brands b ON b.id = a.brand_id
+		WHERE reference_key(a.alias) = reference_key($1)
+		LIMIT 1
+	`
+
+	var id uuid.UUID
+	var canonical string
+	err := q.QueryRowContext(ctx, query, name).Scan(&id, &canonical)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, "", fmt.Errorf("brand %q: %w", name, models.ErrNotFound)
+	}
+	return id, canonical, err
+}
+
+// ResolveModel finds the model line of a brand a free-text name or alias
+// refers to. It fails with ErrNotFound when no model matches.
+func ResolveModel(ctx context.Context, q Queryer, brandID uuid.UUID, name string) (uuid.UUID, string, error) {
+	query := `
+		SELECT id, name FROM models WHERE brand_id = $1 AND reference_key(name) = reference_key($2)
+		UNION ALL
+		SELECT m.id, m.name
+		FROM model_aliases a
+		JOIN models m ON m.id = a.model_id
+		WHERE a.brand_id = $1 AND reference_key(a.alias) = reference_key($2)
+		LIMIT 1
+	`
+
+	var id uuid.UUID
+	var canonical string
+	err := q.QueryRowContext(ctx, query, brandID, name).Scan(&id, &canonical)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, "", fmt.Errorf("model %q: %w", name, models.ErrNotFound)
+	}
+	return id, canonical, err
+}
+
+// aliasList scans the JSON array of aliases the queries below aggregate.
+type aliasList struct {
+	dest *[]string
+}
+
+func (a aliasList) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unexpected aliases type %T", src)
+	}
+	return json.Unmarshal(data, a.dest)
+}
+
+const brandColumns = `
+	b.id, b.name,
+	to_json(ARRAY(SELECT alias FROM brand_aliases a WHERE a.brand_id = b.id ORDER BY alias)),
+	b.created_at, b.updated_at
+`
+
+func scanBrand(row interface{ Scan(...any) error }) (models.Brand, error) {
+	var brand models.Brand
+	err := row.Scan(&brand.ID, &brand.Name, aliasList{&brand.Aliases}, &brand.CreatedAt, &brand.UpdatedAt)
+	return brand, err
+}
+
+const modelColumns = `
+	m.id, m.brand_id, m.name,
+	to_json(ARRAY(SELECT alias FROM model_aliases a WHERE a.model_id = m.id ORDER BY alias)),
+	m.created_at, m.updated_at
+`
+
+func scanModel(row interface{ Scan(...any) error }) (models.VehicleModel, error) {
+	var model models.VehicleModel
+	err := row.Scan(&model.ID, &model.BrandID, &model.Name, aliasList{&model.Aliases}, &model.CreatedAt, &model.UpdatedAt)
+	return model, err
+}
+
+func (s *BrandStore) ListBrands(ctx context.Context) ([]models.Brand, error) {
+	query := `SELECT ` + brandColumns + ` FROM brands b ORDER BY b.name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := []models.Brand{}
+	for rows.Next() {
+		brand, err := scanBrand(rows)
+		if err != nil {
+			return nil, err
+		}
+		brands = append(brands, brand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return brands, nil
+}
+
+func (s *BrandStore) GetBrandByID(ctx context.Context, id string) (models.Brand, error) {
+	return getBrand(ctx, s.db, id)
+}
+
+func getBrand(ctx context.Context, q Queryer, id string) (models.Brand, error) {
+	query := `SELECT ` + brandColumns + ` FROM brands b WHERE b.id = $1`
+
+	brand, err := scanBrand(q.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Brand{}, fmt.Errorf("brand: %w", models.ErrNotFound)
+		}
+		return models.Brand{}, err
+	}
+	return brand, nil
+}
+
+func (s *BrandStore) CreateBrand(ctx context.Context, brand *models.BrandRequest) (_ models.Brand, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Brand{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = checkBrandNames(ctx, tx, uuid.Nil, brand.Name, brand.Aliases); err != nil {
+		return models.Brand{}, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `INSERT INTO brands (name) VALUES ($1) RETURNING id`, brand.Name).Scan(&id)
+	if err != nil {
+		return models.Brand{}, brandWriteError(err)
+	}
+
+	if err = insertBrandAliases(ctx, tx, id, brand.Aliases); err != nil {
+		return models.Brand{}, err
+	}
+
+	created, err := getBrand(ctx, tx, id.String())
+	if err != nil {
+		return models.Brand{}, err
+	}
+	return created, nil
+}
+
+// UpdateBrand renames a brand and replaces its aliases. Cars follow the
+// rename since they reference the brand by id.
+func (s *BrandStore) UpdateBrand(ctx context.Context, id string, brand *models.BrandRequest) (_ models.Brand, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Brand{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var brandID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		UPDATE brands SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING id
+	`, brand.Name, id).Scan(&brandID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("brand: %w", models.ErrNotFound)
+			return models.Brand{}, err
+		}
+		return models.Brand{}, brandWriteError(err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM brand_aliases WHERE brand_id = $1`, brandID); err != nil {
+		return models.Brand{}, err
+	}
+
+	if err = checkBrandNames(ctx, tx, brandID, brand.Name, brand.Aliases); err != nil {
+		return models.Brand{}, err
+	}
+
+	if err = insertBrandAliases(ctx, tx, brandID, brand.Aliases); err != nil {
+		return models.Brand{}, err
+	}
+
+	updated, err := getBrand(ctx, tx, id)
+	if err != nil {
+		return models.Brand{}, err
+	}
+	return updated, nil
+}
+
+// DeleteBrand removes a brand together with its models. Brands of existing
+// cars cannot be deleted.
+func (s *BrandStore) DeleteBrand(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM brands WHERE id = $1`, id)
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return fmt.Errorf("brand is used by cars: %w", models.ErrConflict)
+		}
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("brand: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("brand: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// checkBrandNames makes sure none of the names already refers to a brand
+// other than brandID. The unique indexes only cover names and aliases
+// separately, so a new alias could otherwise shadow another brand's name.
+func checkBrandNames(ctx context.Context, tx *sql.Tx, brandID uuid.UUID, name string, aliases []string) error {
+	for _, n := range append([]string{name}, aliases...) {
+		id, canonical, err := ResolveBrand(ctx, tx, n)
+		if errors.Is(err, models.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if id != brandID {
+			return fmt.Errorf("%q already names brand %s: %w", n, canonical, models.ErrConflict)
+		}
+	}
+	return nil
+}
+
+func insertBrandAliases(ctx context.Context, tx *sql.Tx, brandID uuid.UUID, aliases []string) error {
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx, `INSERT INTO brand_aliases (brand_id, alias) VALUES ($1, $2)`, brandID, alias)
+		if err != nil {
+			return brandWriteError(err)
+		}
+	}
+	return nil
+}
+
+func brandWriteError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("brand name is already taken: %w", models.ErrConflict)
+	}
+	return err
+}
+
+// ListModels returns the model lines of a brand.
+func (s *BrandStore) ListModels(ctx context.Context, brandID string) ([]models.VehicleModel, error) {
+	if _, err := getBrand(ctx, s.db, brandID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + modelColumns + ` FROM models m WHERE m.brand_id = $1 ORDER BY m.name`
+
+	rows, err := s.db.QueryContext(ctx, query, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vehicleModels := []models.VehicleModel{}
+	for rows.Next() {
+		model, err := scanModel(rows)
+		if err != nil {
+			return nil, err
+		}
+		vehicleModels = append(vehicleModels, model)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return vehicleModels, nil
+}
+
+func (s *BrandStore) GetModelByID(ctx context.Context, brandID, id string) (models.VehicleModel, error) {
+	return getModel(ctx, s.db, brandID, id)
+}
+
+func getModel(ctx context.Context, q Queryer, brandID, id string) (models.VehicleModel, error) {
+	query := `SELECT ` + modelColumns + ` FROM models m WHERE m.id = $1 AND m.brand_id = $2`
+
+	model, err := scanModel(q.QueryRowContext(ctx, query, id, brandID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.VehicleModel{}, fmt.Errorf("model: %w", models.ErrNotFound)
+		}
+		return models.VehicleModel{}, err
+	}
+	return model, nil
+}
+
+func (s *BrandStore) CreateModel(ctx context.Context, brandID string, model *models.VehicleModelRequest) (_ models.VehicleModel, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	brand, err := getBrand(ctx, tx, brandID)
+	if err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	if err = checkModelNames(ctx, tx, brand.ID, uuid.Nil, model.Name, model.Aliases); err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `INSERT INTO models (brand_id, name) VALUES ($1, $2) RETURNING id`, brand.ID, model.Name).Scan(&id)
+	if err != nil {
+		return models.VehicleModel{}, modelWriteError(err)
+	}
+
+	if err = insertModelAliases(ctx, tx, brand.ID, id, model.Aliases); err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	created, err := getModel(ctx, tx, brandID, id.String())
+	if err != nil {
+		return models.VehicleModel{}, err
+	}
+	return created, nil
+}
+
+// UpdateModel renames a model line and replaces its aliases.
+func (s *BrandStore) UpdateModel(ctx context.Context, brandID, id string, model *models.VehicleModelRequest) (_ models.VehicleModel, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var modelID, modelBrandID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		UPDATE models SET name = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND brand_id = $3
+		RETURNING id, brand_id
+	`, model.Name, id, brandID).Scan(&modelID, &modelBrandID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("model: %w", models.ErrNotFound)
+			return models.VehicleModel{}, err
+		}
+		return models.VehicleModel{}, modelWriteError(err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM model_aliases WHERE model_id = $1`, modelID); err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	if err = checkModelNames(ctx, tx, modelBrandID, modelID, model.Name, model.Aliases); err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	if err = insertModelAliases(ctx, tx, modelBrandID, modelID, model.Aliases); err != nil {
+		return models.VehicleModel{}, err
+	}
+
+	updated, err := getModel(ctx, tx, brandID, id)
+	if err != nil {
+		return models.VehicleModel{}, err
+	}
+	return updated, nil
+}
+
+// DeleteModel removes a model line that no car uses.
+func (s *BrandStore) DeleteModel(ctx context.Context, brandID, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM models WHERE id = $1 AND brand_id = $2`, id, brandID)
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return fmt.Errorf("model is used by cars: %w", models.ErrConflict)
+		}
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("model: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("model: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// checkModelNames is checkBrandNames for the model lines of one brand.
+func checkModelNames(ctx context.Context, tx *sql.Tx, brandID, modelID uuid.UUID, name string, aliases []string) error {
+	for _, n := range append([]string{name}, aliases...) {
+		id, canonical, err := ResolveModel(ctx, tx, brandID, n)
+		if errors.Is(err, models.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if id != modelID {
+			return fmt.Errorf("%q already names model %s: %w", n, canonical, models.ErrConflict)
+		}
+	}
+	return nil
+}
+
+func insertModelAliases(ctx context.Context, tx *sql.Tx, brandID, modelID uuid.UUID, aliases []string) error {
+	query := `INSERT INTO model_aliases (model_id, brand_id, alias) VALUES ($1, $2, $3)`
+	for _, alias := range aliases {
+		if _, err := tx.ExecContext(ctx, query, modelID, brandID, alias); err != nil {
+			return modelWriteError(err)
+		}
+	}
+	return nil
+}
+
+func modelWriteError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("model name is already taken: %w", models.ErrConflict)
+	}
+	return err
+}
+
+// ResolveTrim finds the trim of a model line a free-text name refers to. It
+// fails with ErrNotFound when no trim matches.
+func ResolveTrim(ctx context.Context, q Queryer, modelID uuid.UUID, name string) (uuid.UUID, string, error) {
+	query := `SELECT id, name FROM trims WHERE model_id = $1 AND reference_key(name) = reference_key($2)`
+
+	var id uuid.UUID
+	var canonical string
+	err := q.QueryRowContext(ctx, query, modelID, name).Scan(&id, &canonical)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, "", fmt.Errorf("trim %q: %w", name, models.ErrNotFound)
+	}
+	return id, canonical, err
+}
+
+const trimColumns = `id, model_id, name, description, created_at, updated_at`
+
+func scanTrim(row interface{ Scan(...any) error }) (models.Trim, error) {
+	var trim models.Trim
+	err := row.Scan(&trim.ID, &trim.ModelID, &trim.Name, &trim.Description, &trim.CreatedAt, &trim.UpdatedAt)
+	return trim, err
+}
+
+// ListTrims returns the trims of a brand's model line.
+func (s *BrandStore) ListTrims(ctx context.Context, brandID, modelID string) ([]models.Trim, error) {
+	if _, err := getModel(ctx, s.db, brandID, modelID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + trimColumns + ` FROM trims WHERE model_id = $1 ORDER BY name`
+
+	rows, err := s.db.QueryContext(ctx, query, modelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trims := []models.Trim{}
+	for rows.Next() {
+		trim, err := scanTrim(rows)
+		if err != nil {
+			return nil, err
+		}
+		trims = append(trims, trim)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return trims, nil
+}
+
+func (s *BrandStore) CreateTrim(ctx context.Context, brandID, modelID string, trim *models.TrimRequest) (models.Trim, error) {
+	model, err := getModel(ctx, s.db, brandID, modelID)
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	query := `
+		INSERT INTO trims (model_id, name, description)
+		VALUES ($1, $2, $3)
+		RETURNING ` + trimColumns
+
+	created, err := scanTrim(s.db.QueryRowContext(ctx, query, model.ID, trim.Name, trim.Description))
+	if err != nil {
+		// The model may have been deleted since it was looked up.
+		if database.IsForeignKeyViolation(err) {
+			return models.Trim{}, fmt.Errorf("model: %w", models.ErrNotFound)
+		}
+		return models.Trim{}, trimWriteError(err)
+	}
+	return created, nil
+}
+
+func (s *BrandStore) UpdateTrim(ctx context.Context, brandID, modelID, id string, trim *models.TrimRequest) (models.Trim, error) {
+	query := `
+		UPDATE trims t
+		SET name = $1, description = $2, updated_at = CURRENT_TIMESTAMP
+		FROM models m
+		WHERE t.id = $3 AND t.model_id = $4 AND m.id = t.model_id AND m.brand_id = $5
+		RETURNING t.id, t.model_id, t.name, t.description, t.created_at, t.updated_at
+	`
+
+	updated, err := scanTrim(s.db.QueryRowContext(ctx, query, trim.Name, trim.Description, id, modelID, brandID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Trim{}, fmt.Errorf("trim: %w", models.ErrNotFound)
+		}
+		return models.Trim{}, trimWriteError(err)
+	}
+	return updated, nil
+}
+
+// DeleteTrim removes a trim that no car uses.
+func (s *BrandStore) DeleteTrim(ctx context.Context, brandID, modelID, id string) error {
+	query := `
+		DELETE FROM trims t
+		USING models m
+		WHERE t.id = $1 AND t.model_id = $2 AND m.id = t.model_id AND m.brand_id = $3
+	`
+	result, err := s.db.ExecContext(ctx, query, id, modelID, brandID)
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return fmt.Errorf("trim is used by cars: %w", models.ErrConflict)
+		}
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("trim: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("trim: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+func trimWriteError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("trim name is already taken: %w", models.ErrConflict)
+	}
+	return err
+}
@@ -3,11 +3,18 @@ package car
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/LikhithMar14/management/database"
 	"github.com/LikhithMar14/management/models"
+	brandstore "github.com/LikhithMar14/management/store/brand"
+	enginestore "github.com/LikhithMar14/management/store/engine"
+	"github.com/LikhithMar14/management/store/outbox"
+	"github.com/LikhithMar14/management/store/price"
 	"github.com/google/uuid"
 )
 
@@ -19,64 +26,122 @@ func NewCarStore(db *sql.DB) *CarStore {
 	return &CarStore{db: db}
 }
 
+// carColumns selects a car with its brand, model, trim and location names
+// from carTables, whether it is in transit or certified, and its price
+// including fitted options.
+const carColumns = `
+	c.id, c.name, c.year, b.name, c.brand_id, m.name, c.model_id, t.name, c.trim_id,
+	c.location_id, l.name, EXISTS (SELECT 1 FROM transfers tr WHERE tr.car_id = c.id AND tr.status = 'in_transit'),
+	c.condition, c.odometer_km, c.condition_grade, c.previous_owners, c.accident_history,
+	c.registration_number, c.registration_date, c.registration_region,
+	c.condition = 'used' AND EXISTS (SELECT 1 FROM car_inspections ci WHERE ci.car_id = c.id AND ci.passed),
+	c.fuel_type, c.engine_id, c.price, c.currency,
+	c.price + COALESCE((SELECT SUM(co.price) FROM car_options co WHERE co.car_id = c.id), 0),
+	c.created_at, c.updated_at
+`
+
+const carTables = `
+	cars c
+	JOIN brands b ON b.id = c.brand_id
+	LEFT JOIN models m ON m.id = c.model_id
+	LEFT JOIN trims t ON t.id = c.trim_id
+	LEFT JOIN locations l ON l.id = c.location_id
+`
+
+func carFields(car *models.Car) []any {
+	return []any{
+		&car.ID, &car.Name, &car.Year, &car.Brand, &car.BrandID, &car.Model, &car.ModelID, &car.Trim, &car.TrimID,
+		&car.LocationID, &car.Location, &car.InTransit,
+		&car.Condition, &car.OdometerKm, &car.ConditionGrade, &car.PreviousOwners, &car.AccidentHistory,
+		&car.RegistrationNumber, &car.RegistrationDate, &car.RegistrationRegion, &car.CertifiedPreOwned,
+		&car.FuelType, &car.Engine.EngineID, &car.Price, &car.Currency, &car.TotalPrice, &car.CreatedAt, &car.UpdatedAt,
+	}
+}
+
+// returningColumns is carColumns for INSERT and UPDATE, which cannot join
+// the reference names; the caller fills those in from resolveBrand.
+const returningColumns = `
+	id, name, year, brand_id, model_id, trim_id,
+	location_id, (SELECT l.name FROM locations l WHERE l.id = cars.location_id),
+	EXISTS (SELECT 1 FROM transfers tr WHERE tr.car_id = cars.id AND tr.status = 'in_transit'),
+	condition, odometer_km, condition_grade, previous_owners, accident_history,
+	registration_number, registration_date, registration_region,
+	condition = 'used' AND EXISTS (SELECT 1 FROM car_inspections ci WHERE ci.car_id = cars.id AND ci.passed),
+	fuel_type, engine_id, price, currency,
+	price + COALESCE((SELECT SUM(co.price) FROM car_options co WHERE co.car_id = cars.id), 0),
+	created_at, updated_at
+`
+
+func returningFields(car *models.Car) []any {
+	return []any{
+		&car.ID, &car.Name, &car.Year, &car.BrandID, &car.ModelID, &car.TrimID,
+		&car.LocationID, &car.Location, &car.InTransit,
+		&car.Condition, &car.OdometerKm, &car.ConditionGrade, &car.PreviousOwners, &car.AccidentHistory,
+		&car.RegistrationNumber, &car.RegistrationDate, &car.RegistrationRegion, &car.CertifiedPreOwned,
+		&car.FuelType, &car.Engine.EngineID, &car.Price, &car.Currency, &car.TotalPrice, &car.CreatedAt, &car.UpdatedAt,
+	}
+}
+
 func (s *CarStore) GetCarByID(ctx context.Context, id string) (models.Car, error) {
 	var car models.Car
 	log.Printf("I am in car store")
 
 	query := `
-		SELECT 
-			c.id, c.name, c.year, c.brand, c.fuel_type, c.engine_id, c.price, c.created_at, c.updated_at,
-			e.displacement, e.number_of_cylinders, e.car_range
-		FROM cars c
+		SELECT ` + carColumns + `,
+			` + enginestore.SpecColumns("e") + `
+		FROM ` + carTables + `
 		JOIN engines e ON c.engine_id = e.id
 		WHERE c.id = $1
 	`
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&car.ID,
-		&car.Name,
-		&car.Year,
-		&car.Brand,
-		&car.FuelType,
-		&car.Engine.EngineID,
-		&car.Price,
-		&car.CreatedAt,
-		&car.UpdatedAt,
-		&car.Engine.Displacement,
-		&car.Engine.NumberOfCylinders,
-		&car.Engine.CarRange,
-	)
+	err := s.db.QueryRowContext(ctx, query, id).Scan(append(carFields(&car), enginestore.SpecFields(&car.Engine.EngineSpec)...)...)
 	log.Println(err)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Car{}, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
 		return models.Car{}, err
 	}
 	return car, nil
 }
 
-func (s *CarStore) GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
+// GetCarsByBrand lists the cars of the brand a name or alias refers to that
+// match the rest of the filter. An unknown brand simply has no cars.
+func (s *CarStore) GetCarsByBrand(ctx context.Context, filter models.CarFilter) ([]models.Car, error) {
 	var cars []models.Car
 	var query string
-	log.Println(brand)
-	log.Println(isEngine)
+	log.Println(filter.Brand)
+	log.Println(filter.IsEngine)
 
-	if isEngine {
+	brandID, _, err := brandstore.ResolveBrand(ctx, s.db, filter.Brand)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return []models.Car{}, nil
+		}
+		return []models.Car{}, err
+	}
+
+	if filter.IsEngine {
 		query = `
-			SELECT c.id, c.name, c.year, c.brand, c.fuel_type, c.engine_id, c.price, c.created_at, c.updated_at, 
-			       e.displacement, e.number_of_cylinders, e.car_range
-			FROM cars c 
+			SELECT ` + carColumns + `,
+			       ` + enginestore.SpecColumns("e") + `
+			FROM ` + carTables + `
 			LEFT JOIN engines e ON c.engine_id = e.id
-			WHERE c.brand = $1
+			WHERE c.brand_id = $1
 		`
 	} else {
 		query = `
-			SELECT c.id, c.name, c.year, c.brand, c.fuel_type, c.engine_id, c.price, c.created_at, c.updated_at
-			FROM cars c
-			WHERE c.brand = $1
+			SELECT ` + carColumns + `
+			FROM ` + carTables + `
+			WHERE c.brand_id = $1
 		`
 	}
 
-	rows, err := s.db.QueryContext(ctx, query, brand)
+	where, args := carWhere(filter, []any{brandID})
+	query += where
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return []models.Car{}, err
 	}
@@ -84,20 +149,13 @@ func (s *CarStore) GetCarsByBrand(ctx context.Context, brand string, isEngine bo
 
 	for rows.Next() {
 		var car models.Car
-		if isEngine {
-			err := rows.Scan(
-				&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType,
-				&car.Engine.EngineID, &car.Price, &car.CreatedAt, &car.UpdatedAt,
-				&car.Engine.Displacement, &car.Engine.NumberOfCylinders, &car.Engine.CarRange,
-			)
+		if filter.IsEngine {
+			err := rows.Scan(append(carFields(&car), enginestore.SpecFields(&car.Engine.EngineSpec)...)...)
 			if err != nil {
 				return []models.Car{}, err
 			}
 		} else {
-			err := rows.Scan(
-				&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType,
-				&car.Engine.EngineID, &car.Price, &car.CreatedAt, &car.UpdatedAt,
-			)
+			err := rows.Scan(carFields(&car)...)
 			if err != nil {
 				return []models.Car{}, err
 			}
@@ -113,7 +171,49 @@ func (s *CarStore) GetCarsByBrand(ctx context.Context, brand string, isEngine bo
 	return cars, nil
 }
 
-func (s *CarStore) CreateCar(ctx context.Context, car *models.CarRequest) (models.Car, error) {
+// carWhere turns the optional parts of a filter into AND conditions,
+// numbering their placeholders after args.
+func carWhere(filter models.CarFilter, args []any) (string, []any) {
+	var where strings.Builder
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		where.WriteString("\n\t\t\tAND " + fmt.Sprintf(condition, len(args)))
+	}
+
+	for _, code := range filter.Options {
+		add(`EXISTS (
+				SELECT 1 FROM car_options co
+				JOIN options o ON o.id = co.option_id
+				WHERE co.car_id = c.id AND o.code = $%d
+			)`, code)
+	}
+	if filter.Condition != "" {
+		add("c.condition = $%d", filter.Condition)
+	}
+	if filter.MinOdometerKm != nil {
+		add("c.odometer_km >= $%d", *filter.MinOdometerKm)
+	}
+	if filter.MaxOdometerKm != nil {
+		add("c.odometer_km <= $%d", *filter.MaxOdometerKm)
+	}
+	if filter.MinGrade != nil {
+		add("c.condition_grade >= $%d", *filter.MinGrade)
+	}
+	if filter.MaxOwners != nil {
+		add("c.previous_owners <= $%d", *filter.MaxOwners)
+	}
+	if filter.AccidentHistory != "" {
+		add("c.accident_history = $%d", filter.AccidentHistory)
+	}
+	if filter.Certified != nil {
+		add(`(c.condition = 'used' AND EXISTS (
+				SELECT 1 FROM car_inspections ci WHERE ci.car_id = c.id AND ci.passed
+			)) = $%d`, *filter.Certified)
+	}
+	return where.String(), args
+}
+
+func (s *CarStore) CreateCar(ctx context.Context, car *models.CarRequest) (_ models.Car, err error) {
 	var newCar models.Car
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -129,41 +229,58 @@ func (s *CarStore) CreateCar(ctx context.Context, car *models.CarRequest) (model
 		}
 	}()
 
-	engineQuery := `
-		INSERT INTO engines (displacement, number_of_cylinders, car_range)
-		VALUES ($1, $2, $3)
-		RETURNING id
-	`
+	brand, err := resolveBrand(ctx, tx, car.Brand, car.Model, car.Trim)
+	if err != nil {
+		return models.Car{}, err
+	}
 
-	var engineID uuid.UUID
-	err = tx.QueryRowContext(ctx, engineQuery, car.Engine.Displacement, car.Engine.NumberOfCylinders, car.Engine.CarRange).Scan(&engineID)
+	engine, err := resolveEngine(ctx, tx, car.Engine, car.FuelType)
 	if err != nil {
 		return models.Car{}, err
 	}
 
+	if err = checkLocation(ctx, tx, car.LocationID); err != nil {
+		return models.Car{}, err
+	}
+
 	carQuery := `
-		INSERT INTO cars (name, year, brand, fuel_type, engine_id, price)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at
-	`
+		INSERT INTO cars (
+			name, year, brand_id, model_id, trim_id, location_id, fuel_type, engine_id, price, currency,
+			condition, odometer_km, condition_grade, previous_owners, accident_history,
+			registration_number, registration_date, registration_region
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''), $17, NULLIF($18, ''))
+		RETURNING ` + returningColumns
+
+	err = tx.QueryRowContext(ctx, carQuery,
+		car.Name, car.Year, brand.id, brand.modelID, brand.trimID, car.LocationID, car.FuelType, engine.EngineID, car.Price, car.Currency,
+		car.Condition, car.OdometerKm, car.ConditionGrade, car.PreviousOwners, car.AccidentHistory,
+		car.RegistrationNumber, car.RegistrationDate, car.RegistrationRegion,
+	).Scan(returningFields(&newCar)...)
+	if err != nil {
+		err = carWriteError(err)
+		return models.Car{}, err
+	}
 
-	err = tx.QueryRowContext(ctx, carQuery, car.Name, car.Year, car.Brand, car.FuelType, engineID, car.Price).Scan(
-		&newCar.ID, &newCar.Name, &newCar.Year, &newCar.Brand, &newCar.FuelType, 
-		&newCar.Engine.EngineID, &newCar.Price, &newCar.CreatedAt, &newCar.UpdatedAt,
-	)
+	err = price.RecordPriceChange(ctx, tx, newCar.ID, newCar.Price, newCar.Currency, nil, nil, models.PriceChangeInitial, nil)
 	if err != nil {
 		return models.Car{}, err
 	}
 
+	newCar.Engine = engine
+	newCar.Brand = brand.name
+	newCar.Model = brand.model
+	newCar.Trim = brand.trim
 
-	newCar.Engine.Displacement = car.Engine.Displacement
-	newCar.Engine.NumberOfCylinders = car.Engine.NumberOfCylinders
-	newCar.Engine.CarRange = car.Engine.CarRange
+	err = outbox.RecordEvent(ctx, tx, models.AggregateCar, newCar.ID, models.EventCarCreated, newCar)
+	if err != nil {
+		return models.Car{}, err
+	}
 
 	return newCar, nil
 }
 
-func (s *CarStore) UpdateCar(ctx context.Context, id string, car *models.CarRequest) (models.Car, error) {
+func (s *CarStore) UpdateCar(ctx context.Context, id string, car *models.CarRequest) (_ models.Car, err error) {
 	log.Println("I am in the store")
 	var updatedCar models.Car
 
@@ -181,56 +298,100 @@ func (s *CarStore) UpdateCar(ctx context.Context, id string, car *models.CarRequ
 	}()
 
 
-	if car.Engine.EngineID != uuid.Nil {
-		engineUpdateQuery := `
-			UPDATE engines 
-			SET displacement = $1, number_of_cylinders = $2, car_range = $3
-			WHERE id = $4
-		`
-		_, err = tx.ExecContext(ctx, engineUpdateQuery, 
-			car.Engine.Displacement, car.Engine.NumberOfCylinders, car.Engine.CarRange, car.Engine.EngineID)
-		if err != nil {
+	brand, err := resolveBrand(ctx, tx, car.Brand, car.Model, car.Trim)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	// Engines are shared, so an update never edits the engine row itself; it
+	// points the car at an existing engine or at the matching catalog entry.
+	engine, err := resolveEngine(ctx, tx, car.Engine, car.FuelType)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	var previousPrice models.Money
+	var previousCurrency string
+	var locationID *uuid.UUID
+	err = tx.QueryRowContext(ctx, `SELECT price, currency, location_id FROM cars WHERE id = $1 FOR UPDATE`, id).
+		Scan(&previousPrice, &previousCurrency, &locationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.Car{}, err
+	}
+
+	// Only a car without a location can be placed directly; moving one is a
+	// transfer so that its history stays complete.
+	if car.LocationID != nil && locationID != nil && *car.LocationID != *locationID {
+		err = fmt.Errorf("move the car with a transfer: %w", models.ErrConflict)
+		return models.Car{}, err
+	}
+	if locationID == nil {
+		if err = checkLocation(ctx, tx, car.LocationID); err != nil {
 			return models.Car{}, err
 		}
+		locationID = car.LocationID
 	}
 
+	// Fitted options are priced in the car's currency, so the currency can
+	// only change once they have been removed.
+	if car.Currency != previousCurrency {
+		var hasOptions bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM car_options WHERE car_id = $1)`, id).Scan(&hasOptions)
+		if err != nil {
+			return models.Car{}, err
+		}
+		if hasOptions {
+			err = fmt.Errorf("remove the car's options before changing its currency: %w", models.ErrConflict)
+			return models.Car{}, err
+		}
+	}
 
 	carUpdateQuery := `
 		UPDATE cars
-		SET name = $1, year = $2, brand = $3, fuel_type = $4, engine_id = $5, price = $6, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $7
-		RETURNING id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at
-	`
-
-	err = tx.QueryRowContext(ctx, carUpdateQuery, 
-		car.Name, car.Year, car.Brand, car.FuelType, car.Engine.EngineID, car.Price, id).Scan(
-		&updatedCar.ID, &updatedCar.Name, &updatedCar.Year, &updatedCar.Brand, 
-		&updatedCar.FuelType, &updatedCar.Engine.EngineID, &updatedCar.Price, 
-		&updatedCar.CreatedAt, &updatedCar.UpdatedAt,
-	)
+		SET name = $1, year = $2, brand_id = $3, model_id = $4, trim_id = $5, location_id = $6, fuel_type = $7,
+			engine_id = $8, price = $9, currency = $10,
+			condition = $11, odometer_km = $12, condition_grade = $13, previous_owners = $14, accident_history = $15,
+			registration_number = NULLIF($16, ''), registration_date = $17, registration_region = NULLIF($18, ''),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $19
+		RETURNING ` + returningColumns
+
+	err = tx.QueryRowContext(ctx, carUpdateQuery,
+		car.Name, car.Year, brand.id, brand.modelID, brand.trimID, locationID, car.FuelType, engine.EngineID, car.Price, car.Currency,
+		car.Condition, car.OdometerKm, car.ConditionGrade, car.PreviousOwners, car.AccidentHistory,
+		car.RegistrationNumber, car.RegistrationDate, car.RegistrationRegion, id,
+	).Scan(returningFields(&updatedCar)...)
 	if err != nil {
+		err = carWriteError(err)
 		return models.Car{}, err
 	}
 
+	if updatedCar.Price != previousPrice || updatedCar.Currency != previousCurrency {
+		err = price.RecordPriceChange(ctx, tx, updatedCar.ID, updatedCar.Price, updatedCar.Currency,
+			&previousPrice, &previousCurrency, models.PriceChangeManual, nil)
+		if err != nil {
+			return models.Car{}, err
+		}
+	}
 
-	engineQuery := `
-		SELECT displacement, number_of_cylinders, car_range
-		FROM engines
-		WHERE id = $1
-	`
-	err = tx.QueryRowContext(ctx, engineQuery, updatedCar.Engine.EngineID).Scan(
-		&updatedCar.Engine.Displacement, &updatedCar.Engine.NumberOfCylinders, &updatedCar.Engine.CarRange,
-	)
+	updatedCar.Engine = engine
+	updatedCar.Brand = brand.name
+	updatedCar.Model = brand.model
+	updatedCar.Trim = brand.trim
+	fmt.Print("Updated Car: ",updatedCar)
+
+	err = outbox.RecordEvent(ctx, tx, models.AggregateCar, updatedCar.ID, models.EventCarUpdated, updatedCar)
 	if err != nil {
 		return models.Car{}, err
 	}
-	fmt.Print("Updated Car: ",updatedCar)
-
 
 	return updatedCar, nil
 }
 
-func (s *CarStore) DeleteCar(ctx context.Context, id string) error {
+func (s *CarStore) DeleteCar(ctx context.Context, id string) (err error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -244,39 +405,263 @@ func (s *CarStore) DeleteCar(ctx context.Context, id string) error {
 		}
 	}()
 
-
-	var engineID uuid.UUID
-	getEngineQuery := `SELECT engine_id FROM cars WHERE id = $1`
-	err = tx.QueryRowContext(ctx, getEngineQuery, id).Scan(&engineID)
+	var carID uuid.UUID
+	carQuery := `DELETE FROM cars WHERE id = $1 RETURNING id`
+	err = tx.QueryRowContext(ctx, carQuery, id).Scan(&carID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("car not found")
+		switch {
+		case errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err):
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		case database.IsForeignKeyViolation(err) && database.ViolatedTable(err) == "attachments":
+			err = fmt.Errorf("car has attachments that must be deleted first: %w", models.ErrConflict)
+		case database.IsForeignKeyViolation(err):
+			err = fmt.Errorf("car has been sold and cannot be deleted: %w", models.ErrConflict)
 		}
 		return err
 	}
 
+	// Engines are catalog entries shared between cars, so they outlive the
+	// cars that use them.
+	return outbox.RecordEvent(ctx, tx, models.AggregateCar, carID, models.EventCarDeleted, models.DeletedPayload{ID: carID})
+}
 
-	carQuery := `DELETE FROM cars WHERE id = $1`
-	result, err := tx.ExecContext(ctx, carQuery, id)
+// GetCarsByEngineID lists the cars built on an engine.
+func (s *CarStore) GetCarsByEngineID(ctx context.Context, engineID string) ([]models.Car, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM engines WHERE id = $1)`, engineID).Scan(&exists)
 	if err != nil {
-		return err
+		if database.IsInvalidInput(err) {
+			return nil, fmt.Errorf("engine: %w", models.ErrNotFound)
+		}
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("engine: %w", models.ErrNotFound)
 	}
 
+	query := `
+		SELECT ` + carColumns + `,
+		       ` + enginestore.SpecColumns("e") + `
+		FROM ` + carTables + `
+		JOIN engines e ON c.engine_id = e.id
+		WHERE c.engine_id = $1
+		ORDER BY b.name, c.name
+	`
 
-	rowsAffected, err := result.RowsAffected()
+	rows, err := s.db.QueryContext(ctx, query, engineID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer rows.Close()
+
+	cars := []models.Car{}
+	for rows.Next() {
+		var car models.Car
+		err := rows.Scan(append(carFields(&car), enginestore.SpecFields(&car.Engine.EngineSpec)...)...)
+		if err != nil {
+			return nil, err
+		}
+		cars = append(cars, car)
 	}
-	if rowsAffected == 0 {
-		return errors.New("car not found")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	return cars, nil
+}
 
+// carWriteError maps a failed insert or update of a car onto a domain error.
+func carWriteError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("registration number is already used by another car: %w", models.ErrConflict)
+	}
+	return err
+}
 
-	engineQuery := `DELETE FROM engines WHERE id = $1`
-	_, err = tx.ExecContext(ctx, engineQuery, engineID)
+// GetCarsByLocation lists the cars kept at a location, including those on
+// their way out.
+func (s *CarStore) GetCarsByLocation(ctx context.Context, locationID string) ([]models.Car, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM locations WHERE id = $1)`, locationID).Scan(&exists)
 	if err != nil {
-		return err
+		if database.IsInvalidInput(err) {
+			return nil, fmt.Errorf("location: %w", models.ErrNotFound)
+		}
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("location: %w", models.ErrNotFound)
+	}
+
+	query := `
+		SELECT ` + carColumns + `
+		FROM ` + carTables + `
+		WHERE c.location_id = $1
+		ORDER BY b.name, c.name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cars := []models.Car{}
+	for rows.Next() {
+		var car models.Car
+		if err := rows.Scan(carFields(&car)...); err != nil {
+			return nil, err
+		}
+		cars = append(cars, car)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cars, nil
+}
+
+// GetCarsByIDs loads several cars with their engines and fitted options in
+// one query. Cars that do not exist are left out; the order is unspecified.
+func (s *CarStore) GetCarsByIDs(ctx context.Context, ids []string) ([]models.Car, error) {
+	query := `
+		SELECT ` + carColumns + `,
+		       ` + enginestore.SpecColumns("e") + `,
+		       COALESCE((
+		           SELECT json_agg(json_build_object(
+		               'option_id', o.id, 'code', o.code, 'name', o.name, 'category', o.category, 'price', co.price
+		           ) ORDER BY o.category, o.name)
+		           FROM car_options co
+		           JOIN options o ON o.id = co.option_id
+		           WHERE co.car_id = c.id
+		       ), '[]')
+		FROM ` + carTables + `
+		JOIN engines e ON c.engine_id = e.id
+		WHERE c.id = ANY($1::uuid[])
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cars := []models.Car{}
+	for rows.Next() {
+		var car models.Car
+		var options []byte
+		dest := append(carFields(&car), enginestore.SpecFields(&car.Engine.EngineSpec)...)
+		if err := rows.Scan(append(dest, &options)...); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(options, &car.Options); err != nil {
+			return nil, err
+		}
+		cars = append(cars, car)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cars, nil
+}
 
+// checkLocation rejects a location that does not exist. A nil location
+// leaves the car unplaced.
+func checkLocation(ctx context.Context, tx *sql.Tx, locationID *uuid.UUID) error {
+	if locationID == nil {
+		return nil
+	}
+	var exists bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM locations WHERE id = $1)`, *locationID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return models.ValidationError(fmt.Errorf("location %s does not exist", *locationID))
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// resolveEngine returns the engine a car should reference. A request naming
+// an engine_id must point at an existing engine that suits the car's fuel
+// type, and is locked so its powertrain cannot change before the car is
+// saved; otherwise the inline spec is looked up in the catalog and only
+// inserted when no identical engine exists.
+func resolveEngine(ctx context.Context, tx *sql.Tx, engine models.Engine, fuelType string) (models.Engine, error) {
+	if engine.EngineID != uuid.Nil {
+		var resolved models.Engine
+		query := `
+			SELECT ` + enginestore.Columns("") + `
+			FROM engines
+			WHERE id = $1
+			FOR SHARE
+		`
+		err := tx.QueryRowContext(ctx, query, engine.EngineID).Scan(enginestore.Fields(&resolved)...)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Engine{}, models.ValidationError(fmt.Errorf("engine %s does not exist", engine.EngineID))
+		}
+		if err != nil {
+			return models.Engine{}, err
+		}
+		if err := models.ValidateEngineForFuelType(resolved.EngineSpec, fuelType); err != nil {
+			return models.Engine{}, models.ValidationError(err)
+		}
+		return resolved, nil
+	}
+
+	return enginestore.Upsert(ctx, tx, engine.EngineSpec)
+}
+
+// carBrand is a car's brand and optional model line and trim as resolved from
+// the free-text names of a request.
+type carBrand struct {
+	id      uuid.UUID
+	name    string
+	modelID *uuid.UUID
+	model   *string
+	trimID  *uuid.UUID
+	trim    *string
+}
+
+// resolveBrand maps the brand, model and trim names of a request onto the
+// reference data. Unknown names are validation errors: reference data is
+// created through its own endpoints, not implicitly by cars.
+func resolveBrand(ctx context.Context, tx *sql.Tx, brand, model, trim string) (carBrand, error) {
+	var resolved carBrand
+	var err error
+
+	resolved.id, resolved.name, err = brandstore.ResolveBrand(ctx, tx, brand)
+	if errors.Is(err, models.ErrNotFound) {
+		return carBrand{}, models.ValidationError(fmt.Errorf("unknown brand %q", brand))
+	}
+	if err != nil {
+		return carBrand{}, err
+	}
+
+	if model == "" {
+		return resolved, nil
+	}
+
+	modelID, modelName, err := brandstore.ResolveModel(ctx, tx, resolved.id, model)
+	if errors.Is(err, models.ErrNotFound) {
+		return carBrand{}, models.ValidationError(fmt.Errorf("unknown %s model %q", resolved.name, model))
+	}
+	if err != nil {
+		return carBrand{}, err
+	}
+	resolved.modelID = &modelID
+	resolved.model = &modelName
+
+	if trim == "" {
+		return resolved, nil
+	}
+
+	trimID, trimName, err := brandstore.ResolveTrim(ctx, tx, modelID, trim)
+	if errors.Is(err, models.ErrNotFound) {
+		return carBrand{}, models.ValidationError(fmt.Errorf("unknown %s %s trim %q", resolved.name, modelName, trim))
+	}
+	if err != nil {
+		return carBrand{}, err
+	}
+	resolved.trimID = &trimID
+	resolved.trim = &trimName
+	return resolved, nil
+}
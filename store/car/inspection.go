@@ -0,0 +1,111 @@
+package car
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+)
+
+// GetInspection returns the certified pre-owned inspection of a car.
+func (s *CarStore) GetInspection(ctx context.Context, carID string) (models.Inspection, error) {
+	var inspection models.Inspection
+	var items []byte
+
+	query := `
+		SELECT car_id, inspector, inspected_on, items, passed, created_at, updated_at
+		FROM car_inspections
+		WHERE car_id = $1
+	`
+	err := s.db.QueryRowContext(ctx, query, carID).Scan(
+		&inspection.CarID, &inspection.Inspector, &inspection.InspectedOn, &items,
+		&inspection.Passed, &inspection.CreatedAt, &inspection.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Inspection{}, fmt.Errorf("inspection: %w", models.ErrNotFound)
+		}
+		return models.Inspection{}, err
+	}
+
+	if err := json.Unmarshal(items, &inspection.Items); err != nil {
+		return models.Inspection{}, err
+	}
+	return inspection, nil
+}
+
+// SetInspection records the inspection of a used car, replacing any earlier
+// one.
+func (s *CarStore) SetInspection(ctx context.Context, carID string, inspection *models.InspectionRequest, inspector string) (_ models.Inspection, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Inspection{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var condition string
+	err = tx.QueryRowContext(ctx, `SELECT condition FROM cars WHERE id = $1 FOR UPDATE`, carID).Scan(&condition)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.Inspection{}, err
+	}
+	if condition != models.ConditionUsed {
+		err = fmt.Errorf("only used cars can be certified: %w", models.ErrConflict)
+		return models.Inspection{}, err
+	}
+
+	items, err := json.Marshal(inspection.Items)
+	if err != nil {
+		return models.Inspection{}, err
+	}
+
+	query := `
+		INSERT INTO car_inspections (car_id, inspector, inspected_on, items, passed)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (car_id) DO UPDATE
+		SET inspector = EXCLUDED.inspector, inspected_on = EXCLUDED.inspected_on, items = EXCLUDED.items,
+			passed = EXCLUDED.passed, updated_at = CURRENT_TIMESTAMP
+		RETURNING car_id, inspector, inspected_on, passed, created_at, updated_at
+	`
+	saved := models.Inspection{Items: inspection.Items}
+	err = tx.QueryRowContext(ctx, query, carID, inspector, inspection.InspectedOn, items, models.InspectionPassed(inspection.Items)).Scan(
+		&saved.CarID, &saved.Inspector, &saved.InspectedOn, &saved.Passed, &saved.CreatedAt, &saved.UpdatedAt,
+	)
+	if err != nil {
+		return models.Inspection{}, err
+	}
+	return saved, nil
+}
+
+// DeleteInspection withdraws a car's certification.
+func (s *CarStore) DeleteInspection(ctx context.Context, carID string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM car_inspections WHERE car_id = $1`, carID)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("inspection: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("inspection: %w", models.ErrNotFound)
+	}
+	return nil
+}
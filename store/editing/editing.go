@@ -0,0 +1,246 @@
+package editing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+// Channel is the Postgres notification channel changes to who is editing a
+// car are announced on. The payload is the car ID.
+const Channel = "car_editing"
+
+type EditingStore struct {
+	db *sql.DB
+}
+
+func NewEditingStore(db *sql.DB) *EditingStore {
+	return &EditingStore{db: db}
+}
+
+// notify announces that the editors or lock of the cars changed.
+func (s *EditingStore) notify(ctx context.Context, carIDs ...uuid.UUID) error {
+	if len(carIDs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(carIDs))
+	for i, id := range carIDs {
+		ids[i] = id.String()
+	}
+	_, err := s.db.ExecContext(ctx, `SELECT pg_notify($1, id) FROM unnest($2::text[]) AS id`, Channel, ids)
+	return err
+}
+
+// notifyRows announces a change to the cars a query returns.
+func (s *EditingStore) notifyRows(ctx context.Context, query string, args ...any) (int, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var carIDs []uuid.UUID
+	for rows.Next() {
+		var carID uuid.UUID
+		if err := rows.Scan(&carID); err != nil {
+			return 0, err
+		}
+		carIDs = append(carIDs, carID)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return len(carIDs), s.notify(ctx, carIDs...)
+}
+
+// Join records that a session has a car open until ttl from now.
+func (s *EditingStore) Join(ctx context.Context, sessionID, carID uuid.UUID, username string, ttl time.Duration) error {
+	query := `
+		INSERT INTO car_editors (session_id, car_id, username, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4::bigint * INTERVAL '1 millisecond')
+		ON CONFLICT (session_id, car_id) DO UPDATE SET expires_at = EXCLUDED.expires_at
+	`
+	_, err := s.db.ExecContext(ctx, query, sessionID, carID, username, ttl.Milliseconds())
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return err
+	}
+	return s.notify(ctx, carID)
+}
+
+// Leave closes a car for a session, releasing its lock if the session holds
+// it.
+func (s *EditingStore) Leave(ctx context.Context, sessionID, carID uuid.UUID) error {
+	query := `
+		WITH editors AS (
+			DELETE FROM car_editors WHERE session_id = $1 AND car_id = $2 RETURNING car_id
+		), locks AS (
+			DELETE FROM car_edit_locks WHERE session_id = $1 AND car_id = $2 RETURNING car_id
+		)
+		SELECT car_id FROM editors UNION SELECT car_id FROM locks
+	`
+	_, err := s.notifyRows(ctx, query, sessionID, carID)
+	return err
+}
+
+// LeaveAll closes every car a session has open and releases its locks.
+func (s *EditingStore) LeaveAll(ctx context.Context, sessionID uuid.UUID) error {
+	query := `
+		WITH editors AS (
+			DELETE FROM car_editors WHERE session_id = $1 RETURNING car_id
+		), locks AS (
+			DELETE FROM car_edit_locks WHERE session_id = $1 RETURNING car_id
+		)
+		SELECT car_id FROM editors UNION SELECT car_id FROM locks
+	`
+	_, err := s.notifyRows(ctx, query, sessionID)
+	return err
+}
+
+// Touch keeps the cars the sessions have open for another ttl. Locks are
+// not renewed: their holders renew them by locking again.
+func (s *EditingStore) Touch(ctx context.Context, sessionIDs []uuid.UUID, ttl time.Duration) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(sessionIDs))
+	for i, id := range sessionIDs {
+		ids[i] = id.String()
+	}
+	query := `
+		UPDATE car_editors
+		SET expires_at = NOW() + $2::bigint * INTERVAL '1 millisecond'
+		WHERE session_id = ANY($1::uuid[])
+	`
+	_, err := s.db.ExecContext(ctx, query, ids, ttl.Milliseconds())
+	return err
+}
+
+// AcquireLock takes or renews the lock on a car for ttl from now. It
+// succeeds when the lock is free, has expired, or is held by the same user,
+// who may have the car open in another connection. Otherwise it returns the
+// lock that is held and false.
+func (s *EditingStore) AcquireLock(ctx context.Context, sessionID, carID uuid.UUID, username string, ttl time.Duration) (models.CarEditLock, bool, error) {
+	var lock models.CarEditLock
+	query := `
+		INSERT INTO car_edit_locks (car_id, session_id, username, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4::bigint * INTERVAL '1 millisecond')
+		ON CONFLICT (car_id) DO UPDATE
+		SET session_id = EXCLUDED.session_id,
+			username = EXCLUDED.username,
+			acquired_at = CASE
+				WHEN car_edit_locks.username = EXCLUDED.username AND car_edit_locks.expires_at > NOW()
+				THEN car_edit_locks.acquired_at ELSE NOW()
+			END,
+			expires_at = EXCLUDED.expires_at
+		WHERE car_edit_locks.username = EXCLUDED.username OR car_edit_locks.expires_at <= NOW()
+		RETURNING username, acquired_at, expires_at
+	`
+	err := s.db.QueryRowContext(ctx, query, carID, sessionID, username, ttl.Milliseconds()).Scan(
+		&lock.Username, &lock.AcquiredAt, &lock.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		held, err := s.getLock(ctx, carID)
+		if err != nil {
+			return models.CarEditLock{}, false, err
+		}
+		if held == nil {
+			// Released between the two queries; the caller may try again
+			return models.CarEditLock{}, false, fmt.Errorf("lock: %w", models.ErrConflict)
+		}
+		return *held, false, nil
+	}
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return models.CarEditLock{}, false, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.CarEditLock{}, false, err
+	}
+	return lock, true, s.notify(ctx, carID)
+}
+
+// ReleaseLock releases the lock on a car if the session holds it.
+func (s *EditingStore) ReleaseLock(ctx context.Context, sessionID, carID uuid.UUID) error {
+	query := `DELETE FROM car_edit_locks WHERE car_id = $1 AND session_id = $2 RETURNING car_id`
+	_, err := s.notifyRows(ctx, query, carID, sessionID)
+	return err
+}
+
+func (s *EditingStore) getLock(ctx context.Context, carID uuid.UUID) (*models.CarEditLock, error) {
+	var lock models.CarEditLock
+	query := `
+		SELECT username, acquired_at, expires_at
+		FROM car_edit_locks
+		WHERE car_id = $1 AND expires_at > NOW()
+	`
+	err := s.db.QueryRowContext(ctx, query, carID).Scan(&lock.Username, &lock.AcquiredAt, &lock.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// GetPresence returns who has a car open, each user once, in the order they
+// opened it, and its lock.
+func (s *EditingStore) GetPresence(ctx context.Context, carID uuid.UUID) (models.CarPresence, error) {
+	presence := models.CarPresence{Editors: []models.CarEditor{}}
+	query := `
+		SELECT username, MIN(joined_at) AS joined_at
+		FROM car_editors
+		WHERE car_id = $1 AND expires_at > NOW()
+		GROUP BY username
+		ORDER BY joined_at, username
+	`
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		return models.CarPresence{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var editor models.CarEditor
+		if err := rows.Scan(&editor.Username, &editor.JoinedAt); err != nil {
+			return models.CarPresence{}, err
+		}
+		presence.Editors = append(presence.Editors, editor)
+	}
+	if err := rows.Err(); err != nil {
+		return models.CarPresence{}, err
+	}
+
+	presence.Lock, err = s.getLock(ctx, carID)
+	if err != nil {
+		return models.CarPresence{}, err
+	}
+	return presence, nil
+}
+
+// PurgeExpired removes editors and locks that have run out, announcing the
+// cars they were on, and returns how many cars changed.
+func (s *EditingStore) PurgeExpired(ctx context.Context) (int, error) {
+	query := `
+		WITH editors AS (
+			DELETE FROM car_editors WHERE expires_at <= NOW() RETURNING car_id
+		), locks AS (
+			DELETE FROM car_edit_locks WHERE expires_at <= NOW() RETURNING car_id
+		)
+		SELECT car_id FROM editors UNION SELECT car_id FROM locks
+	`
+	return s.notifyRows(ctx, query)
+}
+
+// Listen listens on Channel; see database.Listen.
+func (s *EditingStore) Listen(ctx context.Context, ready func(), notify func(payload string)) error {
+	return database.Listen(ctx, s.db, Channel, ready, notify)
+}
@@ -4,7 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/LikhithMar14/management/database"
 	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/outbox"
+	"github.com/google/uuid"
 )
 
 
@@ -21,61 +27,167 @@ func (s *EngineStore) GetEngineByID(ctx context.Context, id string) (models.Engi
 	var engine models.Engine
 
 	query := `
-		SELECT id, displacement, number_of_cylinders, car_range
+		SELECT ` + Columns("") + `
 		FROM engines
 		WHERE id = $1
 	`
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(&engine.EngineID, &engine.Displacement, &engine.NumberOfCylinders, &engine.CarRange)
+	err := s.db.QueryRowContext(ctx, query, id).Scan(Fields(&engine)...)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return models.Engine{}, errors.New("engine not found")
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Engine{}, fmt.Errorf("engine: %w", models.ErrNotFound)
 		}
 		return models.Engine{}, err
 	}
 	return engine, nil
+}
+
+// GetEnginesByIDs returns the engines with the given IDs in one query, in no
+// particular order. IDs that match no engine are left out.
+func (s *EngineStore) GetEnginesByIDs(ctx context.Context, ids []string) ([]models.Engine, error) {
+	engines := []models.Engine{}
+	if len(ids) == 0 {
+		return engines, nil
 	}
-func (s *EngineStore)CreateEngine(ctx context.Context, engine *models.EngineRequest) (models.Engine, error) {
-	var newEngine models.Engine
 
 	query := `
-		INSERT INTO engines (displacement, number_of_cylinders, car_range)
-		VALUES ($1, $2, $3)
-		RETURNING id
+		SELECT ` + Columns("") + `
+		FROM engines
+		WHERE id = ANY($1::uuid[])
 	`
+
+	rows, err := s.db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var engine models.Engine
+		if err := rows.Scan(Fields(&engine)...); err != nil {
+			return nil, err
+		}
+		engines = append(engines, engine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return engines, nil
+}
+
+// CreateEngine returns the persisted engine. Identical specs are
+// deduplicated, so an existing engine may be returned instead of a new one.
+func (s *EngineStore) CreateEngine(ctx context.Context, engine *models.EngineRequest) (_ models.Engine, err error) {
 	if err := models.ValidateEngineRequest(*engine); err != nil {
 		return models.Engine{}, err
 	}
-	err := s.db.QueryRowContext(ctx, query, engine.Displacement, engine.NumberOfCylinders, engine.CarRange).Scan(&newEngine.EngineID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	newEngine, err := Upsert(ctx, tx, engine.EngineSpec)
 	if err != nil {
 		return models.Engine{}, err
 	}
 	return newEngine, nil
 }
 
-func (s *EngineStore)UpdateEngine(ctx context.Context, id string, engine *models.EngineRequest) (models.Engine, error) {
+func (s *EngineStore) UpdateEngine(ctx context.Context, id string, engine *models.EngineRequest) (_ models.Engine, err error) {
 	if err := models.ValidateEngineRequest(*engine); err != nil {
 		return models.Engine{}, err
 	}
 	var updatedEngine models.Engine
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// Lock the engine so no car can attach to it while the spec changes, then
+	// check the cars already on it can still run on the new powertrain
+	var engineID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM engines WHERE id = $1 FOR UPDATE`, id).Scan(&engineID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("engine: %w", models.ErrNotFound)
+		}
+		return models.Engine{}, err
+	}
+	fuelTypes, err := carFuelTypes(ctx, tx, id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+	for _, fuelType := range fuelTypes {
+		if want := models.PowertrainForFuelType(fuelType); want != engine.Powertrain {
+			err = fmt.Errorf("engine is used by %s cars, which need a %s powertrain: %w", fuelType, want, models.ErrConflict)
+			return models.Engine{}, err
+		}
+	}
+
 	query := `
 		UPDATE engines
-		SET displacement = $1, number_of_cylinders = $2, car_range = $3
-		WHERE id = $4
-	`
+		SET powertrain = $1, displacement = $2, number_of_cylinders = $3, car_range = $4, power_kw = $5,
+			torque_nm = $6, battery_kwh = $7, emissions_class = $8, co2_g_per_km = $9,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $10
+		RETURNING ` + Columns("")
 
-	err := s.db.QueryRowContext(ctx, query, engine.Displacement, engine.NumberOfCylinders, engine.CarRange, id).Scan(&updatedEngine.EngineID, &updatedEngine.Displacement, &updatedEngine.NumberOfCylinders, &updatedEngine.CarRange)
+	err = tx.QueryRowContext(ctx, query, append(SpecArgs(engine.EngineSpec), id)...).Scan(Fields(&updatedEngine)...)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return models.Engine{}, errors.New("engine not found")
+		switch {
+		case errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err):
+			err = fmt.Errorf("engine: %w", models.ErrNotFound)
+		case database.IsUniqueViolation(err):
+			err = fmt.Errorf("an engine with this spec already exists: %w", models.ErrConflict)
 		}
 		return models.Engine{}, err
 	}
+
+	err = outbox.RecordEvent(ctx, tx, models.AggregateEngine, updatedEngine.EngineID, models.EventEngineUpdated, updatedEngine)
+	if err != nil {
+		return models.Engine{}, err
+	}
 	return updatedEngine, nil
 }
 
-func (s *EngineStore)DeleteEngine(ctx context.Context, id string) error {
+// carFuelTypes lists the distinct fuel types of the cars on an engine.
+func carFuelTypes(ctx context.Context, tx *sql.Tx, engineID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT DISTINCT fuel_type FROM cars WHERE engine_id = $1 ORDER BY fuel_type`, engineID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fuelTypes []string
+	for rows.Next() {
+		var fuelType string
+		if err := rows.Scan(&fuelType); err != nil {
+			return nil, err
+		}
+		fuelTypes = append(fuelTypes, fuelType)
+	}
+	return fuelTypes, rows.Err()
+}
+
+func (s *EngineStore)DeleteEngine(ctx context.Context, id string) (err error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -88,17 +200,217 @@ func (s *EngineStore)DeleteEngine(ctx context.Context, id string) error {
 			err = tx.Commit()
 		}
 	}()	
+
+	var carCount int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM cars WHERE engine_id = $1`, id).Scan(&carCount)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			err = fmt.Errorf("engine: %w", models.ErrNotFound)
+		}
+		return err
+	}
+	if carCount > 0 {
+		err = fmt.Errorf("engine is used by %d car(s): %w", carCount, models.ErrConflict)
+		return err
+	}
+
 	query := `
 		DELETE FROM engines
 		WHERE id = $1
 	`
 
-	_, err = tx.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("engine not found")
+		if database.IsForeignKeyViolation(err) {
+			err = fmt.Errorf("engine is in use: %w", models.ErrConflict)
 		}
 		return err
 	}
-	return nil
-}
\ No newline at end of file
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("engine: %w", models.ErrNotFound)
+		return err
+	}
+
+	engineID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	err = outbox.RecordEvent(ctx, tx, models.AggregateEngine, engineID, models.EventEngineDeleted, models.DeletedPayload{ID: engineID})
+	return err
+}
+// engineSortColumns maps sort keys to SQL expressions; keys are validated by
+// the service, so only whitelisted expressions reach the query.
+var engineSortColumns = map[string]string{
+	models.EngineSortDisplacement: "e.displacement",
+	models.EngineSortCylinders:    "e.number_of_cylinders",
+	models.EngineSortRange:        "e.car_range",
+	models.EngineSortPower:        "e.power_kw",
+	models.EngineSortCreatedAt:    "e.created_at",
+	models.EngineSortCarCount:     "car_count",
+}
+
+// engineWhere builds the WHERE clause and arguments for filter.
+func engineWhere(filter models.EngineFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Powertrain != "" {
+		add("e.powertrain = $%d", filter.Powertrain)
+	}
+	if filter.MinDisplacement != nil {
+		add("e.displacement >= $%d", *filter.MinDisplacement)
+	}
+	if filter.MaxDisplacement != nil {
+		add("e.displacement <= $%d", *filter.MaxDisplacement)
+	}
+	if filter.Cylinders != nil {
+		add("e.number_of_cylinders = $%d", *filter.Cylinders)
+	}
+	if filter.MinRange != nil {
+		add("e.car_range >= $%d", *filter.MinRange)
+	}
+	if filter.MaxRange != nil {
+		add("e.car_range <= $%d", *filter.MaxRange)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func engineOrderBy(filter models.EngineFilter) string {
+	column, ok := engineSortColumns[filter.Sort]
+	if !ok {
+		column = "e.created_at"
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	// The id tiebreaker keeps pages stable when sort values repeat.
+	return fmt.Sprintf("ORDER BY %s %s, e.id", column, direction)
+}
+
+func (s *EngineStore) countEngines(ctx context.Context, where string, args []any) (int64, error) {
+	var total int64
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM engines e `+where, args...).Scan(&total)
+	return total, err
+}
+
+func (s *EngineStore) ListEngines(ctx context.Context, filter models.EngineFilter) (models.Page[models.Engine], error) {
+	where, args := engineWhere(filter)
+
+	total, err := s.countEngines(ctx, where, args)
+	if err != nil {
+		return models.Page[models.Engine]{}, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM engines e
+		%s
+		%s
+		LIMIT $%d OFFSET $%d
+	`, Columns("e"), where, engineOrderBy(filter), len(args)+1, len(args)+2)
+
+	rows, err := s.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return models.Page[models.Engine]{}, err
+	}
+	defer rows.Close()
+
+	engines := []models.Engine{}
+	for rows.Next() {
+		var engine models.Engine
+		if err := rows.Scan(Fields(&engine)...); err != nil {
+			return models.Page[models.Engine]{}, err
+		}
+		engines = append(engines, engine)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Engine]{}, err
+	}
+
+	return models.Page[models.Engine]{Items: engines, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// GetEngineUsage lists engines with the number of cars using each and their
+// average price per currency.
+func (s *EngineStore) GetEngineUsage(ctx context.Context, filter models.EngineFilter) (models.Page[models.EngineUsage], error) {
+	where, args := engineWhere(filter)
+
+	total, err := s.countEngines(ctx, where, args)
+	if err != nil {
+		return models.Page[models.EngineUsage]{}, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, COUNT(c.id) AS car_count
+		FROM engines e
+		LEFT JOIN cars c ON c.engine_id = e.id
+		%s
+		GROUP BY e.id
+		%s
+		LIMIT $%d OFFSET $%d
+	`, Columns("e"), where, engineOrderBy(filter), len(args)+1, len(args)+2)
+
+	rows, err := s.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return models.Page[models.EngineUsage]{}, err
+	}
+	defer rows.Close()
+
+	usage := []models.EngineUsage{}
+	index := map[string]int{}
+	var ids []string
+	for rows.Next() {
+		u := models.EngineUsage{AveragePrices: map[string]models.Money{}}
+		if err := rows.Scan(append(Fields(&u.Engine), &u.CarCount)...); err != nil {
+			return models.Page[models.EngineUsage]{}, err
+		}
+		index[u.EngineID.String()] = len(usage)
+		ids = append(ids, u.EngineID.String())
+		usage = append(usage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.EngineUsage]{}, err
+	}
+
+	if len(ids) > 0 {
+		priceQuery := `
+			SELECT engine_id, currency, ROUND(AVG(price), 2)
+			FROM cars
+			WHERE engine_id = ANY($1::uuid[])
+			GROUP BY engine_id, currency
+		`
+		priceRows, err := s.db.QueryContext(ctx, priceQuery, ids)
+		if err != nil {
+			return models.Page[models.EngineUsage]{}, err
+		}
+		defer priceRows.Close()
+
+		for priceRows.Next() {
+			var engineID, currency string
+			var average models.Money
+			if err := priceRows.Scan(&engineID, &currency, &average); err != nil {
+				return models.Page[models.EngineUsage]{}, err
+			}
+			usage[index[engineID]].AveragePrices[currency] = average
+		}
+		if err := priceRows.Err(); err != nil {
+			return models.Page[models.EngineUsage]{}, err
+		}
+	}
+
+	return models.Page[models.EngineUsage]{Items: usage, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
@@ -0,0 +1,390 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/storetest"
+)
+
+// testDB is the migrated database the tests share.
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	storetest.Main(m)
+}
+
+// newStore returns a store over emptied engines and outbox tables.
+func newStore(t *testing.T) *EngineStore {
+	t.Helper()
+	testDB = storetest.DB(t, "engines", "brands", "outbox")
+	return NewEngineStore(testDB)
+}
+
+func iceRequest(displacement int64) *models.EngineRequest {
+	return &models.EngineRequest{EngineSpec: models.EngineSpec{
+		Powertrain:        models.PowertrainICE,
+		Displacement:      displacement,
+		NumberOfCylinders: 4,
+		CarRange:          600,
+		PowerKW:           110,
+		TorqueNm:          250,
+		EmissionsClass:    "Euro 6d",
+		CO2GPerKm:         140,
+	}}
+}
+
+func insertCar(t *testing.T, engineID string) {
+	t.Helper()
+	_, err := testDB.Exec(`
+		WITH brand AS (
+			INSERT INTO brands (name) VALUES ('Test')
+			ON CONFLICT (reference_key(name)) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		)
+		INSERT INTO cars (name, year, brand_id, fuel_type, engine_id, price)
+		SELECT 'Test', '2024', id, 'petrol', $1, 1000 FROM brand
+	`, engineID)
+	if err != nil {
+		t.Fatalf("insert car: %v", err)
+	}
+}
+
+func TestCreateEngineReturnsPersistedRow(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	req := iceRequest(1998)
+	created, err := store.CreateEngine(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+
+	if created.EngineSpec != req.EngineSpec {
+		t.Errorf("spec = %+v, want %+v", created.EngineSpec, req.EngineSpec)
+	}
+	if created.CreatedAt.IsZero() || created.UpdatedAt.IsZero() {
+		t.Errorf("timestamps not returned: created_at=%v updated_at=%v", created.CreatedAt, created.UpdatedAt)
+	}
+
+	fetched, err := store.GetEngineByID(ctx, created.EngineID.String())
+	if err != nil {
+		t.Fatalf("GetEngineByID: %v", err)
+	}
+	if fetched != created {
+		t.Errorf("fetched %+v, want %+v", fetched, created)
+	}
+}
+
+func TestCreateEngineDeduplicatesIdenticalSpecs(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	first, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	second, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	if first.EngineID != second.EngineID {
+		t.Errorf("identical specs got ids %s and %s", first.EngineID, second.EngineID)
+	}
+
+	other, err := store.CreateEngine(ctx, iceRequest(2498))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	if other.EngineID == first.EngineID {
+		t.Error("different specs share an id")
+	}
+}
+
+func TestCreateEngineRejectsInvalidSpec(t *testing.T) {
+	store := newStore(t)
+
+	req := iceRequest(0)
+	if _, err := store.CreateEngine(context.Background(), req); err == nil {
+		t.Fatal("expected a validation error for zero displacement")
+	}
+}
+
+func TestGetEngineByIDNotFound(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"7f1a3c2e-0000-4000-8000-000000000000", "not-a-uuid"} {
+		if _, err := store.GetEngineByID(ctx, id); !errors.Is(err, models.ErrNotFound) {
+			t.Errorf("GetEngineByID(%q) error = %v, want ErrNotFound", id, err)
+		}
+	}
+}
+
+func TestGetEnginesByIDs(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	first, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	second, err := store.CreateEngine(ctx, iceRequest(2498))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+
+	engines, err := store.GetEnginesByIDs(ctx, []string{
+		first.EngineID.String(), second.EngineID.String(), "7f1a3c2e-0000-4000-8000-000000000000",
+	})
+	if err != nil {
+		t.Fatalf("GetEnginesByIDs: %v", err)
+	}
+	got := map[string]models.Engine{}
+	for _, engine := range engines {
+		got[engine.EngineID.String()] = engine
+	}
+	if len(got) != 2 || got[first.EngineID.String()] != first || got[second.EngineID.String()] != second {
+		t.Errorf("got %+v, want the two created engines", engines)
+	}
+}
+
+func TestUpdateEngine(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	created, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	req := iceRequest(2198)
+	req.PowerKW = 150
+	updated, err := store.UpdateEngine(ctx, created.EngineID.String(), req)
+	if err != nil {
+		t.Fatalf("UpdateEngine: %v", err)
+	}
+
+	if updated.EngineID != created.EngineID {
+		t.Errorf("id changed from %s to %s", created.EngineID, updated.EngineID)
+	}
+	if updated.EngineSpec != req.EngineSpec {
+		t.Errorf("spec = %+v, want %+v", updated.EngineSpec, req.EngineSpec)
+	}
+	if !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("created_at changed from %v to %v", created.CreatedAt, updated.CreatedAt)
+	}
+	if !updated.UpdatedAt.After(created.UpdatedAt) {
+		t.Errorf("updated_at %v not after %v", updated.UpdatedAt, created.UpdatedAt)
+	}
+}
+
+func TestUpdateEngineNotFound(t *testing.T) {
+	store := newStore(t)
+
+	_, err := store.UpdateEngine(context.Background(), "7f1a3c2e-0000-4000-8000-000000000000", iceRequest(1998))
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("UpdateEngine error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUpdateEngineToExistingSpecConflicts(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	if _, err := store.CreateEngine(ctx, iceRequest(1998)); err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	other, err := store.CreateEngine(ctx, iceRequest(2498))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+
+	_, err = store.UpdateEngine(ctx, other.EngineID.String(), iceRequest(1998))
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("UpdateEngine error = %v, want ErrConflict", err)
+	}
+}
+
+func TestUpdateEngineRejectsPowertrainUnfitForCars(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	created, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	insertCar(t, created.EngineID.String())
+
+	bev := &models.EngineRequest{EngineSpec: models.EngineSpec{
+		Powertrain:         models.PowertrainBEV,
+		CarRange:           450,
+		PowerKW:            150,
+		BatteryCapacityKWh: 77.4,
+	}}
+	_, err = store.UpdateEngine(ctx, created.EngineID.String(), bev)
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("UpdateEngine error = %v, want ErrConflict", err)
+	}
+
+	got, err := store.GetEngineByID(ctx, created.EngineID.String())
+	if err != nil {
+		t.Fatalf("GetEngineByID: %v", err)
+	}
+	if got.Powertrain != models.PowertrainICE {
+		t.Errorf("powertrain = %s after a rejected update", got.Powertrain)
+	}
+}
+
+func TestDeleteEngine(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	created, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	if err := store.DeleteEngine(ctx, created.EngineID.String()); err != nil {
+		t.Fatalf("DeleteEngine: %v", err)
+	}
+	if _, err := store.GetEngineByID(ctx, created.EngineID.String()); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("engine still present after delete: %v", err)
+	}
+	if err := store.DeleteEngine(ctx, created.EngineID.String()); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("second DeleteEngine error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDeleteEngineInUse(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	created, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	insertCar(t, created.EngineID.String())
+
+	if err := store.DeleteEngine(ctx, created.EngineID.String()); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("DeleteEngine error = %v, want ErrConflict", err)
+	}
+}
+
+func TestListEnginesFiltersAndSorts(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	for _, displacement := range []int64{1199, 1498, 1998, 2998} {
+		if _, err := store.CreateEngine(ctx, iceRequest(displacement)); err != nil {
+			t.Fatalf("CreateEngine: %v", err)
+		}
+	}
+
+	min, max := int64(1400), int64(2500)
+	page, err := store.ListEngines(ctx, models.EngineFilter{
+		MinDisplacement: &min,
+		MaxDisplacement: &max,
+		Sort:            models.EngineSortDisplacement,
+		Descending:      true,
+		Limit:           1,
+	})
+	if err != nil {
+		t.Fatalf("ListEngines: %v", err)
+	}
+	if page.Total != 2 {
+		t.Errorf("total = %d, want 2", page.Total)
+	}
+	if len(page.Items) != 1 || page.Items[0].Displacement != 1998 {
+		t.Errorf("items = %+v, want the 1998cc engine", page.Items)
+	}
+}
+
+func TestGetEngineUsage(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	used, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	if _, err := store.CreateEngine(ctx, iceRequest(2998)); err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	insertCar(t, used.EngineID.String())
+	insertCar(t, used.EngineID.String())
+
+	page, err := store.GetEngineUsage(ctx, models.EngineFilter{
+		Sort:       models.EngineSortCarCount,
+		Descending: true,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("GetEngineUsage: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("got %d engines, want 2", len(page.Items))
+	}
+	top := page.Items[0]
+	if top.EngineID != used.EngineID || top.CarCount != 2 {
+		t.Errorf("top engine = %s with %d cars, want %s with 2", top.EngineID, top.CarCount, used.EngineID)
+	}
+	if got := top.AveragePrices[models.DefaultCurrency]; got != 100000 {
+		t.Errorf("average price = %s, want 1000.00", got)
+	}
+}
+
+// outboxEvents returns the types of the events recorded for an aggregate, in
+// order.
+func outboxEvents(t *testing.T, aggregateID string) []string {
+	t.Helper()
+	rows, err := testDB.Query(`SELECT event_type FROM outbox WHERE aggregate_id = $1 ORDER BY id`, aggregateID)
+	if err != nil {
+		t.Fatalf("query outbox: %v", err)
+	}
+	defer rows.Close()
+
+	var types []string
+	for rows.Next() {
+		var eventType string
+		if err := rows.Scan(&eventType); err != nil {
+			t.Fatalf("scan outbox: %v", err)
+		}
+		types = append(types, eventType)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("query outbox: %v", err)
+	}
+	return types
+}
+
+func TestEngineChangesRecordEvents(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	created, err := store.CreateEngine(ctx, iceRequest(1998))
+	if err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	// An identical spec returns the existing engine without creating one.
+	if _, err := store.CreateEngine(ctx, iceRequest(1998)); err != nil {
+		t.Fatalf("CreateEngine: %v", err)
+	}
+	if _, err := store.UpdateEngine(ctx, created.EngineID.String(), iceRequest(2198)); err != nil {
+		t.Fatalf("UpdateEngine: %v", err)
+	}
+	if err := store.DeleteEngine(ctx, created.EngineID.String()); err != nil {
+		t.Fatalf("DeleteEngine: %v", err)
+	}
+
+	got := outboxEvents(t, created.EngineID.String())
+	want := []string{models.EventEngineCreated, models.EventEngineUpdated, models.EventEngineDeleted}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/outbox"
+)
+
+// specColumns are the engines columns making up an EngineSpec, in the order
+// used by SpecFields and SpecArgs. Together they form the deduplication key.
+var specColumns = []string{
+	"powertrain", "displacement", "number_of_cylinders", "car_range", "power_kw",
+	"torque_nm", "battery_kwh", "emissions_class", "co2_g_per_km",
+}
+
+// SpecColumns returns the spec columns, each qualified with alias when it is
+// not empty, as a comma separated list.
+func SpecColumns(alias string) string {
+	if alias == "" {
+		return strings.Join(specColumns, ", ")
+	}
+	qualified := make([]string, len(specColumns))
+	for i, c := range specColumns {
+		qualified[i] = alias + "." + c
+	}
+	return strings.Join(qualified, ", ")
+}
+
+// SpecPlaceholders returns "$n, $n+1, ..." for the spec columns starting at n.
+func SpecPlaceholders(n int) string {
+	placeholders := make([]string, len(specColumns))
+	for i := range specColumns {
+		placeholders[i] = "$" + strconv.Itoa(n+i)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// SpecFields returns scan destinations for the spec columns.
+func SpecFields(spec *models.EngineSpec) []any {
+	return []any{
+		&spec.Powertrain, &spec.Displacement, &spec.NumberOfCylinders, &spec.CarRange, &spec.PowerKW,
+		&spec.TorqueNm, &spec.BatteryCapacityKWh, &spec.EmissionsClass, &spec.CO2GPerKm,
+	}
+}
+
+// SpecArgs returns query arguments for the spec columns.
+func SpecArgs(spec models.EngineSpec) []any {
+	return []any{
+		spec.Powertrain, spec.Displacement, spec.NumberOfCylinders, spec.CarRange, spec.PowerKW,
+		spec.TorqueNm, spec.BatteryCapacityKWh, spec.EmissionsClass, spec.CO2GPerKm,
+	}
+}
+
+// Columns returns every column of an engine row, qualified with alias when
+// it is not empty, in the order used by Fields.
+func Columns(alias string) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	return prefix + "id, " + SpecColumns(alias) + ", " + prefix + "created_at, " + prefix + "updated_at"
+}
+
+// Fields returns scan destinations for Columns.
+func Fields(engine *models.Engine) []any {
+	fields := append([]any{&engine.EngineID}, SpecFields(&engine.EngineSpec)...)
+	return append(fields, &engine.CreatedAt, &engine.UpdatedAt)
+}
+
+// upsertQuery inserts a spec, or returns the identical engine already in
+// the catalog. The no-op update makes RETURNING yield the existing row. After
+// Columns it returns whether the row was inserted: xmax is only zero for a
+// row version this statement created.
+func upsertQuery() string {
+	return `
+		INSERT INTO engines (` + SpecColumns("") + `)
+		VALUES (` + SpecPlaceholders(1) + `)
+		ON CONFLICT ON CONSTRAINT engines_spec_key
+		DO UPDATE SET powertrain = EXCLUDED.powertrain
+		RETURNING ` + Columns("") + `, xmax = 0`
+}
+
+// Upsert runs upsertQuery within tx and records an engine.created event when
+// the engine is new to the catalog.
+func Upsert(ctx context.Context, tx *sql.Tx, spec models.EngineSpec) (models.Engine, error) {
+	var engine models.Engine
+	var inserted bool
+	err := tx.QueryRowContext(ctx, upsertQuery(), SpecArgs(spec)...).Scan(append(Fields(&engine), &inserted)...)
+	if err != nil {
+		return models.Engine{}, err
+	}
+	if inserted {
+		err = outbox.RecordEvent(ctx, tx, models.AggregateEngine, engine.EngineID, models.EventEngineCreated, engine)
+		if err != nil {
+			return models.Engine{}, err
+		}
+	}
+	return engine, nil
+}
@@ -0,0 +1,64 @@
+package eventstream
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+)
+
+// Channel is the Postgres notification channel relayed events are announced
+// on. The payload is the outbox ID of the event.
+const Channel = "inventory_events"
+
+type EventStreamStore struct {
+	db *sql.DB
+}
+
+func NewEventStreamStore(db *sql.DB) *EventStreamStore {
+	return &EventStreamStore{db: db}
+}
+
+// NotifyEvent announces an event to every server listening on Channel once
+// tx commits. Nothing is sent if it rolls back.
+func NotifyEvent(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, Channel, strconv.FormatInt(eventID, 10))
+	return err
+}
+
+// GetStreamEvent loads an announced event from the outbox, with the current
+// brand and location of its car.
+func (s *EventStreamStore) GetStreamEvent(ctx context.Context, eventID int64) (models.StreamEvent, error) {
+	var event models.StreamEvent
+	var payload []byte
+	var brand sql.NullString
+	query := `
+		SELECT o.id, o.event_type, o.aggregate_type, o.aggregate_id, o.payload, o.created_at, b.name, c.location_id
+		FROM outbox o
+		LEFT JOIN cars c ON o.aggregate_type = 'car' AND c.id = o.aggregate_id
+		LEFT JOIN brands b ON b.id = c.brand_id
+		WHERE o.id = $1
+	`
+	err := s.db.QueryRowContext(ctx, query, eventID).Scan(
+		&event.ID, &event.Type, &event.AggregateType, &event.AggregateID, &payload, &event.OccurredAt,
+		&brand, &event.LocationID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.StreamEvent{}, fmt.Errorf("event: %w", models.ErrNotFound)
+		}
+		return models.StreamEvent{}, err
+	}
+	event.Payload = payload
+	event.Brand = brand.String
+	return event, nil
+}
+
+// Listen listens on Channel; see database.Listen.
+func (s *EventStreamStore) Listen(ctx context.Context, ready func(), notify func(payload string)) error {
+	return database.Listen(ctx, s.db, Channel, ready, notify)
+}
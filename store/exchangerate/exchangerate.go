@@ -0,0 +1,109 @@
+package exchangerate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+type ExchangeRateStore struct {
+	db *sql.DB
+}
+
+func NewExchangeRateStore(db *sql.DB) *ExchangeRateStore {
+	return &ExchangeRateStore{db: db}
+}
+
+func (s *ExchangeRateStore) ListExchangeRates(ctx context.Context) ([]models.ExchangeRate, error) {
+	query := `
+		SELECT id, base_currency, quote_currency, rate, effective_date, created_at
+		FROM exchange_rates
+		ORDER BY base_currency, quote_currency, effective_date DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []models.ExchangeRate{}
+	for rows.Next() {
+		var rate models.ExchangeRate
+		if err := rows.Scan(&rate.ID, &rate.BaseCurrency, &rate.QuoteCurrency, &rate.Rate, &rate.EffectiveDate, &rate.CreatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// GetExchangeRate returns the base→quote rate in effect on asOf, that is the
+// one with the latest effective date not after it.
+func (s *ExchangeRateStore) GetExchangeRate(ctx context.Context, base, quote string, asOf time.Time) (models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+
+	query := `
+		SELECT id, base_currency, quote_currency, rate, effective_date, created_at
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND effective_date <= $3
+		ORDER BY effective_date DESC
+		LIMIT 1
+	`
+
+	err := s.db.QueryRowContext(ctx, query, base, quote, asOf).Scan(
+		&rate.ID, &rate.BaseCurrency, &rate.QuoteCurrency, &rate.Rate, &rate.EffectiveDate, &rate.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ExchangeRate{}, fmt.Errorf("exchange rate %s/%s: %w", base, quote, models.ErrNotFound)
+		}
+		return models.ExchangeRate{}, err
+	}
+	return rate, nil
+}
+
+// UpsertExchangeRates stores all rates in one transaction, replacing any
+// existing rate for the same pair and effective date.
+func (s *ExchangeRateStore) UpsertExchangeRates(ctx context.Context, rates []models.ExchangeRate) (_ []models.ExchangeRate, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO exchange_rates (base_currency, quote_currency, rate, effective_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (base_currency, quote_currency, effective_date)
+		DO UPDATE SET rate = EXCLUDED.rate
+		RETURNING id, base_currency, quote_currency, rate, effective_date, created_at
+	`
+
+	saved := make([]models.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		var r models.ExchangeRate
+		err = tx.QueryRowContext(ctx, query, rate.BaseCurrency, rate.QuoteCurrency, rate.Rate, rate.EffectiveDate).Scan(
+			&r.ID, &r.BaseCurrency, &r.QuoteCurrency, &r.Rate, &r.EffectiveDate, &r.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, r)
+	}
+	return saved, nil
+}
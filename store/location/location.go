@@ -0,0 +1,393 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+type LocationStore struct {
+	db *sql.DB
+}
+
+func NewLocationStore(db *sql.DB) *LocationStore {
+	return &LocationStore{db: db}
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// locationColumns selects a location with the number of cars kept there.
+const locationColumns = `
+	l.id, l.code, l.name, l.kind, l.address,
+	(SELECT COUNT(*) FROM cars c WHERE c.location_id = l.id),
+	l.created_at, l.updated_at
+`
+
+func locationFields(location *models.Location) []any {
+	return []any{
+		&location.ID, &location.Code, &location.Name, &location.Kind, &location.Address,
+		&location.CarCount, &location.CreatedAt, &location.UpdatedAt,
+	}
+}
+
+func (s *LocationStore) ListLocations(ctx context.Context) ([]models.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM locations l ORDER BY l.name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []models.Location{}
+	for rows.Next() {
+		var location models.Location
+		if err := rows.Scan(locationFields(&location)...); err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (s *LocationStore) GetLocationByID(ctx context.Context, id string) (models.Location, error) {
+	return getLocation(ctx, s.db, id)
+}
+
+func getLocation(ctx context.Context, q querier, id string) (models.Location, error) {
+	var location models.Location
+	query := `SELECT ` + locationColumns + ` FROM locations l WHERE l.id = $1`
+
+	err := q.QueryRowContext(ctx, query, id).Scan(locationFields(&location)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Location{}, fmt.Errorf("location: %w", models.ErrNotFound)
+		}
+		return models.Location{}, err
+	}
+	return location, nil
+}
+
+func (s *LocationStore) CreateLocation(ctx context.Context, location *models.LocationRequest) (models.Location, error) {
+	query := `
+		INSERT INTO locations (code, name, kind, address)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.db.QueryRowContext(ctx, query, location.Code, location.Name, location.Kind, location.Address).Scan(&id)
+	if err != nil {
+		return models.Location{}, locationWriteError(err)
+	}
+	return getLocation(ctx, s.db, id.String())
+}
+
+func (s *LocationStore) UpdateLocation(ctx context.Context, id string, location *models.LocationRequest) (models.Location, error) {
+	query := `
+		UPDATE locations
+		SET code = $1, name = $2, kind = $3, address = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+		RETURNING id
+	`
+
+	var locationID uuid.UUID
+	err := s.db.QueryRowContext(ctx, query, location.Code, location.Name, location.Kind, location.Address, id).Scan(&locationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Location{}, fmt.Errorf("location: %w", models.ErrNotFound)
+		}
+		return models.Location{}, locationWriteError(err)
+	}
+	return getLocation(ctx, s.db, locationID.String())
+}
+
+// DeleteLocation removes a location that holds no cars and appears in no
+// transfer.
+func (s *LocationStore) DeleteLocation(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM locations WHERE id = $1`, id)
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			if database.ViolatedTable(err) == "transfers" {
+				return fmt.Errorf("location appears in the transfer history: %w", models.ErrConflict)
+			}
+			return fmt.Errorf("location still holds cars: %w", models.ErrConflict)
+		}
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("location: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("location: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+func locationWriteError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("location code is already taken: %w", models.ErrConflict)
+	}
+	return err
+}
+
+// transferColumns selects a transfer with the names of both locations.
+const transferColumns = `
+	t.id, t.car_id, t.from_location_id, lf.name, t.to_location_id, lt.name,
+	t.status, t.notes, t.requested_by, t.completed_by, t.dispatched_at, t.completed_at
+`
+
+const transferTables = `
+	transfers t
+	JOIN locations lf ON lf.id = t.from_location_id
+	JOIN locations lt ON lt.id = t.to_location_id
+`
+
+func transferFields(transfer *models.Transfer) []any {
+	return []any{
+		&transfer.ID, &transfer.CarID, &transfer.FromLocationID, &transfer.FromLocation,
+		&transfer.ToLocationID, &transfer.ToLocation, &transfer.Status, &transfer.Notes,
+		&transfer.RequestedBy, &transfer.CompletedBy, &transfer.DispatchedAt, &transfer.CompletedAt,
+	}
+}
+
+func transferWhere(filter models.TransferFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.CarID != "" {
+		add("t.car_id = $%d", filter.CarID)
+	}
+	if filter.LocationID != "" {
+		add("$%d IN (t.from_location_id, t.to_location_id)", filter.LocationID)
+	}
+	if filter.Status != "" {
+		add("t.status = $%d", filter.Status)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// ListTransfers returns the transfer history, most recent first.
+func (s *LocationStore) ListTransfers(ctx context.Context, filter models.TransferFilter) (models.Page[models.Transfer], error) {
+	where, args := transferWhere(filter)
+
+	var total int64
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM transfers t `+where, args...).Scan(&total)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return models.Page[models.Transfer]{}, models.ValidationError(errors.New("car_id and location_id must be UUIDs"))
+		}
+		return models.Page[models.Transfer]{}, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		ORDER BY t.dispatched_at DESC, t.id
+		LIMIT $%d OFFSET $%d
+	`, transferColumns, transferTables, where, len(args)+1, len(args)+2)
+
+	rows, err := s.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return models.Page[models.Transfer]{}, err
+	}
+	defer rows.Close()
+
+	transfers := []models.Transfer{}
+	for rows.Next() {
+		var transfer models.Transfer
+		if err := rows.Scan(transferFields(&transfer)...); err != nil {
+			return models.Page[models.Transfer]{}, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Transfer]{}, err
+	}
+
+	return models.Page[models.Transfer]{Items: transfers, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+func (s *LocationStore) GetTransferByID(ctx context.Context, id string) (models.Transfer, error) {
+	return getTransfer(ctx, s.db, id)
+}
+
+func getTransfer(ctx context.Context, q querier, id string) (models.Transfer, error) {
+	var transfer models.Transfer
+	query := `SELECT ` + transferColumns + ` FROM ` + transferTables + ` WHERE t.id = $1`
+
+	err := q.QueryRowContext(ctx, query, id).Scan(transferFields(&transfer)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Transfer{}, fmt.Errorf("transfer: %w", models.ErrNotFound)
+		}
+		return models.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+// CreateTransfer dispatches a car from its current location. The car stays
+// at its origin until the transfer is received.
+func (s *LocationStore) CreateTransfer(ctx context.Context, transfer *models.TransferRequest, requestedBy string) (_ models.Transfer, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var from *uuid.UUID
+	var sold bool
+	carQuery := `
+		SELECT location_id, EXISTS (SELECT 1 FROM orders o WHERE o.car_id = cars.id)
+		FROM cars
+		WHERE id = $1
+		FOR UPDATE
+	`
+	err = tx.QueryRowContext(ctx, carQuery, transfer.CarID).Scan(&from, &sold)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.Transfer{}, err
+	}
+
+	switch {
+	case sold:
+		err = fmt.Errorf("car has been sold: %w", models.ErrConflict)
+	case from == nil:
+		err = fmt.Errorf("car has no location to transfer from: %w", models.ErrConflict)
+	case *from == transfer.ToLocationID:
+		err = models.ValidationError(errors.New("car is already at that location"))
+	}
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	query := `
+		INSERT INTO transfers (car_id, from_location_id, to_location_id, notes, requested_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, query, transfer.CarID, *from, transfer.ToLocationID, transfer.Notes, requestedBy).Scan(&id)
+	if err != nil {
+		switch {
+		case database.IsUniqueViolation(err):
+			err = fmt.Errorf("car is already in transit: %w", models.ErrConflict)
+		case database.IsForeignKeyViolation(err):
+			err = models.ValidationError(fmt.Errorf("location %s does not exist", transfer.ToLocationID))
+		}
+		return models.Transfer{}, err
+	}
+
+	created, err := getTransfer(ctx, tx, id.String())
+	if err != nil {
+		return models.Transfer{}, err
+	}
+	return created, nil
+}
+
+// ReceiveTransfer confirms a car arrived and moves it to the destination.
+func (s *LocationStore) ReceiveTransfer(ctx context.Context, id, receivedBy string) (models.Transfer, error) {
+	return s.completeTransfer(ctx, id, models.TransferReceived, receivedBy)
+}
+
+// CancelTransfer calls off a transfer; the car stays at its origin.
+func (s *LocationStore) CancelTransfer(ctx context.Context, id, cancelledBy string) (models.Transfer, error) {
+	return s.completeTransfer(ctx, id, models.TransferCancelled, cancelledBy)
+}
+
+func (s *LocationStore) completeTransfer(ctx context.Context, id, status, completedBy string) (_ models.Transfer, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// Lock the car before the transfer, in the same order CreateTransfer
+	// takes its locks.
+	var carID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		SELECT c.id FROM transfers t JOIN cars c ON c.id = t.car_id WHERE t.id = $1 FOR UPDATE OF c
+	`, id).Scan(&carID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("transfer: %w", models.ErrNotFound)
+		}
+		return models.Transfer{}, err
+	}
+
+	var current string
+	var to uuid.UUID
+	err = tx.QueryRowContext(ctx, `SELECT status, to_location_id FROM transfers WHERE id = $1 FOR UPDATE`, id).Scan(&current, &to)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+	if current != models.TransferInTransit {
+		err = fmt.Errorf("transfer is already %s: %w", current, models.ErrConflict)
+		return models.Transfer{}, err
+	}
+
+	if status == models.TransferReceived {
+		_, err = tx.ExecContext(ctx, `UPDATE cars SET location_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, to, carID)
+		if err != nil {
+			return models.Transfer{}, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE transfers
+		SET status = $1, completed_by = $2, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, status, completedBy, id)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	completed, err := getTransfer(ctx, tx, id)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+	return completed, nil
+}
@@ -0,0 +1,344 @@
+package option
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+type OptionStore struct {
+	db *sql.DB
+}
+
+func NewOptionStore(db *sql.DB) *OptionStore {
+	return &OptionStore{db: db}
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// optionColumns selects an option together with the ids of the options it is
+// incompatible with, as a JSON array.
+const optionColumns = `
+	o.id, o.code, o.name, o.category, o.price, o.currency,
+	to_json(ARRAY(
+		SELECT CASE WHEN i.option_a = o.id THEN i.option_b ELSE i.option_a END
+		FROM option_incompatibilities i
+		WHERE o.id IN (i.option_a, i.option_b)
+		ORDER BY 1
+	)),
+	o.created_at, o.updated_at
+`
+
+func scanOption(row interface{ Scan(...any) error }) (models.Option, error) {
+	var option models.Option
+	var incompatible []byte
+	err := row.Scan(
+		&option.ID, &option.Code, &option.Name, &option.Category, &option.Price, &option.Currency,
+		&incompatible, &option.CreatedAt, &option.UpdatedAt,
+	)
+	if err != nil {
+		return models.Option{}, err
+	}
+	if err := json.Unmarshal(incompatible, &option.IncompatibleWith); err != nil {
+		return models.Option{}, err
+	}
+	return option, nil
+}
+
+// ListOptions returns the catalog, optionally limited to one category.
+func (s *OptionStore) ListOptions(ctx context.Context, category string) ([]models.Option, error) {
+	query := `
+		SELECT ` + optionColumns + `
+		FROM options o
+		WHERE $1 = '' OR o.category = $1
+		ORDER BY o.category, o.name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	options := []models.Option{}
+	for rows.Next() {
+		option, err := scanOption(rows)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+func (s *OptionStore) GetOptionByID(ctx context.Context, id string) (models.Option, error) {
+	return getOption(ctx, s.db, id)
+}
+
+func getOption(ctx context.Context, q querier, id string) (models.Option, error) {
+	query := `SELECT ` + optionColumns + ` FROM options o WHERE o.id = $1`
+
+	option, err := scanOption(q.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Option{}, fmt.Errorf("option %s: %w", id, models.ErrNotFound)
+		}
+		return models.Option{}, err
+	}
+	return option, nil
+}
+
+func (s *OptionStore) CreateOption(ctx context.Context, option *models.OptionRequest) (_ models.Option, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Option{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO options (code, name, category, price, currency)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, query, option.Code, option.Name, option.Category, option.Price, option.Currency).Scan(&id)
+	if err != nil {
+		return models.Option{}, optionWriteError(err)
+	}
+
+	if err = setIncompatibilities(ctx, tx, id, option.IncompatibleWith); err != nil {
+		return models.Option{}, err
+	}
+
+	created, err := getOption(ctx, tx, id.String())
+	if err != nil {
+		return models.Option{}, err
+	}
+	return created, nil
+}
+
+// UpdateOption edits a catalog entry and replaces its incompatibilities. Cars
+// already fitted with the option keep the price they were sold with.
+func (s *OptionStore) UpdateOption(ctx context.Context, id string, option *models.OptionRequest) (_ models.Option, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Option{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+		UPDATE options
+		SET code = $1, name = $2, category = $3, price = $4, currency = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+		RETURNING id
+	`
+	var optionID uuid.UUID
+	err = tx.QueryRowContext(ctx, query, option.Code, option.Name, option.Category, option.Price, option.Currency, id).Scan(&optionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			err = fmt.Errorf("option: %w", models.ErrNotFound)
+			return models.Option{}, err
+		}
+		return models.Option{}, optionWriteError(err)
+	}
+
+	if err = setIncompatibilities(ctx, tx, optionID, option.IncompatibleWith); err != nil {
+		return models.Option{}, err
+	}
+
+	updated, err := getOption(ctx, tx, id)
+	if err != nil {
+		return models.Option{}, err
+	}
+	return updated, nil
+}
+
+// DeleteOption removes an option that no car is fitted with.
+func (s *OptionStore) DeleteOption(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM options WHERE id = $1`, id)
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return fmt.Errorf("option is fitted to cars: %w", models.ErrConflict)
+		}
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("option: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("option: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// setIncompatibilities replaces the options id cannot be combined with. Each
+// pair is stored once, smallest id first.
+func setIncompatibilities(ctx context.Context, tx *sql.Tx, id uuid.UUID, others []uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM option_incompatibilities WHERE $1 IN (option_a, option_b)`, id)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO option_incompatibilities (option_a, option_b)
+		VALUES (LEAST($1::uuid, $2::uuid), GREATEST($1::uuid, $2::uuid))
+		ON CONFLICT DO NOTHING
+	`
+	for _, other := range others {
+		if other == id {
+			return models.ValidationError(errors.New("an option cannot be incompatible with itself"))
+		}
+		if _, err := tx.ExecContext(ctx, query, id, other); err != nil {
+			if database.IsForeignKeyViolation(err) {
+				return models.ValidationError(fmt.Errorf("incompatible option %s does not exist", other))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func optionWriteError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("option code is already taken: %w", models.ErrConflict)
+	}
+	return err
+}
+
+// GetCarOptions returns the options fitted to a car and its price breakdown.
+func (s *OptionStore) GetCarOptions(ctx context.Context, carID string) (models.CarOptions, error) {
+	return getCarOptions(ctx, s.db, carID, false)
+}
+
+func getCarOptions(ctx context.Context, q querier, carID string, lock bool) (models.CarOptions, error) {
+	carOptions := models.CarOptions{Options: []models.CarOption{}}
+
+	carQuery := `SELECT id, price, currency FROM cars WHERE id = $1`
+	if lock {
+		carQuery += ` FOR UPDATE`
+	}
+	err := q.QueryRowContext(ctx, carQuery, carID).Scan(&carOptions.CarID, &carOptions.BasePrice, &carOptions.Currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.CarOptions{}, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.CarOptions{}, err
+	}
+
+	query := `
+		SELECT o.id, o.code, o.name, o.category, co.price
+		FROM car_options co
+		JOIN options o ON o.id = co.option_id
+		WHERE co.car_id = $1
+		ORDER BY o.category, o.name
+	`
+	rows, err := q.QueryContext(ctx, query, carID)
+	if err != nil {
+		return models.CarOptions{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option models.CarOption
+		if err := rows.Scan(&option.OptionID, &option.Code, &option.Name, &option.Category, &option.Price); err != nil {
+			return models.CarOptions{}, err
+		}
+		carOptions.Options = append(carOptions.Options, option)
+		carOptions.OptionsTotal += option.Price
+	}
+	if err := rows.Err(); err != nil {
+		return models.CarOptions{}, err
+	}
+
+	carOptions.TotalPrice = carOptions.BasePrice + carOptions.OptionsTotal
+	return carOptions, nil
+}
+
+// SetCarOptions replaces the options fitted to a car. Options the car already
+// had keep their fitted price. The other prices must be in currency, the car's
+// currency when the caller priced them; if the car has since moved to another
+// currency the call fails with ErrConflict.
+func (s *OptionStore) SetCarOptions(ctx context.Context, carID, currency string, options []models.CarOption) (_ models.CarOptions, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CarOptions{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	current, err := getCarOptions(ctx, tx, carID, true)
+	if err != nil {
+		return models.CarOptions{}, err
+	}
+	if current.Currency != currency {
+		err = fmt.Errorf("car is now priced in %s: %w", current.Currency, models.ErrConflict)
+		return models.CarOptions{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM car_options WHERE car_id = $1`, carID); err != nil {
+		return models.CarOptions{}, err
+	}
+
+	fitted := make(map[uuid.UUID]models.Money, len(current.Options))
+	for _, option := range current.Options {
+		fitted[option.OptionID] = option.Price
+	}
+
+	query := `INSERT INTO car_options (car_id, option_id, price) VALUES ($1, $2, $3)`
+	for _, option := range options {
+		price, ok := fitted[option.OptionID]
+		if !ok {
+			price = option.Price
+		}
+		if _, err = tx.ExecContext(ctx, query, carID, option.OptionID, price); err != nil {
+			if database.IsForeignKeyViolation(err) {
+				err = fmt.Errorf("option %s: %w", option.Code, models.ErrNotFound)
+			}
+			return models.CarOptions{}, err
+		}
+	}
+
+	updated, err := getCarOptions(ctx, tx, carID, false)
+	if err != nil {
+		return models.CarOptions{}, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,132 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/outbox"
+)
+
+type OrderStore struct {
+	db *sql.DB
+}
+
+func NewOrderStore(db *sql.DB) *OrderStore {
+	return &OrderStore{db: db}
+}
+
+// CreateOrder records a sale and the car.sold event in one transaction.
+func (s *OrderStore) CreateOrder(ctx context.Context, order *models.Order) (_ models.Order, err error) {
+	discounts, err := json.Marshal(order.Discounts)
+	if err != nil {
+		return models.Order{}, err
+	}
+	taxes, err := json.Marshal(order.Taxes)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	query := `
+		INSERT INTO orders (
+			car_id, buyer_name, buyer_email, buyer_phone, buyer_address,
+			sale_price, discounts, taxes, discount_total, tax_total, total, currency,
+			payment_method, salesperson
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, invoice_number, created_at
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	sold := models.CarSoldPayload{
+		CarID: order.CarID, SalePrice: order.SalePrice, Total: order.Total, Currency: order.Currency,
+	}
+	err = tx.QueryRowContext(ctx, query,
+		order.CarID, order.Buyer.Name, order.Buyer.Email, order.Buyer.Phone, order.Buyer.Address,
+		order.SalePrice, discounts, taxes, order.DiscountTotal, order.TaxTotal, order.Total, order.Currency,
+		order.PaymentMethod, order.Salesperson,
+	).Scan(&sold.OrderID, &sold.InvoiceNumber, &sold.SoldAt)
+	if err != nil {
+		switch {
+		case database.IsUniqueViolation(err):
+			err = fmt.Errorf("car has already been sold: %w", models.ErrConflict)
+		case database.IsForeignKeyViolation(err):
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.Order{}, err
+	}
+
+	err = outbox.RecordEvent(ctx, tx, models.AggregateCar, order.CarID, models.EventCarSold, sold)
+	if err != nil {
+		return models.Order{}, err
+	}
+	return getOrder(ctx, tx, sold.OrderID.String())
+}
+
+func (s *OrderStore) GetOrderByID(ctx context.Context, id string) (models.Order, error) {
+	return getOrder(ctx, s.db, id)
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func getOrder(ctx context.Context, q querier, id string) (models.Order, error) {
+	var order models.Order
+	var car models.Car
+	var discounts, taxes []byte
+
+	query := `
+		SELECT
+			o.id, o.invoice_number, o.car_id, o.buyer_name, o.buyer_email, o.buyer_phone, o.buyer_address,
+			o.sale_price, o.discounts, o.taxes, o.discount_total, o.tax_total, o.total, o.currency,
+			o.payment_method, o.salesperson, o.created_at, o.updated_at,
+			c.name, c.year, b.name, c.fuel_type
+		FROM orders o
+		JOIN cars c ON o.car_id = c.id
+		JOIN brands b ON b.id = c.brand_id
+		WHERE o.id = $1
+	`
+
+	err := q.QueryRowContext(ctx, query, id).Scan(
+		&order.ID, &order.InvoiceNumber, &order.CarID,
+		&order.Buyer.Name, &order.Buyer.Email, &order.Buyer.Phone, &order.Buyer.Address,
+		&order.SalePrice, &discounts, &taxes, &order.DiscountTotal, &order.TaxTotal, &order.Total, &order.Currency,
+		&order.PaymentMethod, &order.Salesperson, &order.CreatedAt, &order.UpdatedAt,
+		&car.Name, &car.Year, &car.Brand, &car.FuelType,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.Order{}, fmt.Errorf("order: %w", models.ErrNotFound)
+		}
+		return models.Order{}, err
+	}
+
+	if err := json.Unmarshal(discounts, &order.Discounts); err != nil {
+		return models.Order{}, err
+	}
+	if err := json.Unmarshal(taxes, &order.Taxes); err != nil {
+		return models.Order{}, err
+	}
+
+	car.ID = order.CarID
+	order.Car = &car
+	return order, nil
+}
@@ -0,0 +1,200 @@
+package outbox
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/eventstream"
+	"github.com/LikhithMar14/management/store/webhook"
+	"github.com/google/uuid"
+)
+
+type OutboxStore struct {
+	db *sql.DB
+}
+
+func NewOutboxStore(db *sql.DB) *OutboxStore {
+	return &OutboxStore{db: db}
+}
+
+// RecordEvent appends an event to the outbox within tx, so it is published if
+// and only if tx commits. Callers record the event after writing the
+// aggregate's row: the row lock then orders concurrent changes to the same
+// aggregate, and their events get increasing IDs in the same order.
+func RecordEvent(ctx context.Context, tx *sql.Tx, aggregateType string, aggregateID uuid.UUID, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+	query := `
+		INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = tx.ExecContext(ctx, query, aggregateType, aggregateID, eventType, data)
+	return err
+}
+
+// DispatchPending hands up to limit events to the sinks inside the database
+// and returns how many it handed over. Queueing webhook deliveries and
+// announcing the event to the stream listeners are only a write and a
+// notification sent on commit, so they happen in the same transaction that
+// marks the events dispatched: an event reaches them exactly once, and the
+// external publisher being down does not hold them back. As with
+// publishing, an aggregate's events are dispatched in order.
+func (s *OutboxStore) DispatchPending(ctx context.Context, limit int) (_ int, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+		SELECT o.id, o.event_type, o.aggregate_type, o.aggregate_id, o.payload, o.created_at
+		FROM outbox o
+		WHERE o.dispatched_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM outbox p
+			WHERE p.aggregate_id = o.aggregate_id AND p.dispatched_at IS NULL AND p.id < o.id
+		)
+		ORDER BY o.id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	events, err := scanEvents(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if _, err = webhook.EnqueueDeliveries(ctx, tx, event); err != nil {
+			return 0, err
+		}
+		if err = eventstream.NotifyEvent(ctx, tx, event.ID); err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE outbox SET dispatched_at = NOW() WHERE id = $1`, event.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(events), nil
+}
+
+// PublishPending claims up to limit events that are due and passes each to
+// publish, marking it published when publish succeeds and scheduling a retry
+// with exponential backoff, capped at an hour, when it fails. Only the oldest
+// unpublished event of each aggregate is claimed, so an aggregate's events
+// are published in order and a failing event holds back the ones after it.
+// Claiming leases the events by moving their next attempt past lease, which
+// keeps other relays off them without holding a transaction open while they
+// are published; should the relay die, they become due again once the lease
+// runs out. It returns the number of events claimed.
+func (s *OutboxStore) PublishPending(ctx context.Context, limit int, lease time.Duration, publish func(context.Context, models.Event) error) (int, error) {
+	events, err := s.claimEvents(ctx, limit, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if publishErr := publish(ctx, event); publishErr != nil {
+			// The exponent is capped before it is raised so the interval
+			// cannot overflow however many attempts have failed
+			_, err = s.db.ExecContext(ctx, `
+				UPDATE outbox
+				SET attempts = attempts + 1, last_error = $2,
+					next_attempt_at = NOW() + INTERVAL '1 second' * LEAST(power(2, LEAST(attempts, 12)), 3600)
+				WHERE id = $1
+			`, event.ID, publishErr.Error())
+		} else {
+			_, err = s.db.ExecContext(ctx, `
+				UPDATE outbox SET attempts = attempts + 1, last_error = NULL, published_at = NOW() WHERE id = $1
+			`, event.ID)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(events), nil
+}
+
+// claimEvents leases the due events at the head of their aggregate's queue,
+// skipping those another relay is claiming, and returns them in ID order.
+func (s *OutboxStore) claimEvents(ctx context.Context, limit int, lease time.Duration) ([]models.Event, error) {
+	query := `
+		WITH due AS (
+			SELECT o.id
+			FROM outbox o
+			WHERE o.published_at IS NULL
+			AND o.next_attempt_at <= NOW()
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.aggregate_id = o.aggregate_id AND p.published_at IS NULL AND p.id < o.id
+			)
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox o
+		SET next_attempt_at = NOW() + $2::bigint * INTERVAL '1 millisecond'
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.event_type, o.aggregate_type, o.aggregate_id, o.payload, o.created_at
+	`
+	rows, err := s.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(events, func(a, b models.Event) int { return cmp.Compare(a.ID, b.ID) })
+	return events, nil
+}
+
+func scanEvents(rows *sql.Rows) ([]models.Event, error) {
+	defer rows.Close()
+
+	var events []models.Event
+	for rows.Next() {
+		var event models.Event
+		var payload []byte
+		err := rows.Scan(&event.ID, &event.Type, &event.AggregateType, &event.AggregateID, &payload, &event.OccurredAt)
+		if err != nil {
+			return nil, err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// PurgePublished deletes the events published before the given time that
+// have also been dispatched.
+func (s *OutboxStore) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM outbox WHERE published_at < $1 AND dispatched_at IS NOT NULL`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
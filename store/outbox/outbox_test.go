@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/storetest"
+	"github.com/google/uuid"
+)
+
+// testDB is the migrated database the tests share.
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	storetest.Main(m)
+}
+
+func newStore(t *testing.T) *OutboxStore {
+	t.Helper()
+	testDB = storetest.DB(t, "outbox", "webhook_subscriptions")
+	return NewOutboxStore(testDB)
+}
+
+func recordEvents(t *testing.T, aggregateID uuid.UUID, n int) {
+	t.Helper()
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	for range n {
+		if err := RecordEvent(context.Background(), tx, models.AggregateCar, aggregateID, models.EventCarUpdated, nil); err != nil {
+			t.Fatalf("RecordEvent: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+}
+
+func TestPublishPendingHoldsBackAggregateAfterFailure(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+	failing, other := uuid.New(), uuid.New()
+	recordEvents(t, failing, 2)
+	recordEvents(t, other, 1)
+
+	var published []uuid.UUID
+	publish := func(ctx context.Context, event models.Event) error {
+		if event.AggregateID == failing {
+			return errors.New("receiver down")
+		}
+		published = append(published, event.AggregateID)
+		return nil
+	}
+
+	n, err := store.PublishPending(ctx, 10, time.Minute, publish)
+	if err != nil {
+		t.Fatalf("PublishPending: %v", err)
+	}
+	if n != 2 || len(published) != 1 || published[0] != other {
+		t.Fatalf("claimed %d and published %v, want the head of each aggregate and only %s published", n, published, other)
+	}
+
+	// The failed event waits for its retry and holds back the one after it
+	if n, err := store.PublishPending(ctx, 10, time.Minute, publish); err != nil || n != 0 {
+		t.Errorf("second PublishPending = %d, %v, want nothing due", n, err)
+	}
+}
+
+func TestPublishPendingCapsBackoff(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+	recordEvents(t, uuid.New(), 1)
+	if _, err := testDB.Exec(`UPDATE outbox SET attempts = 5000`); err != nil {
+		t.Fatalf("set attempts: %v", err)
+	}
+
+	n, err := store.PublishPending(ctx, 10, time.Minute, func(context.Context, models.Event) error {
+		return errors.New("receiver down")
+	})
+	if err != nil || n != 1 {
+		t.Fatalf("PublishPending = %d, %v", n, err)
+	}
+
+	var seconds float64
+	if err := testDB.QueryRow(`SELECT EXTRACT(EPOCH FROM next_attempt_at - NOW()) FROM outbox`).Scan(&seconds); err != nil {
+		t.Fatalf("read next attempt: %v", err)
+	}
+	wait := time.Duration(seconds * float64(time.Second))
+	if wait <= 59*time.Minute || wait > time.Hour {
+		t.Errorf("next attempt in %s, want an hour", wait)
+	}
+}
+
+func TestPublishPendingLeasesClaimedEvents(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+	recordEvents(t, uuid.New(), 1)
+
+	// A second relay finds nothing due while the first is publishing
+	var nested int
+	n, err := store.PublishPending(ctx, 10, time.Minute, func(ctx context.Context, event models.Event) error {
+		var err error
+		nested, err = store.PublishPending(ctx, 10, time.Minute, func(context.Context, models.Event) error { return nil })
+		return err
+	})
+	if err != nil || n != 1 {
+		t.Fatalf("PublishPending = %d, %v", n, err)
+	}
+	if nested != 0 {
+		t.Errorf("concurrent relay claimed %d leased event(s)", nested)
+	}
+}
+
+func TestDispatchPendingQueuesWebhooksWhilePublishingFails(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+	_, err := testDB.Exec(`
+		INSERT INTO webhook_subscriptions (url, event_types, secret)
+		VALUES ('https://example.com/hook', ARRAY['` + models.EventCarUpdated + `'], 'secret')
+	`)
+	if err != nil {
+		t.Fatalf("insert subscription: %v", err)
+	}
+	recordEvents(t, uuid.New(), 2)
+
+	_, err = store.PublishPending(ctx, 10, time.Minute, func(context.Context, models.Event) error {
+		return errors.New("receiver down")
+	})
+	if err != nil {
+		t.Fatalf("PublishPending: %v", err)
+	}
+
+	dispatched := 0
+	for {
+		n, err := store.DispatchPending(ctx, 10)
+		if err != nil {
+			t.Fatalf("DispatchPending: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+		dispatched += n
+	}
+	if dispatched != 2 {
+		t.Errorf("dispatched %d events, want 2", dispatched)
+	}
+
+	var queued int
+	if err := testDB.QueryRow(`SELECT COUNT(*) FROM webhook_deliveries`).Scan(&queued); err != nil {
+		t.Fatalf("count deliveries: %v", err)
+	}
+	if queued != 2 {
+		t.Errorf("queued %d webhook deliveries, want 2", queued)
+	}
+}
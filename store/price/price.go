@@ -0,0 +1,405 @@
+package price
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/outbox"
+	"github.com/google/uuid"
+)
+
+type PriceStore struct {
+	db *sql.DB
+}
+
+func NewPriceStore(db *sql.DB) *PriceStore {
+	return &PriceStore{db: db}
+}
+
+// RecordPriceChange appends a row to price_history within tx. previous is nil
+// for a car's first price. Every later change is also recorded as a
+// car.priced event; the first price is part of the car.created event.
+func RecordPriceChange(ctx context.Context, tx *sql.Tx, carID uuid.UUID, price models.Money, currency string,
+	previous *models.Money, previousCurrency *string, reason string, scheduleID *uuid.UUID) error {
+	change := models.PriceChange{
+		CarID: carID, Price: price, Currency: currency, PreviousPrice: previous,
+		PreviousCurrency: previousCurrency, Reason: reason, ScheduleID: scheduleID,
+	}
+	query := `
+		INSERT INTO price_history (car_id, price, currency, previous_price, previous_currency, reason, schedule_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, changed_at
+	`
+	err := tx.QueryRowContext(ctx, query, carID, price, currency, previous, previousCurrency, reason, scheduleID).
+		Scan(&change.ID, &change.ChangedAt)
+	if err != nil || previous == nil {
+		return err
+	}
+	return outbox.RecordEvent(ctx, tx, models.AggregateCar, carID, models.EventCarPriced, change)
+}
+
+func (s *PriceStore) GetPriceHistory(ctx context.Context, carID string) ([]models.PriceChange, error) {
+	query := `
+		SELECT id, car_id, price, currency, previous_price, previous_currency, reason, schedule_id, changed_at
+		FROM price_history
+		WHERE car_id = $1
+		ORDER BY changed_at, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.PriceChange{}
+	for rows.Next() {
+		var change models.PriceChange
+		err := rows.Scan(
+			&change.ID, &change.CarID, &change.Price, &change.Currency,
+			&change.PreviousPrice, &change.PreviousCurrency, &change.Reason, &change.ScheduleID, &change.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+const scheduleColumns = `
+	id, car_id, price, currency, starts_at, ends_at, status,
+	original_price, original_currency, created_by, created_at, updated_at
+`
+
+func scanSchedule(row interface{ Scan(...any) error }) (models.PriceSchedule, error) {
+	var schedule models.PriceSchedule
+	err := row.Scan(
+		&schedule.ID, &schedule.CarID, &schedule.Price, &schedule.Currency, &schedule.StartsAt, &schedule.EndsAt,
+		&schedule.Status, &schedule.OriginalPrice, &schedule.OriginalCurrency, &schedule.CreatedBy,
+		&schedule.CreatedAt, &schedule.UpdatedAt,
+	)
+	return schedule, err
+}
+
+// ListPriceSchedules returns the pending and active schedules of a car.
+func (s *PriceStore) ListPriceSchedules(ctx context.Context, carID string) ([]models.PriceSchedule, error) {
+	query := `SELECT ` + scheduleColumns + `
+		FROM price_schedules
+		WHERE car_id = $1 AND status IN ('pending', 'active')
+		ORDER BY starts_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			err = fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []models.PriceSchedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// CreatePriceSchedule stores a new pending schedule. It fails with
+// ErrConflict when the window overlaps another open schedule of the car, since
+// two promotions cannot both own the price, or when it would change the
+// currency of a car with options fitted.
+func (s *PriceStore) CreatePriceSchedule(ctx context.Context, carID string, schedule *models.PriceScheduleRequest, createdBy string) (_ models.PriceSchedule, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// Lock the car so concurrent schedules for it are checked one at a time.
+	var currency string
+	err = tx.QueryRowContext(ctx, `SELECT currency FROM cars WHERE id = $1 FOR UPDATE`, carID).Scan(&currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.PriceSchedule{}, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return models.PriceSchedule{}, err
+	}
+
+	if schedule.Currency != currency {
+		var fitted bool
+		if fitted, err = hasOptions(ctx, tx, carID); err != nil {
+			return models.PriceSchedule{}, err
+		}
+		if fitted {
+			err = fmt.Errorf("remove the car's options before scheduling a price in another currency: %w", models.ErrConflict)
+			return models.PriceSchedule{}, err
+		}
+	}
+
+	overlapQuery := `
+		SELECT EXISTS (
+			SELECT 1 FROM price_schedules
+			WHERE car_id = $1 AND status IN ('pending', 'active')
+			AND starts_at < COALESCE($3, 'infinity'::timestamptz)
+			AND COALESCE(ends_at, 'infinity'::timestamptz) > $2
+		)
+	`
+	var overlaps bool
+	err = tx.QueryRowContext(ctx, overlapQuery, carID, schedule.StartsAt, schedule.EndsAt).Scan(&overlaps)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	if overlaps {
+		err = fmt.Errorf("schedule overlaps an existing price schedule: %w", models.ErrConflict)
+		return models.PriceSchedule{}, err
+	}
+
+	query := `
+		INSERT INTO price_schedules (car_id, price, currency, starts_at, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + scheduleColumns
+
+	created, err := scanSchedule(tx.QueryRowContext(ctx, query,
+		carID, schedule.Price, schedule.Currency, schedule.StartsAt, schedule.EndsAt, createdBy))
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	return created, nil
+}
+
+// CancelPriceSchedule cancels a pending schedule. Active schedules cannot be
+// cancelled since their price is already live; edit the car instead.
+func (s *PriceStore) CancelPriceSchedule(ctx context.Context, carID, scheduleID string) error {
+	query := `
+		UPDATE price_schedules
+		SET status = 'cancelled', updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND car_id = $2 AND status = 'pending'
+	`
+	result, err := s.db.ExecContext(ctx, query, scheduleID, carID)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			err = fmt.Errorf("pending price schedule: %w", models.ErrNotFound)
+		}
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pending price schedule: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// ApplyDuePriceSchedules activates every pending schedule whose start has
+// passed, and completes every active schedule whose end has passed by
+// restoring the price it replaced. Schedules without an end are completed as
+// soon as they are applied, so that they do not block later schedules for the
+// car. Schedules whose whole window was missed are completed without touching
+// the car, and schedules that would change the currency of a car that has
+// since had options fitted are cancelled. It returns the number of schedules
+// processed.
+func (s *PriceStore) ApplyDuePriceSchedules(ctx context.Context, now time.Time) (_ int, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	processed := 0
+
+	missedQuery := `
+		UPDATE price_schedules
+		SET status = 'completed', updated_at = CURRENT_TIMESTAMP
+		WHERE status = 'pending' AND ends_at IS NOT NULL AND ends_at <= $1
+	`
+	result, err := tx.ExecContext(ctx, missedQuery, now)
+	if err != nil {
+		return 0, err
+	}
+	missed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	processed += int(missed)
+
+	due, err := lockSchedules(ctx, tx, `status = 'pending' AND starts_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, schedule := range due {
+		if err = applySchedule(ctx, tx, schedule); err != nil {
+			return 0, err
+		}
+		processed++
+	}
+
+	ended, err := lockSchedules(ctx, tx, `status = 'active' AND ends_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, schedule := range ended {
+		if err = revertSchedule(ctx, tx, schedule); err != nil {
+			return 0, err
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func lockSchedules(ctx context.Context, tx *sql.Tx, where string, now time.Time) ([]models.PriceSchedule, error) {
+	query := `SELECT ` + scheduleColumns + `
+		FROM price_schedules
+		WHERE ` + where + `
+		ORDER BY starts_at
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []models.PriceSchedule
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func applySchedule(ctx context.Context, tx *sql.Tx, schedule models.PriceSchedule) error {
+	var original models.Money
+	var originalCurrency string
+	err := tx.QueryRowContext(ctx, `SELECT price, currency FROM cars WHERE id = $1 FOR UPDATE`, schedule.CarID).
+		Scan(&original, &originalCurrency)
+	if err != nil {
+		return err
+	}
+
+	// Fitted options are priced in the car's currency, which only changes once
+	// they have been removed.
+	if schedule.Currency != originalCurrency {
+		fitted, err := hasOptions(ctx, tx, schedule.CarID.String())
+		if err != nil {
+			return err
+		}
+		if fitted {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE price_schedules SET status = 'cancelled', updated_at = CURRENT_TIMESTAMP WHERE id = $1
+			`, schedule.ID)
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE cars SET price = $1, currency = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3
+	`, schedule.Price, schedule.Currency, schedule.CarID)
+	if err != nil {
+		return err
+	}
+
+	// A schedule without an end has no price to restore, so it is done.
+	status := models.PriceScheduleActive
+	if schedule.EndsAt == nil {
+		status = models.PriceScheduleCompleted
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE price_schedules
+		SET status = $1, original_price = $2, original_currency = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`, status, original, originalCurrency, schedule.ID)
+	if err != nil {
+		return err
+	}
+
+	return RecordPriceChange(ctx, tx, schedule.CarID, schedule.Price, schedule.Currency,
+		&original, &originalCurrency, models.PriceChangeScheduled, &schedule.ID)
+}
+
+// revertSchedule restores the price a schedule replaced, unless the car was
+// repriced by hand in the meantime, in which case the manual price wins, or
+// the restored currency would no longer match options fitted since.
+func revertSchedule(ctx context.Context, tx *sql.Tx, schedule models.PriceSchedule) error {
+	var current models.Money
+	var currentCurrency string
+	err := tx.QueryRowContext(ctx, `SELECT price, currency FROM cars WHERE id = $1 FOR UPDATE`, schedule.CarID).
+		Scan(&current, &currentCurrency)
+	if err != nil {
+		return err
+	}
+
+	stillApplied := current == schedule.Price && currentCurrency == schedule.Currency
+	if stillApplied && schedule.OriginalCurrency != nil && *schedule.OriginalCurrency != currentCurrency {
+		fitted, err := hasOptions(ctx, tx, schedule.CarID.String())
+		if err != nil {
+			return err
+		}
+		stillApplied = !fitted
+	}
+	if stillApplied && schedule.OriginalPrice != nil && schedule.OriginalCurrency != nil {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE cars SET price = $1, currency = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3
+		`, *schedule.OriginalPrice, *schedule.OriginalCurrency, schedule.CarID)
+		if err != nil {
+			return err
+		}
+
+		err = RecordPriceChange(ctx, tx, schedule.CarID, *schedule.OriginalPrice, *schedule.OriginalCurrency,
+			&current, &currentCurrency, models.PriceChangeReverted, &schedule.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE price_schedules SET status = 'completed', updated_at = CURRENT_TIMESTAMP WHERE id = $1
+	`, schedule.ID)
+	return err
+}
+
+func hasOptions(ctx context.Context, tx *sql.Tx, carID string) (bool, error) {
+	var fitted bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM car_options WHERE car_id = $1)`, carID).Scan(&fitted)
+	return fitted, err
+}
@@ -0,0 +1,255 @@
+package price
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/storetest"
+	"github.com/google/uuid"
+)
+
+// testDB is the migrated database the tests share.
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	storetest.Main(m)
+}
+
+// base is the start of every test's timeline; schedules run from an hour to
+// two hours after it.
+var base = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func newStore(t *testing.T) *PriceStore {
+	t.Helper()
+	testDB = storetest.DB(t, "cars", "engines", "brands", "options", "outbox")
+	return NewPriceStore(testDB)
+}
+
+func insertCar(t *testing.T, price models.Money, currency string) string {
+	t.Helper()
+	var id string
+	err := testDB.QueryRow(`
+		WITH brand AS (
+			INSERT INTO brands (name) VALUES ('Test')
+			ON CONFLICT (reference_key(name)) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		), engine AS (
+			INSERT INTO engines (displacement, number_of_cylinders, car_range) VALUES (1998, 4, 600)
+			ON CONFLICT ON CONSTRAINT engines_spec_key DO UPDATE SET car_range = EXCLUDED.car_range
+			RETURNING id
+		)
+		INSERT INTO cars (name, year, brand_id, fuel_type, engine_id, price, currency)
+		SELECT 'Test', '2024', brand.id, 'petrol', engine.id, $1, $2 FROM brand, engine
+		RETURNING id
+	`, price, currency).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert car: %v", err)
+	}
+	return id
+}
+
+func fitOption(t *testing.T, carID string) {
+	t.Helper()
+	_, err := testDB.Exec(`
+		WITH option AS (
+			INSERT INTO options (code, name, category, price, currency)
+			VALUES ('SUNROOF', 'Sunroof', 'feature', 500, 'INR')
+			RETURNING id
+		)
+		INSERT INTO car_options (car_id, option_id, price) SELECT $1, id, 500 FROM option
+	`, carID)
+	if err != nil {
+		t.Fatalf("fit option: %v", err)
+	}
+}
+
+func createSchedule(t *testing.T, store *PriceStore, carID string, price models.Money, currency string) models.PriceSchedule {
+	t.Helper()
+	ends := base.Add(2 * time.Hour)
+	schedule, err := store.CreatePriceSchedule(context.Background(), carID, &models.PriceScheduleRequest{
+		Price: price, Currency: currency, StartsAt: base.Add(time.Hour), EndsAt: &ends,
+	}, "tester")
+	if err != nil {
+		t.Fatalf("CreatePriceSchedule: %v", err)
+	}
+	return schedule
+}
+
+func apply(t *testing.T, store *PriceStore, now time.Time, want int) {
+	t.Helper()
+	processed, err := store.ApplyDuePriceSchedules(context.Background(), now)
+	if err != nil {
+		t.Fatalf("ApplyDuePriceSchedules: %v", err)
+	}
+	if processed != want {
+		t.Errorf("processed %d schedules, want %d", processed, want)
+	}
+}
+
+func assertCarPrice(t *testing.T, carID string, wantPrice models.Money, wantCurrency string) {
+	t.Helper()
+	var price models.Money
+	var currency string
+	if err := testDB.QueryRow(`SELECT price, currency FROM cars WHERE id = $1`, carID).Scan(&price, &currency); err != nil {
+		t.Fatalf("read car price: %v", err)
+	}
+	if price != wantPrice || currency != wantCurrency {
+		t.Errorf("car price = %s %s, want %s %s", price, currency, wantPrice, wantCurrency)
+	}
+}
+
+func assertStatus(t *testing.T, scheduleID uuid.UUID, want string) {
+	t.Helper()
+	var status string
+	if err := testDB.QueryRow(`SELECT status FROM price_schedules WHERE id = $1`, scheduleID).Scan(&status); err != nil {
+		t.Fatalf("read schedule status: %v", err)
+	}
+	if status != want {
+		t.Errorf("schedule status = %s, want %s", status, want)
+	}
+}
+
+func reasons(t *testing.T, store *PriceStore, carID string) []string {
+	t.Helper()
+	history, err := store.GetPriceHistory(context.Background(), carID)
+	if err != nil {
+		t.Fatalf("GetPriceHistory: %v", err)
+	}
+	var got []string
+	for _, change := range history {
+		got = append(got, change.Reason)
+	}
+	return got
+}
+
+func TestApplyDuePriceSchedulesActivatesAndReverts(t *testing.T) {
+	store := newStore(t)
+	carID := insertCar(t, 100000, "INR")
+	schedule := createSchedule(t, store, carID, 90000, "INR")
+
+	apply(t, store, base.Add(30*time.Minute), 0)
+	assertStatus(t, schedule.ID, models.PriceSchedulePending)
+
+	apply(t, store, base.Add(90*time.Minute), 1)
+	assertStatus(t, schedule.ID, models.PriceScheduleActive)
+	assertCarPrice(t, carID, 90000, "INR")
+
+	apply(t, store, base.Add(3*time.Hour), 1)
+	assertStatus(t, schedule.ID, models.PriceScheduleCompleted)
+	assertCarPrice(t, carID, 100000, "INR")
+
+	got := reasons(t, store, carID)
+	want := []string{models.PriceChangeScheduled, models.PriceChangeReverted}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("history reasons = %v, want %v", got, want)
+	}
+}
+
+func TestApplyDuePriceSchedulesCompletesMissedWindow(t *testing.T) {
+	store := newStore(t)
+	carID := insertCar(t, 100000, "INR")
+	schedule := createSchedule(t, store, carID, 90000, "INR")
+
+	apply(t, store, base.Add(3*time.Hour), 1)
+	assertStatus(t, schedule.ID, models.PriceScheduleCompleted)
+	assertCarPrice(t, carID, 100000, "INR")
+	if got := reasons(t, store, carID); len(got) != 0 {
+		t.Errorf("history reasons = %v, want none", got)
+	}
+}
+
+func TestApplyDuePriceSchedulesKeepsManualPrice(t *testing.T) {
+	store := newStore(t)
+	carID := insertCar(t, 100000, "INR")
+	schedule := createSchedule(t, store, carID, 90000, "INR")
+
+	apply(t, store, base.Add(90*time.Minute), 1)
+	if _, err := testDB.Exec(`UPDATE cars SET price = 95000 WHERE id = $1`, carID); err != nil {
+		t.Fatalf("reprice car: %v", err)
+	}
+
+	apply(t, store, base.Add(3*time.Hour), 1)
+	assertStatus(t, schedule.ID, models.PriceScheduleCompleted)
+	assertCarPrice(t, carID, 95000, "INR")
+}
+
+func TestApplyDuePriceSchedulesCompletesOpenEndedSchedule(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+	carID := insertCar(t, 100000, "INR")
+
+	open, err := store.CreatePriceSchedule(ctx, carID, &models.PriceScheduleRequest{
+		Price: 90000, Currency: "INR", StartsAt: base.Add(time.Hour),
+	}, "tester")
+	if err != nil {
+		t.Fatalf("CreatePriceSchedule: %v", err)
+	}
+
+	apply(t, store, base.Add(90*time.Minute), 1)
+	assertStatus(t, open.ID, models.PriceScheduleCompleted)
+	assertCarPrice(t, carID, 90000, "INR")
+
+	// The applied schedule no longer holds the rest of time for itself
+	ends := base.Add(4 * time.Hour)
+	_, err = store.CreatePriceSchedule(ctx, carID, &models.PriceScheduleRequest{
+		Price: 85000, Currency: "INR", StartsAt: base.Add(3 * time.Hour), EndsAt: &ends,
+	}, "tester")
+	if err != nil {
+		t.Fatalf("CreatePriceSchedule after open-ended schedule: %v", err)
+	}
+}
+
+func TestCreatePriceScheduleRejectsCurrencyChangeWithOptions(t *testing.T) {
+	store := newStore(t)
+	carID := insertCar(t, 100000, "INR")
+	fitOption(t, carID)
+
+	ends := base.Add(2 * time.Hour)
+	_, err := store.CreatePriceSchedule(context.Background(), carID, &models.PriceScheduleRequest{
+		Price: 1200, Currency: "USD", StartsAt: base.Add(time.Hour), EndsAt: &ends,
+	}, "tester")
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("CreatePriceSchedule error = %v, want ErrConflict", err)
+	}
+}
+
+func TestApplyDuePriceSchedulesCancelsCurrencyChangeWithOptions(t *testing.T) {
+	store := newStore(t)
+	carID := insertCar(t, 100000, "INR")
+	schedule := createSchedule(t, store, carID, 1200, "USD")
+	fitOption(t, carID)
+
+	apply(t, store, base.Add(90*time.Minute), 1)
+	assertStatus(t, schedule.ID, models.PriceScheduleCancelled)
+	assertCarPrice(t, carID, 100000, "INR")
+}
+
+func TestPriceScheduleStatusIsChecked(t *testing.T) {
+	store := newStore(t)
+	carID := insertCar(t, 100000, "INR")
+	schedule := createSchedule(t, store, carID, 90000, "INR")
+
+	_, err := testDB.Exec(`UPDATE price_schedules SET status = 'paused' WHERE id = $1`, schedule.ID)
+	if err == nil {
+		t.Fatal("unknown status was stored")
+	}
+}
+
+func TestMalformedCarIDIsNotFound(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+
+	if _, err := store.GetPriceHistory(ctx, "not-a-uuid"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("GetPriceHistory error = %v, want ErrNotFound", err)
+	}
+	if _, err := store.ListPriceSchedules(ctx, "not-a-uuid"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("ListPriceSchedules error = %v, want ErrNotFound", err)
+	}
+	if err := store.CancelPriceSchedule(ctx, "not-a-uuid", "not-a-uuid"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("CancelPriceSchedule error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,305 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/LikhithMar14/management/models"
+)
+
+type ReportStore struct {
+	db *sql.DB
+}
+
+func NewReportStore(db *sql.DB) *ReportStore {
+	return &ReportStore{db: db}
+}
+
+// stockQuery selects the cars in stock at the end of the day $1: added by
+// then and not sold by then. Their value is the current asking price with
+// options, and their location is where they are now.
+const stockQuery = `
+	WITH stock AS (
+		SELECT
+			c.id, c.year, c.fuel_type::text AS fuel_type, c.currency, c.created_at,
+			b.name AS brand, COALESCE(l.name, 'Unassigned') AS location,
+			c.price + COALESCE((SELECT SUM(co.price) FROM car_options co WHERE co.car_id = c.id), 0) AS value
+		FROM cars c
+		JOIN brands b ON b.id = c.brand_id
+		LEFT JOIN locations l ON l.id = c.location_id
+		WHERE c.created_at < $1::date + 1
+		AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.car_id = c.id AND o.created_at < $1::date + 1)
+	)
+`
+
+// groupColumns are the stock columns each dimension groups by.
+var groupColumns = map[string]string{
+	models.ReportByBrand:    "brand",
+	models.ReportByFuelType: "fuel_type",
+	models.ReportByYear:     "year",
+	models.ReportByLocation: "location",
+}
+
+// reportViews are the materialized views behind the report, refreshed in
+// this order.
+var reportViews = []string{"report_inventory_groups", "report_stock_by_day", "report_daily_sales"}
+
+// totalDimension is the dimension the views file the totals under.
+const totalDimension = "total"
+
+// GetInventoryReport computes every section of the report from one
+// snapshot of the database. The stock on hand comes from the materialized
+// views when the report ends on or after their last refresh; an earlier
+// snapshot is not in the views and is computed from the tables. Sales
+// always come from the views.
+func (s *ReportStore) GetInventoryReport(ctx context.Context, filter models.ReportFilter) (models.InventoryReport, error) {
+	report := models.InventoryReport{From: filter.From, To: filter.To, Interval: filter.Interval}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return models.InventoryReport{}, err
+	}
+	defer tx.Rollback()
+
+	var current bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT MIN(refreshed_at), $1::date >= MIN(refreshed_at)::date FROM report_refreshes
+	`, filter.To).Scan(&report.DataAsOf, &current)
+	if err != nil {
+		return models.InventoryReport{}, err
+	}
+
+	sections := map[string]*[]models.InventoryGroup{
+		totalDimension:          &report.Totals,
+		models.ReportByBrand:    &report.ByBrand,
+		models.ReportByFuelType: &report.ByFuelType,
+		models.ReportByYear:     &report.ByYear,
+		models.ReportByLocation: &report.ByLocation,
+	}
+	if current {
+		if err := viewInventoryGroups(ctx, tx, sections); err != nil {
+			return models.InventoryReport{}, err
+		}
+		if report.Aging, err = viewAging(ctx, tx, filter.To); err != nil {
+			return models.InventoryReport{}, err
+		}
+	} else {
+		for dimension, dest := range sections {
+			column, ok := groupColumns[dimension]
+			if !ok {
+				column = "'all'"
+			}
+			if *dest, err = inventoryGroups(ctx, tx, column, filter.To); err != nil {
+				return models.InventoryReport{}, err
+			}
+		}
+		if report.Aging, err = aging(ctx, tx, filter.To); err != nil {
+			return models.InventoryReport{}, err
+		}
+	}
+
+	if report.SalesVelocity, err = salesVelocity(ctx, tx, filter); err != nil {
+		return models.InventoryReport{}, err
+	}
+	return report, nil
+}
+
+// RefreshViews rebuilds every report view and records when it was done.
+// Each view is refreshed concurrently, so reports keep reading the previous
+// contents until the new ones are ready.
+func (s *ReportStore) RefreshViews(ctx context.Context) ([]models.ReportRefresh, error) {
+	refreshes := make([]models.ReportRefresh, 0, len(reportViews))
+	for _, view := range reportViews {
+		refresh, err := s.refreshView(ctx, view)
+		if err != nil {
+			return nil, fmt.Errorf("refresh %s: %w", view, err)
+		}
+		refreshes = append(refreshes, refresh)
+	}
+	return refreshes, nil
+}
+
+// refreshView refreshes one view in its own transaction. The refresh time
+// is the start of the transaction: the view holds everything committed
+// before then.
+func (s *ReportStore) refreshView(ctx context.Context, view string) (refresh models.ReportRefresh, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ReportRefresh{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+		return models.ReportRefresh{}, err
+	}
+
+	refresh.View = view
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO report_refreshes (view_name, refreshed_at) VALUES ($1, NOW())
+		ON CONFLICT (view_name) DO UPDATE SET refreshed_at = EXCLUDED.refreshed_at
+		RETURNING refreshed_at
+	`, view).Scan(&refresh.RefreshedAt)
+	if err != nil {
+		return models.ReportRefresh{}, err
+	}
+	return refresh, nil
+}
+
+// viewInventoryGroups fills every section with its groups from the
+// report_inventory_groups view.
+func viewInventoryGroups(ctx context.Context, tx *sql.Tx, sections map[string]*[]models.InventoryGroup) error {
+	for _, dest := range sections {
+		*dest = []models.InventoryGroup{}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT dimension, key, currency, count, total_value, average_value
+		FROM report_inventory_groups
+		ORDER BY dimension, key, currency
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dimension string
+		var group models.InventoryGroup
+		if err := rows.Scan(&dimension, &group.Key, &group.Currency, &group.Count, &group.TotalValue, &group.AverageValue); err != nil {
+			return err
+		}
+		if dest, ok := sections[dimension]; ok {
+			*dest = append(*dest, group)
+		}
+	}
+	return rows.Err()
+}
+
+// inventoryGroups counts the stock per value of column and currency.
+func inventoryGroups(ctx context.Context, tx *sql.Tx, column string, asOf models.Date) ([]models.InventoryGroup, error) {
+	query := stockQuery + fmt.Sprintf(`
+		SELECT %s, currency, COUNT(*), SUM(value), ROUND(AVG(value), 2)
+		FROM stock
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`, column)
+
+	rows, err := tx.QueryContext(ctx, query, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []models.InventoryGroup{}
+	for rows.Next() {
+		var group models.InventoryGroup
+		if err := rows.Scan(&group.Key, &group.Currency, &group.Count, &group.TotalValue, &group.AverageValue); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// aging counts the stock per days-in-stock bucket. Every bucket is
+// reported, empty ones with a zero count.
+func aging(ctx context.Context, tx *sql.Tx, asOf models.Date) ([]models.AgingBucket, error) {
+	query := stockQuery + `
+		SELECT width_bucket(days, $2::int[]), COUNT(*), ROUND(AVG(days), 1)
+		FROM (SELECT $1::date - created_at::date AS days FROM stock) aged
+		GROUP BY 1
+	`
+	return agingBuckets(ctx, tx, query, asOf)
+}
+
+// viewAging counts the stock in the report_stock_by_day view per
+// days-in-stock bucket on asOf.
+func viewAging(ctx context.Context, tx *sql.Tx, asOf models.Date) ([]models.AgingBucket, error) {
+	query := `
+		SELECT width_bucket(days, $2::int[]), SUM(count)::bigint, ROUND(SUM(days * count)::numeric / SUM(count), 1)
+		FROM (
+			SELECT $1::date - added_on AS days, count FROM report_stock_by_day WHERE added_on <= $1::date
+		) aged
+		GROUP BY 1
+	`
+	return agingBuckets(ctx, tx, query, asOf)
+}
+
+// agingBuckets runs an aging query, which takes the date and the bucket
+// thresholds and returns the bucket index, count and average days.
+func agingBuckets(ctx context.Context, tx *sql.Tx, query string, asOf models.Date) ([]models.AgingBucket, error) {
+	buckets := make([]models.AgingBucket, len(models.AgingBuckets))
+	copy(buckets, models.AgingBuckets)
+
+	// width_bucket returns 0 below the first threshold, so the thresholds
+	// are the starts of every bucket after the first.
+	thresholds := make([]int64, 0, len(buckets)-1)
+	for _, bucket := range buckets[1:] {
+		thresholds = append(thresholds, int64(bucket.MinDays))
+	}
+
+	rows, err := tx.QueryContext(ctx, query, asOf, thresholds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var index int
+		var count int64
+		var average float64
+		if err := rows.Scan(&index, &count, &average); err != nil {
+			return nil, err
+		}
+		buckets[index].Count = count
+		buckets[index].AverageDays = average
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// salesVelocity counts the cars sold in each window of the period from the
+// report_daily_sales view, including windows without sales.
+func salesVelocity(ctx context.Context, tx *sql.Tx, filter models.ReportFilter) ([]models.SalesWindow, error) {
+	query := `
+		SELECT w.start::date, COALESCE(SUM(s.sales), 0)::bigint,
+			COALESCE(ROUND(SUM(s.days_in_stock) / NULLIF(SUM(s.sales), 0), 1), 0)
+		FROM generate_series(
+			date_trunc($3, $1::timestamp), date_trunc($3, $2::timestamp), ('1 ' || $3)::interval
+		) AS w(start)
+		LEFT JOIN report_daily_sales s
+			ON date_trunc($3, s.day::timestamp) = w.start AND s.day BETWEEN $1::date AND $2::date
+		GROUP BY w.start
+		ORDER BY w.start
+	`
+	rows, err := tx.QueryContext(ctx, query, filter.From, filter.To, filter.Interval)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	windows := []models.SalesWindow{}
+	for rows.Next() {
+		var window models.SalesWindow
+		if err := rows.Scan(&window.Start, &window.Sales, &window.AverageDays); err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
@@ -0,0 +1,339 @@
+package servicerecord
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+type ServiceRecordStore struct {
+	db *sql.DB
+}
+
+func NewServiceRecordStore(db *sql.DB) *ServiceRecordStore {
+	return &ServiceRecordStore{db: db}
+}
+
+const recordColumns = `
+	id, car_id, service_date, odometer_km, work_done, cost, currency, workshop, created_by, created_at, updated_at
+`
+
+func recordFields(record *models.ServiceRecord) []any {
+	return []any{
+		&record.ID, &record.CarID, &record.ServiceDate, &record.OdometerKm, &record.WorkDone,
+		&record.Cost, &record.Currency, &record.Workshop, &record.CreatedBy, &record.CreatedAt, &record.UpdatedAt,
+	}
+}
+
+// ListServiceRecords returns the service history of a car, oldest first.
+func (s *ServiceRecordStore) ListServiceRecords(ctx context.Context, carID string) ([]models.ServiceRecord, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM cars WHERE id = $1)`, carID).Scan(&exists)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return nil, fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("car: %w", models.ErrNotFound)
+	}
+
+	query := `
+		SELECT ` + recordColumns + `
+		FROM service_records
+		WHERE car_id = $1
+		ORDER BY service_date, odometer_km, created_at
+	`
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []models.ServiceRecord{}
+	for rows.Next() {
+		var record models.ServiceRecord
+		if err := rows.Scan(recordFields(&record)...); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (s *ServiceRecordStore) GetServiceRecord(ctx context.Context, carID, id string) (models.ServiceRecord, error) {
+	var record models.ServiceRecord
+	query := `SELECT ` + recordColumns + ` FROM service_records WHERE id = $1 AND car_id = $2`
+
+	err := s.db.QueryRowContext(ctx, query, id, carID).Scan(recordFields(&record)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.ServiceRecord{}, fmt.Errorf("service record: %w", models.ErrNotFound)
+		}
+		return models.ServiceRecord{}, err
+	}
+	return record, nil
+}
+
+func (s *ServiceRecordStore) CreateServiceRecord(ctx context.Context, carID string, record *models.ServiceRecordRequest, createdBy string) (_ models.ServiceRecord, err error) {
+	var created models.ServiceRecord
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ServiceRecord{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = lockCar(ctx, tx, carID); err != nil {
+		return models.ServiceRecord{}, err
+	}
+	if err = checkOdometer(ctx, tx, carID, uuid.Nil, record); err != nil {
+		return models.ServiceRecord{}, err
+	}
+
+	query := `
+		INSERT INTO service_records (car_id, service_date, odometer_km, work_done, cost, currency, workshop, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + recordColumns
+
+	err = tx.QueryRowContext(ctx, query,
+		carID, record.ServiceDate, record.OdometerKm, record.WorkDone, record.Cost, record.Currency, record.Workshop, createdBy,
+	).Scan(recordFields(&created)...)
+	if err != nil {
+		return models.ServiceRecord{}, err
+	}
+	return created, nil
+}
+
+func (s *ServiceRecordStore) UpdateServiceRecord(ctx context.Context, carID, id string, record *models.ServiceRecordRequest) (_ models.ServiceRecord, err error) {
+	var updated models.ServiceRecord
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ServiceRecord{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = lockCar(ctx, tx, carID); err != nil {
+		return models.ServiceRecord{}, err
+	}
+
+	recordID, err := uuid.Parse(id)
+	if err != nil {
+		err = fmt.Errorf("service record: %w", models.ErrNotFound)
+		return models.ServiceRecord{}, err
+	}
+	if err = checkOdometer(ctx, tx, carID, recordID, record); err != nil {
+		return models.ServiceRecord{}, err
+	}
+
+	query := `
+		UPDATE service_records
+		SET service_date = $1, odometer_km = $2, work_done = $3, cost = $4, currency = $5, workshop = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7 AND car_id = $8
+		RETURNING ` + recordColumns
+
+	err = tx.QueryRowContext(ctx, query,
+		record.ServiceDate, record.OdometerKm, record.WorkDone, record.Cost, record.Currency, record.Workshop, recordID, carID,
+	).Scan(recordFields(&updated)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("service record: %w", models.ErrNotFound)
+		}
+		return models.ServiceRecord{}, err
+	}
+	return updated, nil
+}
+
+func (s *ServiceRecordStore) DeleteServiceRecord(ctx context.Context, carID, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM service_records WHERE id = $1 AND car_id = $2`, id, carID)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("service record: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("service record: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// lockCar serializes writes to a car's service history so that concurrent
+// records cannot break the odometer order between them.
+func lockCar(ctx context.Context, tx *sql.Tx, carID string) error {
+	var id uuid.UUID
+	err := tx.QueryRowContext(ctx, `SELECT id FROM cars WHERE id = $1 FOR UPDATE`, carID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return fmt.Errorf("car: %w", models.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkOdometer rejects a reading lower than one recorded on an earlier date
+// or higher than one recorded on a later date. Services on the same day may
+// be logged in any order. except is the record being edited.
+func checkOdometer(ctx context.Context, tx *sql.Tx, carID string, except uuid.UUID, record *models.ServiceRecordRequest) error {
+	query := `
+		SELECT
+			(SELECT MAX(odometer_km) FROM service_records WHERE car_id = $1 AND id <> $3 AND service_date < $2),
+			(SELECT MIN(odometer_km) FROM service_records WHERE car_id = $1 AND id <> $3 AND service_date > $2)
+	`
+
+	var floor, ceiling *int64
+	if err := tx.QueryRowContext(ctx, query, carID, record.ServiceDate, except).Scan(&floor, &ceiling); err != nil {
+		return err
+	}
+	if floor != nil && record.OdometerKm < *floor {
+		return models.ValidationError(fmt.Errorf("odometer must be at least %d km, the reading of an earlier service", *floor))
+	}
+	if ceiling != nil && record.OdometerKm > *ceiling {
+		return models.ValidationError(fmt.Errorf("odometer must be at most %d km, the reading of a later service", *ceiling))
+	}
+	return nil
+}
+
+func (s *ServiceRecordStore) ListServiceIntervals(ctx context.Context) ([]models.ServiceInterval, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT powertrain, interval_km, interval_months, updated_at
+		FROM service_intervals
+		ORDER BY powertrain
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	intervals := []models.ServiceInterval{}
+	for rows.Next() {
+		var interval models.ServiceInterval
+		if err := rows.Scan(&interval.Powertrain, &interval.IntervalKm, &interval.IntervalMonths, &interval.UpdatedAt); err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, interval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return intervals, nil
+}
+
+func (s *ServiceRecordStore) SetServiceInterval(ctx context.Context, interval *models.ServiceInterval) (models.ServiceInterval, error) {
+	query := `
+		INSERT INTO service_intervals (powertrain, interval_km, interval_months)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (powertrain) DO UPDATE
+		SET interval_km = EXCLUDED.interval_km, interval_months = EXCLUDED.interval_months, updated_at = CURRENT_TIMESTAMP
+		RETURNING powertrain, interval_km, interval_months, updated_at
+	`
+
+	var saved models.ServiceInterval
+	err := s.db.QueryRowContext(ctx, query, interval.Powertrain, interval.IntervalKm, interval.IntervalMonths).
+		Scan(&saved.Powertrain, &saved.IntervalKm, &saved.IntervalMonths, &saved.UpdatedAt)
+	if err != nil {
+		return models.ServiceInterval{}, err
+	}
+	return saved, nil
+}
+
+// dueQuery computes the next service of every unsold car from its latest
+// record and the interval of its powertrain. A car without records counts
+// from the day it was added, at 0 km. The current reading is the car's
+// odometer or its latest service reading, whichever is higher.
+const dueQuery = `
+	WITH latest AS (
+		SELECT DISTINCT ON (car_id) car_id, service_date, odometer_km
+		FROM service_records
+		ORDER BY car_id, service_date DESC, odometer_km DESC
+	), due AS (
+		SELECT
+			c.id, c.name, b.name AS brand, e.powertrain,
+			l.service_date, l.odometer_km,
+			GREATEST(c.odometer_km, COALESCE(l.odometer_km, 0)) AS current_odometer_km,
+			(COALESCE(l.service_date, c.created_at::date) + make_interval(months => si.interval_months))::date AS next_due_date,
+			COALESCE(l.odometer_km, 0) + si.interval_km AS next_due_odometer_km
+		FROM cars c
+		JOIN brands b ON b.id = c.brand_id
+		JOIN engines e ON e.id = c.engine_id
+		JOIN service_intervals si ON si.powertrain = e.powertrain
+		LEFT JOIN latest l ON l.car_id = c.id
+		WHERE NOT EXISTS (SELECT 1 FROM orders o WHERE o.car_id = c.id)
+	)
+`
+
+// ListServicesDue lists cars whose next service falls on or before
+// filter.Before or whose mileage has reached it, soonest first.
+func (s *ServiceRecordStore) ListServicesDue(ctx context.Context, filter models.ServiceDueFilter) (models.Page[models.ServiceDue], error) {
+	where := `WHERE (next_due_date <= $1 OR current_odometer_km >= next_due_odometer_km) AND ($2 = '' OR powertrain = $2)`
+	args := []any{filter.Before, filter.Powertrain}
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, dueQuery+`SELECT COUNT(*) FROM due `+where, args...).Scan(&total); err != nil {
+		return models.Page[models.ServiceDue]{}, err
+	}
+
+	query := dueQuery + `
+		SELECT id, name, brand, powertrain, service_date, odometer_km, current_odometer_km,
+			next_due_date, next_due_odometer_km,
+			next_due_date < $3 OR current_odometer_km >= next_due_odometer_km
+		FROM due
+		` + where + `
+		ORDER BY next_due_date, name, id
+		LIMIT $4 OFFSET $5
+	`
+	rows, err := s.db.QueryContext(ctx, query, append(args, filter.AsOf, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return models.Page[models.ServiceDue]{}, err
+	}
+	defer rows.Close()
+
+	due := []models.ServiceDue{}
+	for rows.Next() {
+		var car models.ServiceDue
+		err := rows.Scan(
+			&car.CarID, &car.Name, &car.Brand, &car.Powertrain, &car.LastServiceDate, &car.LastOdometerKm, &car.OdometerKm,
+			&car.NextDueDate, &car.NextDueOdometerKm, &car.Overdue,
+		)
+		if err != nil {
+			return models.Page[models.ServiceDue]{}, err
+		}
+		due = append(due, car)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.ServiceDue]{}, err
+	}
+
+	return models.Page[models.ServiceDue]{Items: due, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
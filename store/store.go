@@ -3,35 +3,204 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/LikhithMar14/management/models"
+	"github.com/LikhithMar14/management/store/attachment"
+	"github.com/LikhithMar14/management/store/brand"
 	"github.com/LikhithMar14/management/store/car"
+	"github.com/LikhithMar14/management/store/editing"
 	"github.com/LikhithMar14/management/store/engine"
+	"github.com/LikhithMar14/management/store/eventstream"
+	"github.com/LikhithMar14/management/store/exchangerate"
+	"github.com/LikhithMar14/management/store/location"
+	"github.com/LikhithMar14/management/store/option"
+	"github.com/LikhithMar14/management/store/order"
+	"github.com/LikhithMar14/management/store/outbox"
+	"github.com/LikhithMar14/management/store/price"
+	"github.com/LikhithMar14/management/store/report"
+	"github.com/LikhithMar14/management/store/servicerecord"
+	"github.com/LikhithMar14/management/store/webhook"
+	"github.com/google/uuid"
 )
 
 type Storage struct {
 	CarStore CarStoreInterface
 	EngineStore EngineStoreInterface
+	OrderStore OrderStoreInterface
+	ExchangeRateStore ExchangeRateStoreInterface
+	PriceStore PriceStoreInterface
+	BrandStore BrandStoreInterface
+	OptionStore OptionStoreInterface
+	AttachmentStore AttachmentStoreInterface
+	LocationStore LocationStoreInterface
+	ServiceRecordStore ServiceRecordStoreInterface
+	ReportStore ReportStoreInterface
+	OutboxStore OutboxStoreInterface
+	WebhookStore WebhookStoreInterface
+	EventStreamStore EventStreamStoreInterface
+	EditingStore EditingStoreInterface
 }
 
 type CarStoreInterface interface {
 	GetCarByID(ctx context.Context, id string) (models.Car, error)
-	GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error)
+	GetCarsByBrand(ctx context.Context, filter models.CarFilter) ([]models.Car, error)
 	CreateCar(ctx context.Context, car *models.CarRequest) (models.Car, error)
 	UpdateCar(ctx context.Context, id string, car *models.CarRequest) (models.Car, error)
 	DeleteCar(ctx context.Context, id string) error
+	GetCarsByEngineID(ctx context.Context, engineID string) ([]models.Car, error)
+	GetCarsByLocation(ctx context.Context, locationID string) ([]models.Car, error)
+	GetCarsByIDs(ctx context.Context, ids []string) ([]models.Car, error)
+	GetInspection(ctx context.Context, carID string) (models.Inspection, error)
+	SetInspection(ctx context.Context, carID string, inspection *models.InspectionRequest, inspector string) (models.Inspection, error)
+	DeleteInspection(ctx context.Context, carID string) error
 }
 
 type EngineStoreInterface interface {
 	GetEngineByID(ctx context.Context, id string) (models.Engine, error)
+	GetEnginesByIDs(ctx context.Context, ids []string) ([]models.Engine, error)
 	CreateEngine(ctx context.Context, engine *models.EngineRequest) (models.Engine, error)
 	UpdateEngine(ctx context.Context, id string, engine *models.EngineRequest) (models.Engine, error)
 	DeleteEngine(ctx context.Context, id string) error
+	ListEngines(ctx context.Context, filter models.EngineFilter) (models.Page[models.Engine], error)
+	GetEngineUsage(ctx context.Context, filter models.EngineFilter) (models.Page[models.EngineUsage], error)
+}
+
+type OrderStoreInterface interface {
+	GetOrderByID(ctx context.Context, id string) (models.Order, error)
+	CreateOrder(ctx context.Context, order *models.Order) (models.Order, error)
+}
+
+type ExchangeRateStoreInterface interface {
+	ListExchangeRates(ctx context.Context) ([]models.ExchangeRate, error)
+	GetExchangeRate(ctx context.Context, base, quote string, asOf time.Time) (models.ExchangeRate, error)
+	UpsertExchangeRates(ctx context.Context, rates []models.ExchangeRate) ([]models.ExchangeRate, error)
+}
+
+type PriceStoreInterface interface {
+	GetPriceHistory(ctx context.Context, carID string) ([]models.PriceChange, error)
+	ListPriceSchedules(ctx context.Context, carID string) ([]models.PriceSchedule, error)
+	CreatePriceSchedule(ctx context.Context, carID string, schedule *models.PriceScheduleRequest, createdBy string) (models.PriceSchedule, error)
+	CancelPriceSchedule(ctx context.Context, carID, scheduleID string) error
+	ApplyDuePriceSchedules(ctx context.Context, now time.Time) (int, error)
+}
+
+type BrandStoreInterface interface {
+	ListBrands(ctx context.Context) ([]models.Brand, error)
+	GetBrandByID(ctx context.Context, id string) (models.Brand, error)
+	CreateBrand(ctx context.Context, brand *models.BrandRequest) (models.Brand, error)
+	UpdateBrand(ctx context.Context, id string, brand *models.BrandRequest) (models.Brand, error)
+	DeleteBrand(ctx context.Context, id string) error
+	ListModels(ctx context.Context, brandID string) ([]models.VehicleModel, error)
+	GetModelByID(ctx context.Context, brandID, id string) (models.VehicleModel, error)
+	CreateModel(ctx context.Context, brandID string, model *models.VehicleModelRequest) (models.VehicleModel, error)
+	UpdateModel(ctx context.Context, brandID, id string, model *models.VehicleModelRequest) (models.VehicleModel, error)
+	DeleteModel(ctx context.Context, brandID, id string) error
+	ListTrims(ctx context.Context, brandID, modelID string) ([]models.Trim, error)
+	CreateTrim(ctx context.Context, brandID, modelID string, trim *models.TrimRequest) (models.Trim, error)
+	UpdateTrim(ctx context.Context, brandID, modelID, id string, trim *models.TrimRequest) (models.Trim, error)
+	DeleteTrim(ctx context.Context, brandID, modelID, id string) error
+}
+
+type OptionStoreInterface interface {
+	ListOptions(ctx context.Context, category string) ([]models.Option, error)
+	GetOptionByID(ctx context.Context, id string) (models.Option, error)
+	CreateOption(ctx context.Context, option *models.OptionRequest) (models.Option, error)
+	UpdateOption(ctx context.Context, id string, option *models.OptionRequest) (models.Option, error)
+	DeleteOption(ctx context.Context, id string) error
+	GetCarOptions(ctx context.Context, carID string) (models.CarOptions, error)
+	SetCarOptions(ctx context.Context, carID, currency string, options []models.CarOption) (models.CarOptions, error)
+}
+
+type AttachmentStoreInterface interface {
+	CreateAttachment(ctx context.Context, attachment *models.Attachment) (models.Attachment, error)
+	ListAttachments(ctx context.Context, carID string) ([]models.Attachment, error)
+	GetAttachmentByID(ctx context.Context, id string) (models.Attachment, error)
+	DeleteAttachment(ctx context.Context, carID, id string) (models.Attachment, error)
+}
+
+type LocationStoreInterface interface {
+	ListLocations(ctx context.Context) ([]models.Location, error)
+	GetLocationByID(ctx context.Context, id string) (models.Location, error)
+	CreateLocation(ctx context.Context, location *models.LocationRequest) (models.Location, error)
+	UpdateLocation(ctx context.Context, id string, location *models.LocationRequest) (models.Location, error)
+	DeleteLocation(ctx context.Context, id string) error
+	ListTransfers(ctx context.Context, filter models.TransferFilter) (models.Page[models.Transfer], error)
+	GetTransferByID(ctx context.Context, id string) (models.Transfer, error)
+	CreateTransfer(ctx context.Context, transfer *models.TransferRequest, requestedBy string) (models.Transfer, error)
+	ReceiveTransfer(ctx context.Context, id, receivedBy string) (models.Transfer, error)
+	CancelTransfer(ctx context.Context, id, cancelledBy string) (models.Transfer, error)
+}
+
+type ServiceRecordStoreInterface interface {
+	ListServiceRecords(ctx context.Context, carID string) ([]models.ServiceRecord, error)
+	GetServiceRecord(ctx context.Context, carID, id string) (models.ServiceRecord, error)
+	CreateServiceRecord(ctx context.Context, carID string, record *models.ServiceRecordRequest, createdBy string) (models.ServiceRecord, error)
+	UpdateServiceRecord(ctx context.Context, carID, id string, record *models.ServiceRecordRequest) (models.ServiceRecord, error)
+	DeleteServiceRecord(ctx context.Context, carID, id string) error
+	ListServiceIntervals(ctx context.Context) ([]models.ServiceInterval, error)
+	SetServiceInterval(ctx context.Context, interval *models.ServiceInterval) (models.ServiceInterval, error)
+	ListServicesDue(ctx context.Context, filter models.ServiceDueFilter) (models.Page[models.ServiceDue], error)
+}
+
+type ReportStoreInterface interface {
+	GetInventoryReport(ctx context.Context, filter models.ReportFilter) (models.InventoryReport, error)
+	RefreshViews(ctx context.Context) ([]models.ReportRefresh, error)
+}
+
+type OutboxStoreInterface interface {
+	DispatchPending(ctx context.Context, limit int) (int, error)
+	PublishPending(ctx context.Context, limit int, lease time.Duration, publish func(context.Context, models.Event) error) (int, error)
+	PurgePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type WebhookStoreInterface interface {
+	ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetWebhookByID(ctx context.Context, id string) (models.WebhookSubscription, error)
+	CreateWebhook(ctx context.Context, webhook *models.WebhookRequest, createdBy string) (models.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id string, webhook *models.WebhookRequest) (models.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	CreateTestDelivery(ctx context.Context, subscriptionID string, payload []byte, lease time.Duration) (models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt models.WebhookAttempt, status string, nextAttemptAt time.Time) error
+	ListDeliveries(ctx context.Context, subscriptionID string, filter models.WebhookDeliveryFilter) (models.Page[models.WebhookDelivery], error)
+	GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error)
+}
+
+type EventStreamStoreInterface interface {
+	GetStreamEvent(ctx context.Context, eventID int64) (models.StreamEvent, error)
+	Listen(ctx context.Context, ready func(), notify func(payload string)) error
+}
+
+type EditingStoreInterface interface {
+	Join(ctx context.Context, sessionID, carID uuid.UUID, username string, ttl time.Duration) error
+	Leave(ctx context.Context, sessionID, carID uuid.UUID) error
+	LeaveAll(ctx context.Context, sessionID uuid.UUID) error
+	Touch(ctx context.Context, sessionIDs []uuid.UUID, ttl time.Duration) error
+	AcquireLock(ctx context.Context, sessionID, carID uuid.UUID, username string, ttl time.Duration) (models.CarEditLock, bool, error)
+	ReleaseLock(ctx context.Context, sessionID, carID uuid.UUID) error
+	GetPresence(ctx context.Context, carID uuid.UUID) (models.CarPresence, error)
+	PurgeExpired(ctx context.Context) (int, error)
+	Listen(ctx context.Context, ready func(), notify func(payload string)) error
 }
 
 func NewStorage(db *sql.DB) *Storage {
 	return &Storage{	
 		CarStore: car.NewCarStore(db),
 		EngineStore: engine.NewEngineStore(db),
+		OrderStore: order.NewOrderStore(db),
+		ExchangeRateStore: exchangerate.NewExchangeRateStore(db),
+		PriceStore: price.NewPriceStore(db),
+		BrandStore: brand.NewBrandStore(db),
+		OptionStore: option.NewOptionStore(db),
+		AttachmentStore: attachment.NewAttachmentStore(db),
+		LocationStore: location.NewLocationStore(db),
+		ServiceRecordStore: servicerecord.NewServiceRecordStore(db),
+		ReportStore: report.NewReportStore(db),
+		OutboxStore: outbox.NewOutboxStore(db),
+		WebhookStore: webhook.NewWebhookStore(db),
+		EventStreamStore: eventstream.NewEventStreamStore(db),
+		EditingStore: editing.NewEditingStore(db),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,140 @@
+// Package storetest runs store integration tests against a migrated Postgres
+// database.
+package storetest
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LikhithMar14/management/migrations"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// db is the database shared by a package's tests, or nil when no Postgres is
+// available, in which case the tests skip.
+var db *sql.DB
+
+// Main runs a package's tests. It connects to TEST_DATABASE_URL when set.
+// Otherwise it launches a throwaway cluster with the initdb and pg_ctl
+// binaries found in PG_BIN or on PATH, and removes it afterwards.
+func Main(m *testing.M) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	var stop func()
+	if dsn == "" {
+		var err error
+		dsn, stop, err = startPostgres()
+		if err != nil {
+			log.Printf("skipping integration tests: %v", err)
+		}
+	}
+
+	if dsn != "" {
+		conn, err := sql.Open("pgx", dsn)
+		if err != nil {
+			log.Fatalf("open test database: %v", err)
+		}
+		goose.SetBaseFS(migrations.FS)
+		if err := goose.Up(conn, "."); err != nil {
+			log.Fatalf("migrate test database: %v", err)
+		}
+		db = conn
+	}
+
+	code := m.Run()
+
+	if db != nil {
+		db.Close()
+	}
+	if stop != nil {
+		stop()
+	}
+	os.Exit(code)
+}
+
+// DB returns the test database with the given tables emptied, skipping the
+// test when there is no database.
+func DB(t *testing.T, tables ...string) *sql.DB {
+	t.Helper()
+	if db == nil {
+		t.Skip("no Postgres available: set TEST_DATABASE_URL or put initdb and pg_ctl on PATH")
+	}
+	if len(tables) > 0 {
+		if _, err := db.Exec(`TRUNCATE ` + strings.Join(tables, ", ") + ` CASCADE`); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+	}
+	return db
+}
+
+func startPostgres() (string, func(), error) {
+	initdb, err := findPGBinary("initdb")
+	if err != nil {
+		return "", nil, err
+	}
+	pgCtl, err := findPGBinary("pg_ctl")
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "store-pg-")
+	if err != nil {
+		return "", nil, err
+	}
+	dataDir := filepath.Join(dir, "data")
+
+	out, err := exec.Command(initdb, "-D", dataDir, "-U", "postgres", "-A", "trust", "--no-sync").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("initdb: %v: %s", err, out)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses='' -c fsync=off", port, dir)
+	out, err = exec.Command(pgCtl, "-D", dataDir, "-o", options, "-w", "-l", filepath.Join(dir, "log"), "start").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("pg_ctl start: %v: %s", err, out)
+	}
+
+	stop := func() {
+		exec.Command(pgCtl, "-D", dataDir, "-m", "immediate", "stop").Run()
+		os.RemoveAll(dir)
+	}
+	dsn := fmt.Sprintf("host=%s port=%d user=postgres dbname=postgres sslmode=disable", dir, port)
+	return dsn, stop, nil
+}
+
+func findPGBinary(name string) (string, error) {
+	if bin := os.Getenv("PG_BIN"); bin != "" {
+		path := filepath.Join(bin, name)
+		if _, err := os.Stat(path); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+	return exec.LookPath(name)
+}
+
+// freePort picks a port number for the server. The socket only lives in a
+// temporary directory, but Postgres still derives its file name from the port.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,408 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LikhithMar14/management/database"
+	"github.com/LikhithMar14/management/models"
+	"github.com/google/uuid"
+)
+
+type WebhookStore struct {
+	db *sql.DB
+}
+
+func NewWebhookStore(db *sql.DB) *WebhookStore {
+	return &WebhookStore{db: db}
+}
+
+// stringList scans the JSON array the queries below turn text arrays into.
+type stringList struct {
+	dest *[]string
+}
+
+func (l stringList) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unexpected event types type %T", src)
+	}
+	return json.Unmarshal(data, l.dest)
+}
+
+// webhookColumns leaves out the secret, which is only read for sending.
+const webhookColumns = `
+	w.id, w.url, to_json(w.event_types), w.description, w.active, w.created_by, w.created_at, w.updated_at
+`
+
+func webhookFields(webhook *models.WebhookSubscription) []any {
+	return []any{
+		&webhook.ID, &webhook.URL, stringList{&webhook.EventTypes}, &webhook.Description, &webhook.Active,
+		&webhook.CreatedBy, &webhook.CreatedAt, &webhook.UpdatedAt,
+	}
+}
+
+func (s *WebhookStore) ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions w ORDER BY w.created_at, w.id`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []models.WebhookSubscription{}
+	for rows.Next() {
+		var webhook models.WebhookSubscription
+		if err := rows.Scan(webhookFields(&webhook)...); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookStore) GetWebhookByID(ctx context.Context, id string) (models.WebhookSubscription, error) {
+	var webhook models.WebhookSubscription
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions w WHERE w.id = $1`
+
+	err := s.db.QueryRowContext(ctx, query, id).Scan(webhookFields(&webhook)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.WebhookSubscription{}, fmt.Errorf("webhook: %w", models.ErrNotFound)
+		}
+		return models.WebhookSubscription{}, err
+	}
+	return webhook, nil
+}
+
+// CreateWebhook stores a subscription. The service fills in the secret.
+func (s *WebhookStore) CreateWebhook(ctx context.Context, webhook *models.WebhookRequest, createdBy string) (models.WebhookSubscription, error) {
+	var created models.WebhookSubscription
+	query := `
+		INSERT INTO webhook_subscriptions AS w (url, event_types, description, active, secret, created_by)
+		VALUES ($1, $2::text[], $3, $4, $5, $6)
+		RETURNING ` + webhookColumns
+
+	err := s.db.QueryRowContext(ctx, query,
+		webhook.URL, webhook.EventTypes, webhook.Description, *webhook.Active, webhook.Secret, createdBy,
+	).Scan(webhookFields(&created)...)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	return created, nil
+}
+
+// UpdateWebhook replaces a subscription, keeping its secret unless a new one
+// is given.
+func (s *WebhookStore) UpdateWebhook(ctx context.Context, id string, webhook *models.WebhookRequest) (models.WebhookSubscription, error) {
+	var updated models.WebhookSubscription
+	query := `
+		UPDATE webhook_subscriptions AS w
+		SET url = $1, event_types = $2::text[], description = $3, active = $4,
+			secret = COALESCE(NULLIF($5, ''), w.secret), updated_at = CURRENT_TIMESTAMP
+		WHERE w.id = $6
+		RETURNING ` + webhookColumns
+
+	err := s.db.QueryRowContext(ctx, query,
+		webhook.URL, webhook.EventTypes, webhook.Description, *webhook.Active, webhook.Secret, id,
+	).Scan(webhookFields(&updated)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.WebhookSubscription{}, fmt.Errorf("webhook: %w", models.ErrNotFound)
+		}
+		return models.WebhookSubscription{}, err
+	}
+	return updated, nil
+}
+
+// DeleteWebhook removes a subscription with its delivery log.
+func (s *WebhookStore) DeleteWebhook(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return fmt.Errorf("webhook: %w", models.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// EnqueueDeliveries queues an event for every active subscription to its
+// type within tx. An event that was already queued is skipped, so relaying it
+// again does not deliver it twice.
+func EnqueueDeliveries(ctx context.Context, tx *sql.Tx, event models.Event) (int64, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT w.id, $1, $2::text, $3
+		FROM webhook_subscriptions w
+		WHERE w.active AND $2::text = ANY(w.event_types)
+		ON CONFLICT (subscription_id, event_id) WHERE event_id IS NOT NULL DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, query, event.ID, event.Type, payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deliveryColumns = `
+	d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	CASE WHEN d.status = 'pending' THEN d.next_attempt_at END,
+	d.last_status_code, d.last_error, d.delivered_at, d.created_at, d.updated_at
+`
+
+func scanDelivery(row interface{ Scan(...any) error }, extra ...any) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var payload []byte
+	fields := []any{
+		&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+		&delivery.LastStatusCode, &delivery.LastError, &delivery.DeliveredAt, &delivery.CreatedAt, &delivery.UpdatedAt,
+	}
+	if err := row.Scan(append(fields, extra...)...); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	delivery.Payload = payload
+	return delivery, nil
+}
+
+// ClaimDueDeliveries takes up to limit pending deliveries that are due, with
+// the URL and secret of their subscription, and leases them by moving their
+// next attempt past the lease. That keeps other dispatchers off them while
+// they are sent; should the sender die, they become due again once the
+// lease runs out.
+func (s *WebhookStore) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhook_subscriptions w ON w.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW() AND w.active
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + $2::bigint * INTERVAL '1 millisecond'
+		FROM due, webhook_subscriptions w
+		WHERE d.id = due.id AND w.id = d.subscription_id
+		RETURNING ` + deliveryColumns + `, w.url, w.secret`
+
+	rows, err := s.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var url, secret string
+		delivery, err := scanDelivery(rows, &url, &secret)
+		if err != nil {
+			return nil, err
+		}
+		delivery.URL, delivery.Secret = url, secret
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CreateTestDelivery logs a test fire to a subscription and returns it with
+// the URL and secret for sending. It is leased like a claimed delivery so
+// the dispatcher leaves it to the caller.
+func (s *WebhookStore) CreateTestDelivery(ctx context.Context, subscriptionID string, payload []byte, lease time.Duration) (models.WebhookDelivery, error) {
+	query := `
+		INSERT INTO webhook_deliveries AS d (subscription_id, event_type, payload, next_attempt_at)
+		SELECT w.id, $2, $3, NOW() + $4::bigint * INTERVAL '1 millisecond'
+		FROM webhook_subscriptions w
+		WHERE w.id = $1
+		RETURNING ` + deliveryColumns + `,
+			(SELECT url FROM webhook_subscriptions WHERE id = d.subscription_id),
+			(SELECT secret FROM webhook_subscriptions WHERE id = d.subscription_id)`
+
+	var url, secret string
+	delivery, err := scanDelivery(s.db.QueryRowContext(ctx, query, subscriptionID, models.EventWebhookTest, payload, lease.Milliseconds()), &url, &secret)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.WebhookDelivery{}, fmt.Errorf("webhook: %w", models.ErrNotFound)
+		}
+		return models.WebhookDelivery{}, err
+	}
+	delivery.URL, delivery.Secret = url, secret
+	return delivery, nil
+}
+
+// RecordAttempt logs an attempt and moves the delivery to status. A pending
+// delivery is tried again at nextAttemptAt.
+func (s *WebhookStore) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt models.WebhookAttempt, status string, nextAttemptAt time.Time) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_attempts (delivery_id, attempted_at, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)
+	`, deliveryID, attempt.AttemptedAt, attempt.StatusCode, attempt.Error, attempt.DurationMs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2::text, attempts = attempts + 1, next_attempt_at = $3,
+			last_status_code = $4, last_error = NULLIF($5::text, ''),
+			delivered_at = CASE WHEN $2::text = 'delivered' THEN $6::timestamp END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, deliveryID, status, nextAttemptAt, attempt.StatusCode, attempt.Error, attempt.AttemptedAt)
+	return err
+}
+
+// ListDeliveries returns a subscription's deliveries, newest first.
+func (s *WebhookStore) ListDeliveries(ctx context.Context, subscriptionID string, filter models.WebhookDeliveryFilter) (models.Page[models.WebhookDelivery], error) {
+	if _, err := s.GetWebhookByID(ctx, subscriptionID); err != nil {
+		return models.Page[models.WebhookDelivery]{}, err
+	}
+
+	args := []any{subscriptionID}
+	var where strings.Builder
+	where.WriteString(`WHERE d.subscription_id = $1`)
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		fmt.Fprintf(&where, ` AND d.status = $%d`, len(args))
+	}
+
+	var total int64
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_deliveries d `+where.String(), args...).Scan(&total)
+	if err != nil {
+		return models.Page[models.WebhookDelivery]{}, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM webhook_deliveries d
+		%s
+		ORDER BY d.created_at DESC, d.id
+		LIMIT $%d OFFSET $%d
+	`, deliveryColumns, where.String(), len(args)+1, len(args)+2)
+
+	rows, err := s.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return models.Page[models.WebhookDelivery]{}, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return models.Page[models.WebhookDelivery]{}, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.WebhookDelivery]{}, err
+	}
+	return models.Page[models.WebhookDelivery]{Items: deliveries, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// GetDelivery returns a delivery of a subscription with every attempt made.
+func (s *WebhookStore) GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries d WHERE d.id = $1 AND d.subscription_id = $2`
+
+	delivery, err := scanDelivery(s.db.QueryRowContext(ctx, query, deliveryID, subscriptionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || database.IsInvalidInput(err) {
+			return models.WebhookDelivery{}, fmt.Errorf("delivery: %w", models.ErrNotFound)
+		}
+		return models.WebhookDelivery{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT attempted_at, status_code, error, duration_ms
+		FROM webhook_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempted_at, id
+	`, delivery.ID)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	defer rows.Close()
+
+	delivery.AttemptLog = []models.WebhookAttempt{}
+	for rows.Next() {
+		var attempt models.WebhookAttempt
+		if err := rows.Scan(&attempt.AttemptedAt, &attempt.StatusCode, &attempt.Error, &attempt.DurationMs); err != nil {
+			return models.WebhookDelivery{}, err
+		}
+		delivery.AttemptLog = append(delivery.AttemptLog, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// RetryDelivery puts a dead-lettered delivery back in the queue with a fresh
+// set of attempts.
+func (s *WebhookStore) RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = CURRENT_TIMESTAMP
+		WHERE d.id = $1 AND d.subscription_id = $2 AND d.status = 'dead'
+		RETURNING ` + deliveryColumns
+
+	delivery, err := scanDelivery(s.db.QueryRowContext(ctx, query, deliveryID, subscriptionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		// Either there is no such delivery or it is not dead.
+		if _, err := s.GetDelivery(ctx, subscriptionID, deliveryID); err != nil {
+			return models.WebhookDelivery{}, err
+		}
+		return models.WebhookDelivery{}, fmt.Errorf("only dead deliveries can be retried: %w", models.ErrConflict)
+	}
+	if err != nil {
+		if database.IsInvalidInput(err) {
+			return models.WebhookDelivery{}, fmt.Errorf("delivery: %w", models.ErrNotFound)
+		}
+		return models.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}